@@ -1,11 +1,14 @@
 package client
 
 import (
+	"io"
+
 	"github.com/xabinapal/gopve/pkg/request"
 )
 
 type Client interface {
 	Request(method, resource string, form request.Values, out interface{}) error
+	Websocket(resource string, form request.Values) (io.ReadWriteCloser, error)
 	StartAtomicBlock()
 	EndAtomicBlock()
 }
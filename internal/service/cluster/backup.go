@@ -0,0 +1,307 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/types/cluster"
+	"github.com/xabinapal/gopve/pkg/types/node"
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type getBackupJobResponseJSON struct {
+	ID       string         `json:"id"`
+	Enabled  *types.PVEBool `json:"enabled"`
+	Schedule string         `json:"schedule"`
+	Node     string         `json:"node"`
+	Comment  string         `json:"comment"`
+
+	VMID    string        `json:"vmid"`
+	Pool    string        `json:"pool"`
+	All     types.PVEBool `json:"all"`
+	Exclude string        `json:"exclude"`
+
+	Mode        vm.BackupMode        `json:"mode"`
+	Compression vm.BackupCompression `json:"compress"`
+	Storage     string               `json:"storage"`
+
+	NotesTemplate string        `json:"notes-template"`
+	Protected     types.PVEBool `json:"protected"`
+
+	MailTo           string                    `json:"mailto"`
+	MailNotification vm.BackupMailNotification `json:"mailnotification"`
+
+	Retention vm.BackupRetention `json:"prune-backups"`
+
+	BandwidthLimit uint           `json:"bwlimit"`
+	RemoveOld      *types.PVEBool `json:"remove"`
+}
+
+func (res getBackupJobResponseJSON) Map(
+	svc *Service,
+) (cluster.BackupJob, error) {
+	vmids, err := splitVMIDs(res.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := splitVMIDs(res.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var mailTo []string
+
+	if res.MailTo != "" {
+		list := types.PVEList{Separator: ","}
+		if err := list.Unmarshal(res.MailTo); err != nil {
+			return nil, err
+		}
+
+		mailTo = list.List()
+	}
+
+	var removeOld *bool
+	if res.RemoveOld != nil {
+		removeOld = new(bool)
+		*removeOld = res.RemoveOld.Bool()
+	}
+
+	return &BackupJob{
+		svc: svc,
+		id:  res.ID,
+
+		props: cluster.BackupJobProperties{
+			Enabled:  res.Enabled == nil || res.Enabled.Bool(),
+			Schedule: res.Schedule,
+			Node:     res.Node,
+			Comment:  res.Comment,
+
+			Selection: vm.BackupSelection{
+				VMIDs:   vmids,
+				Pool:    res.Pool,
+				All:     res.All.Bool(),
+				Exclude: exclude,
+			},
+
+			Options: vm.BackupOptions{
+				Mode:        res.Mode,
+				Compression: res.Compression,
+				Storage:     res.Storage,
+
+				NotesTemplate: res.NotesTemplate,
+				Protected:     res.Protected.Bool(),
+
+				MailTo:           mailTo,
+				MailNotification: res.MailNotification,
+
+				Retention: res.Retention,
+
+				BandwidthLimit: res.BandwidthLimit,
+				RemoveOld:      removeOld,
+			},
+		},
+	}, nil
+}
+
+func (svc *Service) ListBackupJobs() ([]cluster.BackupJob, error) {
+	var res []getBackupJobResponseJSON
+	if err := svc.client.Request(http.MethodGet, "cluster/backup", nil, &res); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]cluster.BackupJob, len(res))
+
+	for i, job := range res {
+		out, err := job.Map(svc)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs[i] = out
+	}
+
+	return jobs, nil
+}
+
+func (svc *Service) GetBackupJob(id string) (cluster.BackupJob, error) {
+	var res getBackupJobResponseJSON
+	if err := svc.client.Request(http.MethodGet, fmt.Sprintf("cluster/backup/%s", id), nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Map(svc)
+}
+
+func (svc *Service) CreateBackupJob(
+	id string,
+	props cluster.BackupJobProperties,
+) (cluster.BackupJob, error) {
+	if id == "" {
+		return nil, fmt.Errorf("backup job id is required")
+	}
+
+	form, err := props.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	form.AddString("id", id)
+
+	if err := svc.client.Request(http.MethodPost, "cluster/backup", form, nil); err != nil {
+		return nil, err
+	}
+
+	return svc.GetBackupJob(id)
+}
+
+type getNotBackedUpResponseJSON struct {
+	VMID uint    `json:"vmid"`
+	Name string  `json:"name"`
+	Kind vm.Kind `json:"type"`
+}
+
+func (svc *Service) ListNotBackedUp() ([]cluster.NotBackedUpGuest, error) {
+	var res []getNotBackedUpResponseJSON
+	if err := svc.client.Request(http.MethodGet, "cluster/backup-info/not-backed-up", nil, &res); err != nil {
+		return nil, err
+	}
+
+	guests := make([]cluster.NotBackedUpGuest, len(res))
+
+	for i, guest := range res {
+		guests[i] = cluster.NotBackedUpGuest{
+			VMID: guest.VMID,
+			Name: guest.Name,
+			Kind: guest.Kind,
+		}
+	}
+
+	return guests, nil
+}
+
+type BackupJob struct {
+	svc *Service
+	id  string
+
+	props cluster.BackupJobProperties
+}
+
+func (obj *BackupJob) ID() string {
+	return obj.id
+}
+
+func (obj *BackupJob) GetProperties() (cluster.BackupJobProperties, error) {
+	return obj.props, nil
+}
+
+func (obj *BackupJob) SetProperties(props cluster.BackupJobProperties) error {
+	form, err := props.MapToUpdateValues(obj.props)
+	if err != nil {
+		return err
+	}
+
+	if err := obj.svc.client.Request(http.MethodPut, fmt.Sprintf("cluster/backup/%s", obj.id), form, nil); err != nil {
+		return err
+	}
+
+	obj.props = props
+
+	return nil
+}
+
+func (obj *BackupJob) Run() ([]task.Task, error) {
+	selections := make(map[string]vm.BackupSelection)
+
+	if len(obj.props.Selection.VMIDs) != 0 {
+		for _, vmid := range obj.props.Selection.VMIDs {
+			virtualMachine, err := obj.svc.api.VirtualMachine().Get(vmid)
+			if err != nil {
+				return nil, err
+			}
+
+			nodeName := virtualMachine.Node()
+			if obj.props.Node != "" && obj.props.Node != nodeName {
+				continue
+			}
+
+			selection := selections[nodeName]
+			selection.VMIDs = append(selection.VMIDs, vmid)
+			selections[nodeName] = selection
+		}
+	} else if obj.props.Node != "" {
+		selections[obj.props.Node] = obj.props.Selection
+	} else {
+		nodes, err := obj.svc.api.Node().List()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range nodes {
+			if n.Status() == node.StatusOnline {
+				selections[n.Name()] = obj.props.Selection
+			}
+		}
+	}
+
+	nodeNames := make([]string, 0, len(selections))
+	for nodeName := range selections {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	sort.Strings(nodeNames)
+
+	tasks := make([]task.Task, len(nodeNames))
+
+	for i, nodeName := range nodeNames {
+		t, err := obj.svc.api.VirtualMachine().Backup(
+			nodeName,
+			selections[nodeName],
+			obj.props.Options,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks[i] = t
+	}
+
+	return tasks, nil
+}
+
+func (obj *BackupJob) Delete() error {
+	return obj.svc.client.Request(
+		http.MethodDelete,
+		fmt.Sprintf("cluster/backup/%s", obj.id),
+		nil,
+		nil,
+	)
+}
+
+func splitVMIDs(s string) ([]uint, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	list := types.PVEList{Separator: ","}
+	if err := list.Unmarshal(s); err != nil {
+		return nil, err
+	}
+
+	vmids := make([]uint, list.Len())
+
+	for i, vmid := range list.List() {
+		n, err := strconv.ParseUint(vmid, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		vmids[i] = uint(n)
+	}
+
+	return vmids, nil
+}
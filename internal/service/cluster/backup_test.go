@@ -0,0 +1,328 @@
+package cluster_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/cluster/test"
+	node "github.com/xabinapal/gopve/internal/service/node/test"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	vm "github.com/xabinapal/gopve/internal/service/vm/test"
+	"github.com/xabinapal/gopve/pkg/types/cluster"
+	node_types "github.com/xabinapal/gopve/pkg/types/node"
+	task_types "github.com/xabinapal/gopve/pkg/types/task"
+	vm_types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestClusterServiceBackupJobs(t *testing.T) {
+	svc, _, exc := test.NewService()
+
+	expectedDailyProperties := cluster.BackupJobProperties{
+		Enabled:  true,
+		Schedule: "21:00",
+
+		Selection: vm_types.BackupSelection{
+			All:     true,
+			Exclude: []uint{100, 101},
+		},
+
+		Options: vm_types.BackupOptions{
+			Mode:             vm_types.BackupModeSnapshot,
+			Compression:      vm_types.BackupCompressionZStandard,
+			Storage:          "local",
+			NotesTemplate:    "{{guestname}}",
+			MailTo:           []string{"root@example.com"},
+			MailNotification: vm_types.BackupMailNotificationFailure,
+			Retention: vm_types.BackupRetention{
+				KeepDaily:  7,
+				KeepWeekly: 4,
+			},
+		},
+	}
+
+	t.Run("List", func(t *testing.T) {
+		response, err := ioutil.ReadFile("./testdata/get_cluster_backup.json")
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "cluster/backup", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		jobs, err := svc.ListBackupJobs()
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+
+		assert.Equal(t, "backup-daily", jobs[0].ID())
+
+		props, err := jobs[0].GetProperties()
+		require.NoError(t, err)
+		assert.Equal(t, expectedDailyProperties, props)
+
+		assert.Equal(t, "backup-weekly", jobs[1].ID())
+
+		removeOld := false
+
+		props, err = jobs[1].GetProperties()
+		require.NoError(t, err)
+		assert.Equal(t, cluster.BackupJobProperties{
+			Enabled:  true,
+			Schedule: "sat 02:00",
+			Node:     "test_node",
+
+			Selection: vm_types.BackupSelection{
+				VMIDs: []uint{100, 102},
+			},
+
+			Options: vm_types.BackupOptions{
+				Mode: vm_types.BackupModeStop,
+				Retention: vm_types.BackupRetention{
+					KeepLast: 3,
+				},
+				RemoveOld: &removeOld,
+			},
+		}, props)
+
+		values, err := props.MapToValues()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0"}, values["remove"])
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		response, err := ioutil.ReadFile(
+			"./testdata/get_cluster_backup_{id}.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodPost, "cluster/backup", url.Values{
+				"id":               {"backup-daily"},
+				"enabled":          {"1"},
+				"schedule":         {"21:00"},
+				"all":              {"1"},
+				"exclude":          {"100,101"},
+				"mode":             {"snapshot"},
+				"compress":         {"zstd"},
+				"storage":          {"local"},
+				"notes-template":   {"{{guestname}}"},
+				"mailto":           {"root@example.com"},
+				"mailnotification": {"failure"},
+				"prune-backups":    {"keep-daily=7,keep-weekly=4"},
+			}).
+			Return([]byte("{\"data\":null}"), nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodGet, "cluster/backup/backup-daily", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		job, err := svc.CreateBackupJob("backup-daily", expectedDailyProperties)
+		require.NoError(t, err)
+		assert.Equal(t, "backup-daily", job.ID())
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("CreateWithoutSchedule", func(t *testing.T) {
+		_, err := svc.CreateBackupJob("backup-daily", cluster.BackupJobProperties{
+			Selection: vm_types.BackupSelection{All: true},
+		})
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("SetProperties", func(t *testing.T) {
+		response, err := ioutil.ReadFile(
+			"./testdata/get_cluster_backup_{id}.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "cluster/backup/backup-daily", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodPut, "cluster/backup/backup-daily", url.Values{
+				"enabled":  {"0"},
+				"schedule": {"22:30"},
+				"pool":     {"test_pool"},
+				"mode":     {"snapshot"},
+				"storage":  {"local"},
+				"delete":   {"all,compress,exclude,mailnotification,mailto,notes-template,prune-backups"},
+			}).
+			Return([]byte("{\"data\":null}"), nil).
+			Once()
+
+		job, err := svc.GetBackupJob("backup-daily")
+		require.NoError(t, err)
+
+		err = job.SetProperties(cluster.BackupJobProperties{
+			Enabled:  false,
+			Schedule: "22:30",
+
+			Selection: vm_types.BackupSelection{
+				Pool: "test_pool",
+			},
+
+			Options: vm_types.BackupOptions{
+				Mode:    vm_types.BackupModeSnapshot,
+				Storage: "local",
+			},
+		})
+		require.NoError(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		response, err := ioutil.ReadFile(
+			"./testdata/get_cluster_backup_{id}.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "cluster/backup/backup-daily", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodDelete, "cluster/backup/backup-daily", url.Values(nil)).
+			Return([]byte("{\"data\":null}"), nil).
+			Once()
+
+		job, err := svc.GetBackupJob("backup-daily")
+		require.NoError(t, err)
+
+		require.NoError(t, job.Delete())
+
+		exc.AssertExpectations(t)
+	})
+}
+
+func TestClusterServiceRunBackupJob(t *testing.T) {
+	expectedTask, _, _ := task.NewTask(
+		"test_node",
+		"::",
+		"vzdump",
+		"",
+		"root@pam",
+		"",
+	)
+
+	t.Run("AllNodes", func(t *testing.T) {
+		svc, api, exc := test.NewService()
+
+		response, err := ioutil.ReadFile(
+			"./testdata/get_cluster_backup_{id}.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "cluster/backup/backup-daily", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		testNode, _ := node.NewNode()
+
+		api.NodeService.
+			On("List").
+			Return([]node_types.Node{testNode}, nil).
+			Once()
+
+		job, err := svc.GetBackupJob("backup-daily")
+		require.NoError(t, err)
+
+		props, err := job.GetProperties()
+		require.NoError(t, err)
+
+		api.VirtualMachineService.
+			On("Backup", "test_node", props.Selection, props.Options).
+			Return(expectedTask, nil).
+			Once()
+
+		tasks, err := job.Run()
+		require.NoError(t, err)
+		assert.Equal(t, []task_types.Task{expectedTask}, tasks)
+
+		exc.AssertExpectations(t)
+		api.NodeService.AssertExpectations(t)
+		api.VirtualMachineService.AssertExpectations(t)
+	})
+
+	t.Run("VMIDs", func(t *testing.T) {
+		svc, api, exc := test.NewService()
+
+		response, err := ioutil.ReadFile("./testdata/get_cluster_backup.json")
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "cluster/backup", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		virtualMachine, _, _ := vm.NewVirtualMachine()
+
+		api.VirtualMachineService.
+			On("Get", uint(100)).
+			Return(virtualMachine, nil).
+			Once()
+
+		api.VirtualMachineService.
+			On("Get", uint(102)).
+			Return(virtualMachine, nil).
+			Once()
+
+		jobs, err := svc.ListBackupJobs()
+		require.NoError(t, err)
+
+		props, err := jobs[1].GetProperties()
+		require.NoError(t, err)
+
+		api.VirtualMachineService.
+			On("Backup", "test_node", vm_types.BackupSelection{
+				VMIDs: []uint{100, 102},
+			}, props.Options).
+			Return(expectedTask, nil).
+			Once()
+
+		tasks, err := jobs[1].Run()
+		require.NoError(t, err)
+		assert.Equal(t, []task_types.Task{expectedTask}, tasks)
+
+		exc.AssertExpectations(t)
+		api.VirtualMachineService.AssertExpectations(t)
+	})
+}
+
+func TestClusterServiceNotBackedUp(t *testing.T) {
+	svc, _, exc := test.NewService()
+
+	response, err := ioutil.ReadFile(
+		"./testdata/get_cluster_backup-info_not-backed-up.json",
+	)
+	require.NoError(t, err)
+
+	exc.
+		On("Request", http.MethodGet, "cluster/backup-info/not-backed-up", url.Values(nil)).
+		Return(response, nil).
+		Once()
+
+	guests, err := svc.ListNotBackedUp()
+	require.NoError(t, err)
+
+	assert.Equal(t, []cluster.NotBackedUpGuest{
+		{VMID: 103, Name: "test_qemu", Kind: vm_types.KindQEMU},
+		{VMID: 104, Name: "test_lxc", Kind: vm_types.KindLXC},
+	}, guests)
+
+	exc.AssertExpectations(t)
+}
@@ -0,0 +1,56 @@
+package cluster
+
+import (
+	"sort"
+
+	"github.com/xabinapal/gopve/pkg/types/node"
+	"github.com/xabinapal/gopve/pkg/types/task"
+)
+
+func (svc *Service) forEachOnlineNode(
+	fn func(n node.Node) (task.Task, error),
+) ([]task.Task, error) {
+	nodes, err := svc.api.Node().List()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name() < nodes[j].Name()
+	})
+
+	var tasks []task.Task
+
+	for _, n := range nodes {
+		if n.Status() != node.StatusOnline {
+			continue
+		}
+
+		t, err := fn(n)
+		if err != nil {
+			return tasks, err
+		}
+
+		tasks = append(tasks, t)
+	}
+
+	return tasks, nil
+}
+
+func (svc *Service) StartAll(opts node.StartAllOptions) ([]task.Task, error) {
+	return svc.forEachOnlineNode(func(n node.Node) (task.Task, error) {
+		return n.StartAll(opts)
+	})
+}
+
+func (svc *Service) StopAll(opts node.StopAllOptions) ([]task.Task, error) {
+	return svc.forEachOnlineNode(func(n node.Node) (task.Task, error) {
+		return n.StopAll(opts)
+	})
+}
+
+func (svc *Service) ShutdownAll(opts node.StopAllOptions) ([]task.Task, error) {
+	return svc.forEachOnlineNode(func(n node.Node) (task.Task, error) {
+		return n.ShutdownAll(opts)
+	})
+}
@@ -0,0 +1,64 @@
+package cluster_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/cluster/test"
+	node_service "github.com/xabinapal/gopve/internal/service/node"
+	node "github.com/xabinapal/gopve/internal/service/node/test"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	node_types "github.com/xabinapal/gopve/pkg/types/node"
+	task_types "github.com/xabinapal/gopve/pkg/types/task"
+)
+
+func TestServiceBulk(t *testing.T) {
+	svc, api, _ := test.NewService()
+
+	nodeSvc, nodeAPI, nodeExc := node.NewServiceWithAPI()
+	onlineNode := node_service.NewNode(
+		nodeSvc,
+		"test_node",
+		node_types.StatusOnline,
+	)
+	offlineNode := node_service.NewNode(
+		nodeSvc,
+		"offline_node",
+		node_types.StatusOffline,
+	)
+
+	api.NodeService.
+		On("List").
+		Return([]node_types.Node{onlineNode, offlineNode}, nil)
+
+	expectedTask, _, _ := task.NewTask(
+		"test_node",
+		"::",
+		"stopall",
+		"",
+		"root@pam",
+		"",
+	)
+
+	nodeAPI.TaskService.
+		On("Get", "UPID:test_node::::stopall::root@pam:").
+		Return(expectedTask, nil)
+
+	nodeExc.
+		On("Request", http.MethodPost, "nodes/test_node/stopall", url.Values{
+			"force-stop": {"0"},
+			"timeout":    {"120"},
+		}).
+		Return([]byte("{\"data\":\"UPID:test_node::::stopall::root@pam:\"}"), nil).
+		Once()
+
+	tasks, err := svc.ShutdownAll(node_types.StopAllOptions{Timeout: 120})
+	require.NoError(t, err)
+	assert.Equal(t, []task_types.Task{expectedTask}, tasks)
+
+	nodeExc.AssertExpectations(t)
+	api.NodeService.AssertExpectations(t)
+}
@@ -0,0 +1,250 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type getReplicationJobResponseJSON struct {
+	ID        string        `json:"id"`
+	Guest     uint          `json:"guest"`
+	JobNumber uint          `json:"jobnum"`
+	Target    string        `json:"target"`
+	Schedule  string        `json:"schedule"`
+	Rate      float64       `json:"rate"`
+	Comment   string        `json:"comment"`
+	Disable   types.PVEBool `json:"disable"`
+}
+
+func (res getReplicationJobResponseJSON) Map(svc *Service) vm.ReplicationJob {
+	return &ReplicationJob{
+		svc:    svc,
+		id:     res.ID,
+		vmid:   res.Guest,
+		jobnum: res.JobNumber,
+
+		props: vm.ReplicationJobProperties{
+			Target:    res.Target,
+			Schedule:  res.Schedule,
+			RateLimit: res.Rate,
+			Comment:   res.Comment,
+			Disabled:  res.Disable.Bool(),
+		},
+	}
+}
+
+func (svc *Service) ListReplicationJobs() ([]vm.ReplicationJob, error) {
+	var res []getReplicationJobResponseJSON
+	if err := svc.client.Request(http.MethodGet, "cluster/replication", nil, &res); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]vm.ReplicationJob, len(res))
+	for i, job := range res {
+		jobs[i] = job.Map(svc)
+	}
+
+	return jobs, nil
+}
+
+func (svc *Service) GetReplicationJob(id string) (vm.ReplicationJob, error) {
+	var res getReplicationJobResponseJSON
+	if err := svc.client.Request(http.MethodGet, fmt.Sprintf("cluster/replication/%s", id), nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Map(svc), nil
+}
+
+// CreateReplicationJob adds a job for the guest with the lowest job number
+// not used by its other jobs.
+func (svc *Service) CreateReplicationJob(
+	vmid uint,
+	props vm.ReplicationJobProperties,
+) (vm.ReplicationJob, error) {
+	form, err := props.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := svc.ListReplicationJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[uint]bool)
+	for _, job := range jobs {
+		if job.VMID() == vmid {
+			used[job.JobNumber()] = true
+		}
+	}
+
+	var jobnum uint
+	for used[jobnum] {
+		jobnum++
+	}
+
+	id := fmt.Sprintf("%d-%d", vmid, jobnum)
+
+	form.AddString("id", id)
+	form.AddString("type", "local")
+
+	if err := svc.client.Request(http.MethodPost, "cluster/replication", form, nil); err != nil {
+		return nil, err
+	}
+
+	return svc.GetReplicationJob(id)
+}
+
+type ReplicationJob struct {
+	svc    *Service
+	id     string
+	vmid   uint
+	jobnum uint
+
+	props vm.ReplicationJobProperties
+}
+
+func (obj *ReplicationJob) ID() string {
+	return obj.id
+}
+
+func (obj *ReplicationJob) VMID() uint {
+	return obj.vmid
+}
+
+func (obj *ReplicationJob) JobNumber() uint {
+	return obj.jobnum
+}
+
+func (obj *ReplicationJob) GetProperties() (vm.ReplicationJobProperties, error) {
+	return obj.props, nil
+}
+
+func (obj *ReplicationJob) SetProperties(props vm.ReplicationJobProperties) error {
+	form, err := props.MapToUpdateValues(obj.props)
+	if err != nil {
+		return err
+	}
+
+	if err := obj.svc.client.Request(http.MethodPut, fmt.Sprintf("cluster/replication/%s", obj.id), form, nil); err != nil {
+		return err
+	}
+
+	obj.props = props
+
+	return nil
+}
+
+func (obj *ReplicationJob) sourcePath(path string) (string, error) {
+	virtualMachine, err := obj.svc.api.VirtualMachine().Get(obj.vmid)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"nodes/%s/replication/%s/%s",
+		virtualMachine.Node(),
+		obj.id,
+		path,
+	), nil
+}
+
+type getReplicationStatusResponseJSON struct {
+	LastSync  int64   `json:"last_sync"`
+	LastTry   int64   `json:"last_try"`
+	NextSync  int64   `json:"next_sync"`
+	Duration  float64 `json:"duration"`
+	FailCount uint    `json:"fail_count"`
+	Error     string  `json:"error"`
+	PID       uint    `json:"pid"`
+}
+
+func (obj *ReplicationJob) GetStatus() (vm.ReplicationStatus, error) {
+	path, err := obj.sourcePath("status")
+	if err != nil {
+		return vm.ReplicationStatus{}, err
+	}
+
+	var res getReplicationStatusResponseJSON
+	if err := obj.svc.client.Request(http.MethodGet, path, nil, &res); err != nil {
+		return vm.ReplicationStatus{}, err
+	}
+
+	return vm.ReplicationStatus{
+		LastSync:  replicationTime(res.LastSync),
+		LastTry:   replicationTime(res.LastTry),
+		NextSync:  replicationTime(res.NextSync),
+		Duration:  time.Duration(res.Duration * float64(time.Second)),
+		FailCount: res.FailCount,
+		Error:     res.Error,
+		Running:   res.PID != 0,
+	}, nil
+}
+
+type getReplicationLogResponseJSON struct {
+	LineNumber int    `json:"n"`
+	Contents   string `json:"t"`
+}
+
+func (obj *ReplicationJob) GetLog(
+	opts vm.GetReplicationLogOptions,
+) (vm.ReplicationLogEntries, error) {
+	path, err := obj.sourcePath("log")
+	if err != nil {
+		return nil, err
+	}
+
+	form := make(request.Values)
+
+	form.ConditionalAddUint("start", opts.LineStart, opts.LineStart != 0)
+	form.ConditionalAddUint("limit", opts.LineLimit, opts.LineLimit != 0)
+
+	var res []getReplicationLogResponseJSON
+	if err := obj.svc.client.Request(http.MethodGet, path, form, &res); err != nil {
+		return nil, err
+	}
+
+	entries := make(vm.ReplicationLogEntries)
+	for _, entry := range res {
+		entries[entry.LineNumber] = entry.Contents
+	}
+
+	return entries, nil
+}
+
+func (obj *ReplicationJob) ScheduleNow() error {
+	path, err := obj.sourcePath("schedule_now")
+	if err != nil {
+		return err
+	}
+
+	return obj.svc.client.Request(http.MethodPost, path, nil, nil)
+}
+
+func (obj *ReplicationJob) Delete(opts vm.DeleteReplicationJobOptions) error {
+	form, err := opts.MapToValues()
+	if err != nil {
+		return err
+	}
+
+	return obj.svc.client.Request(
+		http.MethodDelete,
+		fmt.Sprintf("cluster/replication/%s", obj.id),
+		form,
+		nil,
+	)
+}
+
+func replicationTime(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(timestamp, 0).UTC()
+}
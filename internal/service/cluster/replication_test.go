@@ -0,0 +1,217 @@
+package cluster_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/cluster/test"
+	vm "github.com/xabinapal/gopve/internal/service/vm/test"
+	vm_types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestClusterServiceReplicationJobs(t *testing.T) {
+	svc, api, exc := test.NewService()
+
+	listResponse, err := ioutil.ReadFile("./testdata/get_cluster_replication.json")
+	require.NoError(t, err)
+
+	jobResponse, err := ioutil.ReadFile("./testdata/get_cluster_replication_{id}.json")
+	require.NoError(t, err)
+
+	expectedProperties := vm_types.ReplicationJobProperties{
+		Target:    "test_node_2",
+		Schedule:  "*/5",
+		RateLimit: 12.5,
+		Comment:   "zfs mirror",
+	}
+
+	t.Run("List", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodGet, "cluster/replication", url.Values(nil)).
+			Return(listResponse, nil).
+			Once()
+
+		jobs, err := svc.ListReplicationJobs()
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+
+		assert.Equal(t, "100-0", jobs[0].ID())
+		assert.Equal(t, uint(100), jobs[0].VMID())
+		assert.Equal(t, uint(0), jobs[0].JobNumber())
+
+		props, err := jobs[0].GetProperties()
+		require.NoError(t, err)
+		assert.Equal(t, expectedProperties, props)
+
+		assert.Equal(t, "101-1", jobs[1].ID())
+
+		props, err = jobs[1].GetProperties()
+		require.NoError(t, err)
+		assert.Equal(t, vm_types.ReplicationJobProperties{
+			Target:   "test_node_2",
+			Disabled: true,
+		}, props)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodGet, "cluster/replication", url.Values(nil)).
+			Return(listResponse, nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodPost, "cluster/replication", url.Values{
+				"id":     {"100-1"},
+				"type":   {"local"},
+				"target": {"test_node_2"},
+				"rate":   {"1.5"},
+			}).
+			Return([]byte("{\"data\":null}"), nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodGet, "cluster/replication/100-1", url.Values(nil)).
+			Return(jobResponse, nil).
+			Once()
+
+		_, err := svc.CreateReplicationJob(100, vm_types.ReplicationJobProperties{
+			Target:    "test_node_2",
+			RateLimit: 1.5,
+		})
+		require.NoError(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("CreateWithoutTarget", func(t *testing.T) {
+		_, err := svc.CreateReplicationJob(100, vm_types.ReplicationJobProperties{})
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("SetProperties", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodGet, "cluster/replication/100-0", url.Values(nil)).
+			Return(jobResponse, nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodPut, "cluster/replication/100-0", url.Values{
+				"schedule": {"*/30"},
+				"disable":  {"1"},
+				"delete":   {"comment,rate"},
+			}).
+			Return([]byte("{\"data\":null}"), nil).
+			Once()
+
+		job, err := svc.GetReplicationJob("100-0")
+		require.NoError(t, err)
+
+		err = job.SetProperties(vm_types.ReplicationJobProperties{
+			Target:   "test_node_2",
+			Schedule: "*/30",
+			Disabled: true,
+		})
+		require.NoError(t, err)
+
+		err = job.SetProperties(vm_types.ReplicationJobProperties{
+			Target: "test_node_3",
+		})
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		statusResponse, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_replication_{id}_status.json",
+		)
+		require.NoError(t, err)
+
+		logResponse, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_replication_{id}_log.json",
+		)
+		require.NoError(t, err)
+
+		virtualMachine, _, _ := vm.NewVirtualMachine()
+
+		api.VirtualMachineService.
+			On("Get", uint(100)).
+			Return(virtualMachine, nil).
+			Times(3)
+
+		exc.
+			On("Request", http.MethodGet, "cluster/replication/100-0", url.Values(nil)).
+			Return(jobResponse, nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/replication/100-0/status", url.Values(nil)).
+			Return(statusResponse, nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/replication/100-0/log", url.Values{
+				"limit": {"3"},
+			}).
+			Return(logResponse, nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/replication/100-0/schedule_now", url.Values(nil)).
+			Return([]byte("{\"data\":null}"), nil).
+			Once()
+
+		job, err := svc.GetReplicationJob("100-0")
+		require.NoError(t, err)
+
+		status, err := job.GetStatus()
+		require.NoError(t, err)
+		assert.Equal(t, time.Unix(1609459200, 0).UTC(), status.LastSync)
+		assert.Equal(t, time.Unix(1609459500, 0).UTC(), status.LastTry)
+		assert.Equal(t, time.Unix(1609459800, 0).UTC(), status.NextSync)
+		assert.Equal(t, 2500*time.Millisecond, status.Duration)
+		assert.Equal(t, uint(1), status.FailCount)
+		assert.False(t, status.Running)
+		assert.True(t, status.Failed())
+
+		log, err := job.GetLog(vm_types.GetReplicationLogOptions{LineLimit: 3})
+		require.NoError(t, err)
+		assert.Len(t, log, 3)
+		assert.Equal(t, "2021-01-01 00:05:00 100-0: start replication job", log[1])
+
+		require.NoError(t, job.ScheduleNow())
+
+		exc.AssertExpectations(t)
+		api.VirtualMachineService.AssertExpectations(t)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodGet, "cluster/replication/100-0", url.Values(nil)).
+			Return(jobResponse, nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodDelete, "cluster/replication/100-0", url.Values{
+				"keep": {"1"},
+			}).
+			Return([]byte("{\"data\":null}"), nil).
+			Once()
+
+		job, err := svc.GetReplicationJob("100-0")
+		require.NoError(t, err)
+
+		require.NoError(t, job.Delete(vm_types.DeleteReplicationJobOptions{Keep: true}))
+
+		exc.AssertExpectations(t)
+	})
+}
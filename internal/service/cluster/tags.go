@@ -0,0 +1,38 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/cluster"
+)
+
+type getOptionsResponseJSON struct {
+	TagStyle string `json:"tag-style"`
+}
+
+func (svc *Service) GetTagStyle() (cluster.TagStyle, error) {
+	var res getOptionsResponseJSON
+	if err := svc.client.Request(http.MethodGet, "cluster/options", nil, &res); err != nil {
+		return cluster.TagStyle{}, err
+	}
+
+	var style cluster.TagStyle
+	if res.TagStyle == "" {
+		return style, nil
+	}
+
+	return style, (&style).Unmarshal(res.TagStyle)
+}
+
+func (svc *Service) SetTagStyle(style cluster.TagStyle) error {
+	form := request.Values{}
+
+	if style.IsZero() {
+		form.AddString("delete", "tag-style")
+	} else if err := form.AddObject("tag-style", style); err != nil {
+		return err
+	}
+
+	return svc.client.Request(http.MethodPut, "cluster/options", form, nil)
+}
@@ -0,0 +1,61 @@
+package cluster_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/cluster/test"
+	"github.com/xabinapal/gopve/pkg/types/cluster"
+)
+
+func TestServiceTagStyle(t *testing.T) {
+	svc, _, exc := test.NewService()
+
+	style := cluster.TagStyle{
+		CaseSensitive: true,
+		ColorMap: map[string]cluster.TagColor{
+			"prod": {Background: "FF0000", Text: "FFFFFF"},
+			"dev":  {Background: "00FF00"},
+		},
+		Ordering: cluster.TagOrderingAlphabetical,
+		Shape:    cluster.TagShapeDense,
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodGet, "cluster/options", url.Values(nil)).
+			Return([]byte(`{"data":{"keyboard":"en-us","tag-style":"case-sensitive=1,color-map=dev:00FF00;prod:FF0000:FFFFFF,ordering=alphabetical,shape=dense"}}`), nil).
+			Once()
+
+		res, err := svc.GetTagStyle()
+		require.NoError(t, err)
+		assert.Equal(t, style, res)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodPut, "cluster/options", url.Values{
+				"tag-style": {"case-sensitive=1,color-map=dev:00FF00;prod:FF0000:FFFFFF,ordering=alphabetical,shape=dense"},
+			}).
+			Return([]byte{}, nil).
+			Once()
+
+		require.NoError(t, svc.SetTagStyle(style))
+
+		exc.
+			On("Request", http.MethodPut, "cluster/options", url.Values{
+				"delete": {"tag-style"},
+			}).
+			Return([]byte{}, nil).
+			Once()
+
+		require.NoError(t, svc.SetTagStyle(cluster.TagStyle{}))
+
+		exc.AssertExpectations(t)
+	})
+}
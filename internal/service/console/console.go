@@ -0,0 +1,99 @@
+package console
+
+import (
+	"encoding/json"
+	"strconv"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/types/console"
+)
+
+type VNCTicketResponseJSON struct {
+	User   string      `json:"user"`
+	Ticket string      `json:"ticket"`
+	Port   json.Number `json:"port"`
+
+	Certificate string `json:"cert"`
+	Password    string `json:"password"`
+
+	UPID string `json:"upid"`
+}
+
+func (res VNCTicketResponseJSON) Map() (console.VNCTicket, error) {
+	port, err := strconv.ParseUint(res.Port.String(), 10, 16)
+	if err != nil {
+		return console.VNCTicket{}, err
+	}
+
+	return console.VNCTicket{
+		User:   res.User,
+		Ticket: res.Ticket,
+		Port:   uint(port),
+
+		Certificate: res.Certificate,
+		Password:    res.Password,
+
+		UPID: res.UPID,
+	}, nil
+}
+
+type SPICETicketResponseJSON struct {
+	Type     string `json:"type"`
+	Host     string `json:"host"`
+	Proxy    string `json:"proxy"`
+	Password string `json:"password"`
+	TLSPort  uint   `json:"tls-port"`
+
+	HostSubject string `json:"host-subject"`
+	CA          string `json:"ca"`
+
+	Title            string `json:"title"`
+	ToggleFullscreen string `json:"toggle-fullscreen"`
+	ReleaseCursor    string `json:"release-cursor"`
+	SecureAttention  string `json:"secure-attention"`
+
+	DeleteThisFile internal_types.PVEBool `json:"delete-this-file"`
+}
+
+func (res SPICETicketResponseJSON) Map() (console.SPICETicket, error) {
+	return console.SPICETicket{
+		Type:     res.Type,
+		Host:     res.Host,
+		Proxy:    res.Proxy,
+		Password: res.Password,
+		TLSPort:  res.TLSPort,
+
+		HostSubject: res.HostSubject,
+		CA:          res.CA,
+
+		Title:            res.Title,
+		ToggleFullscreen: res.ToggleFullscreen,
+		ReleaseCursor:    res.ReleaseCursor,
+		SecureAttention:  res.SecureAttention,
+
+		DeleteThisFile: res.DeleteThisFile.Bool(),
+	}, nil
+}
+
+type TermTicketResponseJSON struct {
+	User   string      `json:"user"`
+	Ticket string      `json:"ticket"`
+	Port   json.Number `json:"port"`
+
+	UPID string `json:"upid"`
+}
+
+func (res TermTicketResponseJSON) Map() (console.TermTicket, error) {
+	port, err := strconv.ParseUint(res.Port.String(), 10, 16)
+	if err != nil {
+		return console.TermTicket{}, err
+	}
+
+	return console.TermTicket{
+		User:   res.User,
+		Ticket: res.Ticket,
+		Port:   uint(port),
+
+		UPID: res.UPID,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package node
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	types "github.com/xabinapal/gopve/pkg/types/node"
+	"github.com/xabinapal/gopve/pkg/types/task"
+)
+
+func postBulk(node *Node, action string, form request.Values) (task.Task, error) {
+	var task string
+	if err := node.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/%s", node.name, action), form, &task); err != nil {
+		return nil, err
+	}
+
+	return node.svc.api.Task().Get(task)
+}
+
+func (node *Node) StartAll(opts types.StartAllOptions) (task.Task, error) {
+	form, err := opts.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	return postBulk(node, "startall", form)
+}
+
+func (node *Node) StopAll(opts types.StopAllOptions) (task.Task, error) {
+	form, err := opts.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	return postBulk(node, "stopall", form)
+}
+
+func (node *Node) ShutdownAll(opts types.StopAllOptions) (task.Task, error) {
+	form, err := opts.MapToShutdownValues()
+	if err != nil {
+		return nil, err
+	}
+
+	return postBulk(node, "stopall", form)
+}
+
+func (node *Node) MigrateAll(
+	target string,
+	opts types.MigrateAllOptions,
+) (task.Task, error) {
+	form, err := opts.MapToValues(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return postBulk(node, "migrateall", form)
+}
@@ -0,0 +1,105 @@
+package node_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/node/test"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	types "github.com/xabinapal/gopve/pkg/types/node"
+)
+
+func TestNodeBulk(t *testing.T) {
+	node, api, exc := test.NewNodeWithAPI()
+
+	mockTask := func(action string, form url.Values) interface{} {
+		upid := "UPID:test_node::::" + action + "::root@pam:"
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/"+action, form).
+			Return([]byte("{\"data\":\""+upid+"\"}"), nil).
+			Once()
+
+		expectedTask, _, _ := task.NewTask(
+			"test_node",
+			"::",
+			action,
+			"",
+			"root@pam",
+			"",
+		)
+
+		api.TaskService.On("Get", upid).Return(expectedTask, nil).Once()
+
+		return expectedTask
+	}
+
+	t.Run("StartAll", func(t *testing.T) {
+		expectedTask := mockTask("startall", url.Values{
+			"vms":   {"100,101"},
+			"force": {"1"},
+		})
+
+		task, err := node.StartAll(types.StartAllOptions{
+			VMIDs: []uint{100, 101},
+			Force: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("StopAll", func(t *testing.T) {
+		expectedTask := mockTask("stopall", url.Values{
+			"force-stop": {"1"},
+			"timeout":    {"60"},
+		})
+
+		task, err := node.StopAll(types.StopAllOptions{
+			Timeout: 60,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("ShutdownAll", func(t *testing.T) {
+		expectedTask := mockTask("stopall", url.Values{
+			"force-stop": {"0"},
+			"vms":        {"100"},
+		})
+
+		task, err := node.ShutdownAll(types.StopAllOptions{
+			VMIDs: []uint{100},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("MigrateAll", func(t *testing.T) {
+		expectedTask := mockTask("migrateall", url.Values{
+			"target":           {"other_node"},
+			"maxworkers":       {"2"},
+			"with-local-disks": {"1"},
+		})
+
+		task, err := node.MigrateAll("other_node", types.MigrateAllOptions{
+			MaxWorkers:     2,
+			WithLocalDisks: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		_, err = node.MigrateAll("", types.MigrateAllOptions{})
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,86 @@
+package node
+
+import (
+	"fmt"
+	"net/http"
+
+	internal_console "github.com/xabinapal/gopve/internal/service/console"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/console"
+)
+
+func (n *Node) VNCProxy(
+	opts console.VNCProxyOptions,
+) (console.VNCTicket, error) {
+	if opts.GeneratePassword {
+		return console.VNCTicket{}, fmt.Errorf(
+			"vnc passwords can only be generated for qemu virtual machines",
+		)
+	}
+
+	values := request.Values{}
+	values.ConditionalAddBool("websocket", true, opts.Websocket)
+
+	var res internal_console.VNCTicketResponseJSON
+	if err := n.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/vncshell", n.name), values, &res); err != nil {
+		return console.VNCTicket{}, err
+	}
+
+	return res.Map()
+}
+
+func (n *Node) SPICEProxy(
+	opts console.SPICEProxyOptions,
+) (console.SPICETicket, error) {
+	values := request.Values{}
+	values.ConditionalAddString("proxy", opts.Proxy, opts.Proxy != "")
+
+	var res internal_console.SPICETicketResponseJSON
+	if err := n.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/spiceshell", n.name), values, &res); err != nil {
+		return console.SPICETicket{}, err
+	}
+
+	return res.Map()
+}
+
+func (n *Node) TermProxy(
+	opts console.TermProxyOptions,
+) (console.TermTicket, error) {
+	if opts.Serial != "" {
+		return console.TermTicket{}, fmt.Errorf(
+			"serial terminals are only available for qemu virtual machines",
+		)
+	}
+
+	values := request.Values{}
+	values.ConditionalAddString("cmd", opts.Command, opts.Command != "")
+
+	var res internal_console.TermTicketResponseJSON
+	if err := n.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/termproxy", n.name), values, &res); err != nil {
+		return console.TermTicket{}, err
+	}
+
+	return res.Map()
+}
+
+func (n *Node) OpenTerminal(
+	opts console.TermProxyOptions,
+) (*console.Terminal, error) {
+	ticket, err := n.TermProxy(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	values := request.Values{
+		"vncticket": {ticket.Ticket},
+	}
+
+	values.AddUint("port", ticket.Port)
+
+	conn, err := n.svc.client.Websocket(fmt.Sprintf("nodes/%s/vncwebsocket", n.name), values)
+	if err != nil {
+		return nil, err
+	}
+
+	return console.NewTerminal(conn, ticket.User, ticket.Ticket)
+}
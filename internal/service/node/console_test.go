@@ -0,0 +1,100 @@
+package node_test
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/node/test"
+	"github.com/xabinapal/gopve/pkg/types/console"
+)
+
+func TestNodeConsole(t *testing.T) {
+	t.Run("VNCProxy", func(t *testing.T) {
+		node, exc := test.NewNode()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/vncshell", url.Values{
+				"websocket": {"1"},
+			}).
+			Return([]byte(`{"data": {"user": "root@pam", "ticket": "PVEVNC:TEST", "port": "5900", "cert": "test_cert", "upid": "UPID:test_node:00000000:00000000:00000000:vncshell::root@pam:"}}`), nil).
+			Once()
+
+		ticket, err := node.VNCProxy(console.VNCProxyOptions{Websocket: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, console.VNCTicket{
+			User:        "root@pam",
+			Ticket:      "PVEVNC:TEST",
+			Port:        5900,
+			Certificate: "test_cert",
+			UPID:        "UPID:test_node:00000000:00000000:00000000:vncshell::root@pam:",
+		}, ticket)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("SPICEProxy", func(t *testing.T) {
+		node, exc := test.NewNode()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/spiceshell", url.Values{}).
+			Return([]byte(`{"data": {"type": "spice", "password": "test_password", "tls-port": 61000}}`), nil).
+			Once()
+
+		ticket, err := node.SPICEProxy(console.SPICEProxyOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, console.SPICETicket{
+			Type:     "spice",
+			Password: "test_password",
+			TLSPort:  61000,
+		}, ticket)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("OpenTerminal", func(t *testing.T) {
+		node, exc := test.NewNode()
+
+		client, server := net.Pipe()
+
+		go func() {
+			defer server.Close()
+
+			login, err := bufio.NewReader(server).ReadString('\n')
+			assert.NoError(t, err)
+			assert.Equal(t, "root@pam:PVEVNC:TEST\n", login)
+
+			_, err = server.Write([]byte("OK"))
+			assert.NoError(t, err)
+		}()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/termproxy", url.Values{
+				"cmd": {"upgrade"},
+			}).
+			Return([]byte(`{"data": {"user": "root@pam", "ticket": "PVEVNC:TEST", "port": 5900, "upid": "UPID:test_node:00000000:00000000:00000000:vncshell::root@pam:"}}`), nil).
+			Once()
+
+		exc.
+			On("Websocket", "nodes/test_node/vncwebsocket", url.Values{
+				"port":      {"5900"},
+				"vncticket": {"PVEVNC:TEST"},
+			}).
+			Return(client, nil).
+			Once()
+
+		terminal, err := node.OpenTerminal(console.TermProxyOptions{
+			Command: "upgrade",
+		})
+		require.NoError(t, err)
+		require.NoError(t, terminal.Close())
+
+		exc.AssertExpectations(t)
+	})
+}
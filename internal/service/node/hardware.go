@@ -0,0 +1,196 @@
+package node
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/node"
+)
+
+type getPCIDeviceResponseJSON struct {
+	ID    string `json:"id"`
+	Class string `json:"class"`
+
+	VendorID   string `json:"vendor"`
+	VendorName string `json:"vendor_name"`
+	DeviceID   string `json:"device"`
+	DeviceName string `json:"device_name"`
+
+	SubsystemVendorID   string `json:"subsystem_vendor"`
+	SubsystemVendorName string `json:"subsystem_vendor_name"`
+	SubsystemDeviceID   string `json:"subsystem_device"`
+	SubsystemDeviceName string `json:"subsystem_device_name"`
+
+	IOMMUGroup *int `json:"iommugroup"`
+
+	MediatedDevices types.PVEBool `json:"mdev"`
+}
+
+func (obj getPCIDeviceResponseJSON) Map() node.PCIDevice {
+	group := -1
+	if obj.IOMMUGroup != nil {
+		group = *obj.IOMMUGroup
+	}
+
+	return node.PCIDevice{
+		ID:                      obj.ID,
+		Class:                   obj.Class,
+		VendorID:                obj.VendorID,
+		VendorName:              obj.VendorName,
+		DeviceID:                obj.DeviceID,
+		DeviceName:              obj.DeviceName,
+		SubsystemVendorID:       obj.SubsystemVendorID,
+		SubsystemVendorName:     obj.SubsystemVendorName,
+		SubsystemDeviceID:       obj.SubsystemDeviceID,
+		SubsystemDeviceName:     obj.SubsystemDeviceName,
+		IOMMUGroup:              group,
+		SupportsMediatedDevices: obj.MediatedDevices.Bool(),
+	}
+}
+
+func (n *Node) listPCIDevices(form request.Values) ([]node.PCIDevice, error) {
+	var res []getPCIDeviceResponseJSON
+	if err := n.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/hardware/pci", n.name), form, &res); err != nil {
+		return nil, err
+	}
+
+	devices := make([]node.PCIDevice, len(res))
+	for i, device := range res {
+		devices[i] = device.Map()
+	}
+
+	return devices, nil
+}
+
+func (n *Node) ListPCIDevices() ([]node.PCIDevice, error) {
+	return n.listPCIDevices(nil)
+}
+
+func (n *Node) ListIOMMUGroups() ([]node.IOMMUGroup, error) {
+	devices, err := n.listPCIDevices(request.Values{
+		"pci-class-blacklist": {""},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []node.IOMMUGroup
+	indexes := make(map[int]int)
+
+	for _, device := range devices {
+		if device.IOMMUGroup < 0 {
+			continue
+		}
+
+		i, ok := indexes[device.IOMMUGroup]
+		if !ok {
+			i = len(groups)
+			indexes[device.IOMMUGroup] = i
+			groups = append(groups, node.IOMMUGroup{ID: device.IOMMUGroup})
+		}
+
+		groups[i].Devices = append(groups[i].Devices, device)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ID < groups[j].ID
+	})
+
+	return groups, nil
+}
+
+type getMediatedDeviceTypeResponseJSON struct {
+	Name        string `json:"type"`
+	Description string `json:"description"`
+	Available   uint   `json:"available"`
+}
+
+func (n *Node) ListMediatedDeviceTypes(
+	pciID string,
+) ([]node.MediatedDeviceType, error) {
+	var res []getMediatedDeviceTypeResponseJSON
+	if err := n.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/hardware/pci/%s/mdev", n.name, pciID), nil, &res); err != nil {
+		return nil, err
+	}
+
+	mdevTypes := make([]node.MediatedDeviceType, len(res))
+	for i, t := range res {
+		mdevTypes[i] = node.MediatedDeviceType(t)
+	}
+
+	return mdevTypes, nil
+}
+
+type getUSBDeviceResponseJSON struct {
+	BusNumber    uint   `json:"busnum"`
+	DeviceNumber uint   `json:"devnum"`
+	Port         uint   `json:"port"`
+	Level        uint   `json:"level"`
+	Path         string `json:"usbpath"`
+
+	Class     uint   `json:"class"`
+	VendorID  string `json:"vendid"`
+	ProductID string `json:"prodid"`
+
+	Manufacturer string `json:"manufacturer"`
+	Product      string `json:"product"`
+	Serial       string `json:"serial"`
+	Speed        string `json:"speed"`
+}
+
+func (n *Node) ListUSBDevices() ([]node.USBDevice, error) {
+	var res []getUSBDeviceResponseJSON
+	if err := n.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/hardware/usb", n.name), nil, &res); err != nil {
+		return nil, err
+	}
+
+	devices := make([]node.USBDevice, len(res))
+	for i, device := range res {
+		devices[i] = node.USBDevice(device)
+	}
+
+	return devices, nil
+}
+
+type getCPUInfoResponseJSON struct {
+	CPUInfo struct {
+		Model string `json:"model"`
+		MHz   string `json:"mhz"`
+
+		Sockets uint `json:"sockets"`
+		Cores   uint `json:"cores"`
+		CPUs    uint `json:"cpus"`
+
+		HVM   string `json:"hvm"`
+		Flags string `json:"flags"`
+	} `json:"cpuinfo"`
+}
+
+func (n *Node) GetCPUInfo() (node.CPUInfo, error) {
+	var res getCPUInfoResponseJSON
+	if err := n.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/status", n.name), nil, &res); err != nil {
+		return node.CPUInfo{}, err
+	}
+
+	info := res.CPUInfo
+
+	mhz, err := strconv.ParseFloat(info.MHz, 64)
+	if err != nil && info.MHz != "" {
+		return node.CPUInfo{}, fmt.Errorf("invalid cpu frequency %s", info.MHz)
+	}
+
+	return node.CPUInfo{
+		Model:   info.Model,
+		MHz:     mhz,
+		Sockets: info.Sockets,
+		Cores:   info.Cores,
+		CPUs:    info.CPUs,
+		HVM:     info.HVM == "1",
+		Flags:   strings.Fields(info.Flags),
+	}, nil
+}
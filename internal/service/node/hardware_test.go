@@ -0,0 +1,146 @@
+package node_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/node/test"
+	"github.com/xabinapal/gopve/pkg/types/node"
+)
+
+func TestNodeHardware(t *testing.T) {
+	n, exc := test.NewNode()
+
+	pciResponse, err := ioutil.ReadFile("./testdata/get_nodes_{node}_hardware_pci.json")
+	require.NoError(t, err)
+
+	bridge := node.PCIDevice{
+		ID:         "0000:00:01.0",
+		Class:      "0x060400",
+		VendorID:   "0x8086",
+		VendorName: "Intel Corporation",
+		DeviceID:   "0x1901",
+		DeviceName: "6th-9th Gen Core Processor PCIe Controller (x16)",
+		IOMMUGroup: 1,
+	}
+
+	gpu := node.PCIDevice{
+		ID:                      "0000:01:00.0",
+		Class:                   "0x030000",
+		VendorID:                "0x10de",
+		VendorName:              "NVIDIA Corporation",
+		DeviceID:                "0x1b80",
+		DeviceName:              "GP104 [GeForce GTX 1080]",
+		SubsystemVendorID:       "0x1458",
+		SubsystemVendorName:     "Gigabyte Technology Co., Ltd",
+		SubsystemDeviceID:       "0x3702",
+		IOMMUGroup:              1,
+		SupportsMediatedDevices: true,
+	}
+
+	nic := node.PCIDevice{
+		ID:         "0000:00:1f.6",
+		Class:      "0x020000",
+		VendorID:   "0x8086",
+		VendorName: "Intel Corporation",
+		DeviceID:   "0x15b8",
+		DeviceName: "Ethernet Connection (2) I219-V",
+		IOMMUGroup: 0,
+	}
+
+	t.Run("ListPCIDevices", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/hardware/pci", url.Values(nil)).
+			Return(pciResponse, nil).
+			Once()
+
+		devices, err := n.ListPCIDevices()
+		require.NoError(t, err)
+		assert.Equal(t, []node.PCIDevice{bridge, gpu, nic}, devices)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("ListIOMMUGroups", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/hardware/pci", url.Values{
+				"pci-class-blacklist": {""},
+			}).
+			Return(pciResponse, nil).
+			Once()
+
+		groups, err := n.ListIOMMUGroups()
+		require.NoError(t, err)
+		assert.Equal(t, []node.IOMMUGroup{
+			{ID: 0, Devices: []node.PCIDevice{nic}},
+			{ID: 1, Devices: []node.PCIDevice{bridge, gpu}},
+		}, groups)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("ListMediatedDeviceTypes", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/hardware/pci/0000:01:00.0/mdev", url.Values(nil)).
+			Return([]byte(`{"data":[
+				{"type":"nvidia-63","description":"GRID P4-1Q","available":8}
+			]}`), nil).
+			Once()
+
+		types, err := n.ListMediatedDeviceTypes("0000:01:00.0")
+		require.NoError(t, err)
+		assert.Equal(t, []node.MediatedDeviceType{
+			{Name: "nvidia-63", Description: "GRID P4-1Q", Available: 8},
+		}, types)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("ListUSBDevices", func(t *testing.T) {
+		response, err := ioutil.ReadFile("./testdata/get_nodes_{node}_hardware_usb.json")
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/hardware/usb", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		devices, err := n.ListUSBDevices()
+		require.NoError(t, err)
+		require.Len(t, devices, 1)
+
+		assert.Equal(t, "DataTraveler 3.0", devices[0].Product)
+		assert.Equal(t, "0951:1666", devices[0].HostID())
+		assert.Equal(t, "1-1.2", devices[0].HostPath())
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("GetCPUInfo", func(t *testing.T) {
+		response, err := ioutil.ReadFile("./testdata/get_nodes_{node}_status.json")
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/status", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		info, err := n.GetCPUInfo()
+		require.NoError(t, err)
+
+		assert.Equal(t, "AMD EPYC 7302 16-Core Processor", info.Model)
+		assert.Equal(t, 2994.384, info.MHz)
+		assert.Equal(t, uint(2), info.Sockets)
+		assert.Equal(t, uint(32), info.Cores)
+		assert.Equal(t, uint(64), info.CPUs)
+		assert.Equal(t, uint(16), info.CoresPerSocket())
+		assert.True(t, info.HVM)
+		assert.Contains(t, info.Flags, "svm")
+
+		exc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,22 @@
+package node
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/types/metrics"
+)
+
+func (n *Node) GetMetrics(opts metrics.Options) (metrics.NodeMetrics, error) {
+	form, err := opts.MapToValues()
+	if err != nil {
+		return metrics.NodeMetrics{}, err
+	}
+
+	var res []metrics.Sample
+	if err := n.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/rrddata", n.name), form, &res); err != nil {
+		return metrics.NodeMetrics{}, err
+	}
+
+	return metrics.NewNodeMetrics(res), nil
+}
@@ -0,0 +1,52 @@
+package node_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/node/test"
+	"github.com/xabinapal/gopve/pkg/types/metrics"
+)
+
+func TestNodeMetrics(t *testing.T) {
+	node, exc := test.NewNode()
+
+	response, err := ioutil.ReadFile("./testdata/get_nodes_{node}_rrddata.json")
+	require.NoError(t, err)
+
+	exc.
+		On("Request", http.MethodGet, "nodes/test_node/rrddata", url.Values{
+			"timeframe": {"day"},
+			"cf":        {"MAX"},
+		}).
+		Return(response, nil).
+		Once()
+
+	nodeMetrics, err := node.GetMetrics(metrics.Options{
+		Timeframe:             metrics.TimeframeDay,
+		ConsolidationFunction: metrics.ConsolidationFunctionMax,
+	})
+	require.NoError(t, err)
+
+	timestamp := time.Unix(1609459200, 0).UTC()
+
+	assert.Equal(t, metrics.Series{
+		Unit:   metrics.UnitRatio,
+		Points: []metrics.Point{{Time: timestamp, Value: 0.01}},
+	}, nodeMetrics.IOWait)
+
+	assert.Equal(t, metrics.Series{
+		Unit:   metrics.UnitBytes,
+		Points: []metrics.Point{{Time: timestamp, Value: 17179869184}},
+	}, nodeMetrics.MemoryTotal)
+
+	assert.Equal(t, metrics.UnitCount, nodeMetrics.LoadAverage.Unit)
+	assert.Equal(t, metrics.UnitBytes, nodeMetrics.RootDisk.Unit)
+
+	exc.AssertExpectations(t)
+}
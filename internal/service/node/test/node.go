@@ -2,11 +2,17 @@ package test
 
 import (
 	"github.com/xabinapal/gopve/internal/service/node"
+	"github.com/xabinapal/gopve/pkg/client/test"
 	"github.com/xabinapal/gopve/pkg/request/mocks"
 	types "github.com/xabinapal/gopve/pkg/types/node"
 )
 
 func NewNode() (*node.Node, *mocks.Executor) {
-	svc, exc := NewService()
-	return node.NewNode(svc, "test_node", types.StatusOnline), exc
+	obj, _, exc := NewNodeWithAPI()
+	return obj, exc
+}
+
+func NewNodeWithAPI() (*node.Node, *test.API, *mocks.Executor) {
+	svc, api, exc := NewServiceWithAPI()
+	return node.NewNode(svc, "test_node", types.StatusOnline), api, exc
 }
@@ -7,8 +7,13 @@ import (
 )
 
 func NewService() (*node.Service, *mocks.Executor) {
+	svc, _, exc := NewServiceWithAPI()
+	return svc, exc
+}
+
+func NewServiceWithAPI() (*node.Service, *test.API, *mocks.Executor) {
 	cli, exc := test.NewClient()
 	api := test.NewAPI()
 
-	return node.NewService(cli, api), exc
+	return node.NewService(cli, api), api, exc
 }
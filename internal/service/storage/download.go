@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/types/storage"
+	"github.com/xabinapal/gopve/pkg/types/task"
+)
+
+func (obj *Storage) DownloadURL(
+	node string,
+	opts storage.DownloadURLOptions,
+) (task.Task, error) {
+	if obj.Content()&opts.Content == 0 {
+		return nil, fmt.Errorf(
+			"storage %s can't hold %s content",
+			obj.name,
+			opts.Content,
+		)
+	}
+
+	form, err := opts.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	var upid string
+	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/storage/%s/download-url", node, obj.name), form, &upid); err != nil {
+		return nil, err
+	}
+
+	return obj.svc.api.Task().Get(upid)
+}
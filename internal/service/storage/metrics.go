@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/types/metrics"
+)
+
+func (obj *Storage) GetMetrics(
+	node string,
+	opts metrics.Options,
+) (metrics.StorageMetrics, error) {
+	form, err := opts.MapToValues()
+	if err != nil {
+		return metrics.StorageMetrics{}, err
+	}
+
+	var res []metrics.Sample
+	if err := obj.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/storage/%s/rrddata", node, obj.name), form, &res); err != nil {
+		return metrics.StorageMetrics{}, err
+	}
+
+	return metrics.NewStorageMetrics(res), nil
+}
@@ -0,0 +1,44 @@
+package storage_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/storage/test"
+	"github.com/xabinapal/gopve/pkg/types/metrics"
+)
+
+func TestStorageMetrics(t *testing.T) {
+	storage, _, exc := test.NewStorage()
+
+	exc.
+		On("Request", http.MethodGet, "nodes/test_node/storage/test_storage/rrddata", url.Values{
+			"timeframe": {"week"},
+		}).
+		Return([]byte(`{"data": [{"time": 1609459200, "used": 1024, "total": 4096}]}`), nil).
+		Once()
+
+	storageMetrics, err := storage.GetMetrics("test_node", metrics.Options{
+		Timeframe: metrics.TimeframeWeek,
+	})
+	require.NoError(t, err)
+
+	timestamp := time.Unix(1609459200, 0).UTC()
+
+	assert.Equal(t, metrics.StorageMetrics{
+		Used: metrics.Series{
+			Unit:   metrics.UnitBytes,
+			Points: []metrics.Point{{Time: timestamp, Value: 1024}},
+		},
+		Total: metrics.Series{
+			Unit:   metrics.UnitBytes,
+			Points: []metrics.Point{{Time: timestamp, Value: 4096}},
+		},
+	}, storageMetrics)
+
+	exc.AssertExpectations(t)
+}
@@ -0,0 +1,340 @@
+package vm
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+type GuestAgent struct {
+	vm *QEMUVirtualMachine
+}
+
+func (obj *QEMUVirtualMachine) GuestAgent() (qemu.GuestAgent, error) {
+	props, err := obj.GetQEMUProperties()
+	if err != nil {
+		return nil, err
+	}
+
+	if !props.Agent.Enabled {
+		return nil, qemu.ErrAgentNotEnabled
+	}
+
+	return &GuestAgent{vm: obj}, nil
+}
+
+func (obj *GuestAgent) request(
+	method string,
+	command string,
+	values request.Values,
+	out interface{},
+) error {
+	return obj.vm.svc.client.Request(method, fmt.Sprintf("nodes/%s/qemu/%d/agent/%s", obj.vm.node, obj.vm.vmid, command), values, out)
+}
+
+func (obj *GuestAgent) Ping() error {
+	return obj.request(http.MethodPost, "ping", nil, nil)
+}
+
+type getAgentOSInfoResponseJSON struct {
+	Result struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		PrettyName string `json:"pretty-name"`
+
+		Version   string `json:"version"`
+		VersionID string `json:"version-id"`
+
+		KernelRelease string `json:"kernel-release"`
+		KernelVersion string `json:"kernel-version"`
+		Machine       string `json:"machine"`
+	} `json:"result"`
+}
+
+func (obj *GuestAgent) GetOSInfo() (qemu.AgentOSInfo, error) {
+	var res getAgentOSInfoResponseJSON
+	if err := obj.request(http.MethodGet, "get-osinfo", nil, &res); err != nil {
+		return qemu.AgentOSInfo{}, err
+	}
+
+	return qemu.AgentOSInfo{
+		ID:         res.Result.ID,
+		Name:       res.Result.Name,
+		PrettyName: res.Result.PrettyName,
+
+		Version:   res.Result.Version,
+		VersionID: res.Result.VersionID,
+
+		KernelRelease: res.Result.KernelRelease,
+		KernelVersion: res.Result.KernelVersion,
+		Machine:       res.Result.Machine,
+	}, nil
+}
+
+type getAgentNetworkInterfacesResponseJSON struct {
+	Result []struct {
+		Name            string `json:"name"`
+		HardwareAddress string `json:"hardware-address"`
+
+		IPAddresses []struct {
+			IPAddress string `json:"ip-address"`
+			Prefix    uint   `json:"prefix"`
+		} `json:"ip-addresses"`
+	} `json:"result"`
+}
+
+func (res getAgentNetworkInterfacesResponseJSON) Map() ([]qemu.AgentNetworkInterface, error) {
+	ifaces := make([]qemu.AgentNetworkInterface, len(res.Result))
+
+	for i, iface := range res.Result {
+		ifaces[i].Name = iface.Name
+
+		if iface.HardwareAddress != "" {
+			mac, err := net.ParseMAC(iface.HardwareAddress)
+			if err != nil {
+				return nil, err
+			}
+
+			ifaces[i].MACAddress = mac
+		}
+
+		for _, addr := range iface.IPAddresses {
+			ip := net.ParseIP(addr.IPAddress)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip address %s", addr.IPAddress)
+			}
+
+			ifaces[i].IPAddresses = append(
+				ifaces[i].IPAddresses,
+				qemu.AgentIPAddress{
+					IP:     ip,
+					Prefix: addr.Prefix,
+				},
+			)
+		}
+	}
+
+	return ifaces, nil
+}
+
+func (obj *GuestAgent) GetNetworkInterfaces() ([]qemu.AgentNetworkInterface, error) {
+	var res getAgentNetworkInterfacesResponseJSON
+	if err := obj.request(http.MethodGet, "network-get-interfaces", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Map()
+}
+
+type getAgentFilesystemsResponseJSON struct {
+	Result []struct {
+		Name       string `json:"name"`
+		Mountpoint string `json:"mountpoint"`
+		Type       string `json:"type"`
+
+		TotalBytes uint64 `json:"total-bytes"`
+		UsedBytes  uint64 `json:"used-bytes"`
+
+		Disks []struct {
+			Device  string `json:"dev"`
+			Serial  string `json:"serial"`
+			BusType string `json:"bus-type"`
+		} `json:"disk"`
+	} `json:"result"`
+}
+
+func (res getAgentFilesystemsResponseJSON) Map() ([]qemu.AgentFilesystem, error) {
+	filesystems := make([]qemu.AgentFilesystem, len(res.Result))
+
+	for i, fs := range res.Result {
+		filesystems[i] = qemu.AgentFilesystem{
+			Name:       fs.Name,
+			Mountpoint: fs.Mountpoint,
+			Type:       fs.Type,
+
+			TotalBytes: fs.TotalBytes,
+			UsedBytes:  fs.UsedBytes,
+		}
+
+		for _, disk := range fs.Disks {
+			filesystems[i].Disks = append(
+				filesystems[i].Disks,
+				qemu.AgentFilesystemDisk{
+					Device:  disk.Device,
+					Serial:  disk.Serial,
+					BusType: disk.BusType,
+				},
+			)
+		}
+	}
+
+	return filesystems, nil
+}
+
+func (obj *GuestAgent) GetFilesystems() ([]qemu.AgentFilesystem, error) {
+	var res getAgentFilesystemsResponseJSON
+	if err := obj.request(http.MethodGet, "get-fsinfo", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Map()
+}
+
+func (obj *GuestAgent) freeze(command string) (uint, error) {
+	var res struct {
+		Result uint `json:"result"`
+	}
+
+	if err := obj.request(http.MethodPost, command, nil, &res); err != nil {
+		return 0, err
+	}
+
+	return res.Result, nil
+}
+
+func (obj *GuestAgent) FreezeFilesystems() (uint, error) {
+	return obj.freeze("fsfreeze-freeze")
+}
+
+func (obj *GuestAgent) ThawFilesystems() (uint, error) {
+	return obj.freeze("fsfreeze-thaw")
+}
+
+func (obj *GuestAgent) SetUserPassword(
+	username, password string,
+	crypted bool,
+) error {
+	values := request.Values{
+		"username": {username},
+		"password": {password},
+	}
+
+	values.ConditionalAddBool("crypted", true, crypted)
+
+	return obj.request(http.MethodPost, "set-user-password", values, nil)
+}
+
+func (obj *GuestAgent) Shutdown() error {
+	return obj.request(http.MethodPost, "shutdown", nil, nil)
+}
+
+func (obj *GuestAgent) Exec(opts qemu.AgentExecOptions) (uint, error) {
+	if len(opts.Command) == 0 {
+		return 0, fmt.Errorf("command is required to execute it in the guest")
+	}
+
+	values := request.Values{
+		"command": opts.Command,
+	}
+
+	values.ConditionalAddString(
+		"input-data",
+		string(opts.Input),
+		len(opts.Input) != 0,
+	)
+
+	var res struct {
+		PID uint `json:"pid"`
+	}
+
+	if err := obj.request(http.MethodPost, "exec", values, &res); err != nil {
+		return 0, err
+	}
+
+	return res.PID, nil
+}
+
+type getAgentExecStatusResponseJSON struct {
+	Exited   internal_types.PVEBool `json:"exited"`
+	ExitCode int                    `json:"exitcode"`
+	Signal   int                    `json:"signal"`
+
+	Stdout          string                 `json:"out-data"`
+	StdoutTruncated internal_types.PVEBool `json:"out-truncated"`
+	Stderr          string                 `json:"err-data"`
+	StderrTruncated internal_types.PVEBool `json:"err-truncated"`
+}
+
+func (res getAgentExecStatusResponseJSON) Map() (qemu.AgentExecStatus, error) {
+	return qemu.AgentExecStatus{
+		Exited:   res.Exited.Bool(),
+		ExitCode: res.ExitCode,
+		Signal:   res.Signal,
+
+		Stdout:          []byte(res.Stdout),
+		StdoutTruncated: res.StdoutTruncated.Bool(),
+		Stderr:          []byte(res.Stderr),
+		StderrTruncated: res.StderrTruncated.Bool(),
+	}, nil
+}
+
+func (obj *GuestAgent) GetExecStatus(pid uint) (qemu.AgentExecStatus, error) {
+	values := request.Values{}
+	values.AddUint("pid", pid)
+
+	var res getAgentExecStatusResponseJSON
+	if err := obj.request(http.MethodGet, "exec-status", values, &res); err != nil {
+		return qemu.AgentExecStatus{}, err
+	}
+
+	return res.Map()
+}
+
+func (obj *GuestAgent) Run(
+	opts qemu.AgentExecOptions,
+) (qemu.AgentExecStatus, error) {
+	pid, err := obj.Exec(opts)
+	if err != nil {
+		return qemu.AgentExecStatus{}, err
+	}
+
+	interval := opts.PollingInterval
+	if interval == 0 {
+		interval = qemu.DefaultAgentExecPollingInterval
+	}
+
+	start := time.Now()
+
+	for {
+		status, err := obj.GetExecStatus(pid)
+		if err != nil || status.Exited {
+			return status, err
+		}
+
+		if opts.Timeout != 0 && time.Since(start) >= opts.Timeout {
+			return status, qemu.ErrAgentExecTimeout
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+func (obj *GuestAgent) ReadFile(path string) (qemu.AgentFile, error) {
+	var res struct {
+		Content   string                 `json:"content"`
+		Truncated internal_types.PVEBool `json:"truncated"`
+	}
+
+	if err := obj.request(http.MethodGet, "file-read", request.Values{
+		"file": {path},
+	}, &res); err != nil {
+		return qemu.AgentFile{}, err
+	}
+
+	return qemu.AgentFile{
+		Content:   []byte(res.Content),
+		Truncated: res.Truncated.Bool(),
+	}, nil
+}
+
+func (obj *GuestAgent) WriteFile(path string, content []byte) error {
+	return obj.request(http.MethodPost, "file-write", request.Values{
+		"file":    {path},
+		"content": {string(content)},
+	}, nil)
+}
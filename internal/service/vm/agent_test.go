@@ -0,0 +1,335 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	"github.com/xabinapal/gopve/pkg/request/mocks"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+func newAgentProperties(agent string) types.Properties {
+	props := types.Properties{
+		"digest":  "0000000000000000000000000000000000000000",
+		"ostype":  "l26",
+		"sockets": float64(1),
+		"cores":   float64(1),
+		"memory":  float64(512),
+	}
+
+	if agent != "" {
+		props["agent"] = agent
+	}
+
+	return props
+}
+
+func newGuestAgent(t *testing.T) (qemu.GuestAgent, *mocks.Executor) {
+	virtualMachine, _, exc := test.NewQEMUWithProperties(
+		newAgentProperties("1,fstrim_cloned_disks=1"),
+	)
+
+	agent, err := virtualMachine.GuestAgent()
+	require.NoError(t, err)
+
+	return agent, exc
+}
+
+func TestVirtualMachineGuestAgent(t *testing.T) {
+	t.Run("NotEnabled", func(t *testing.T) {
+		for _, agent := range []string{"", "0", "enabled=0,type=isa"} {
+			virtualMachine, _, exc := test.NewQEMUWithProperties(
+				newAgentProperties(agent),
+			)
+
+			_, err := virtualMachine.GuestAgent()
+			assert.Equal(t, qemu.ErrAgentNotEnabled, err, agent)
+
+			exc.AssertExpectations(t)
+		}
+	})
+
+	t.Run("Ping", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/agent/ping", url.Values(nil)).
+			Return([]byte(`{"data": {"result": {}}}`), nil).
+			Once()
+
+		require.NoError(t, agent.Ping())
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("GetOSInfo", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_qemu_{vmid}_agent_get-osinfo.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/agent/get-osinfo", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		osInfo, err := agent.GetOSInfo()
+		require.NoError(t, err)
+
+		assert.Equal(t, qemu.AgentOSInfo{
+			ID:            "debian",
+			Name:          "Debian GNU/Linux",
+			PrettyName:    "Debian GNU/Linux 11 (bullseye)",
+			Version:       "11 (bullseye)",
+			VersionID:     "11",
+			KernelRelease: "5.10.0-8-amd64",
+			KernelVersion: "#1 SMP Debian 5.10.46-4 (2021-08-03)",
+			Machine:       "x86_64",
+		}, osInfo)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("GetNetworkInterfaces", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_qemu_{vmid}_agent_network-get-interfaces.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/agent/network-get-interfaces", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		ifaces, err := agent.GetNetworkInterfaces()
+		require.NoError(t, err)
+		require.Len(t, ifaces, 2)
+
+		assert.Equal(t, "eth0", ifaces[1].Name)
+		assert.Equal(t, "aa:bb:cc:dd:ee:ff", ifaces[1].MACAddress.String())
+		assert.Equal(
+			t,
+			[]net.IP{net.ParseIP("10.0.0.10").To4()},
+			ifaces[1].IPv4Addresses(),
+		)
+		assert.Equal(
+			t,
+			[]net.IP{net.ParseIP("fe80::a8bb:ccff:fedd:eeff")},
+			ifaces[1].IPv6Addresses(),
+		)
+
+		ipNet := ifaces[1].IPAddresses[0].IPNet()
+		assert.Equal(t, "10.0.0.10/24", ipNet.String())
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("GetFilesystems", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_qemu_{vmid}_agent_get-fsinfo.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/agent/get-fsinfo", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		filesystems, err := agent.GetFilesystems()
+		require.NoError(t, err)
+
+		assert.Equal(t, []qemu.AgentFilesystem{
+			{
+				Name:       "sda1",
+				Mountpoint: "/",
+				Type:       "ext4",
+				TotalBytes: 33756561408,
+				UsedBytes:  2147483648,
+				Disks: []qemu.AgentFilesystemDisk{
+					{
+						Device:  "/dev/sda1",
+						Serial:  "0QEMU_QEMU_HARDDISK_drive-scsi0",
+						BusType: "scsi",
+					},
+				},
+			},
+		}, filesystems)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("FreezeFilesystems", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/agent/fsfreeze-freeze", url.Values(nil)).
+			Return([]byte(`{"data": {"result": 2}}`), nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/agent/fsfreeze-thaw", url.Values(nil)).
+			Return([]byte(`{"data": {"result": 2}}`), nil).
+			Once()
+
+		frozen, err := agent.FreezeFilesystems()
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), frozen)
+
+		thawed, err := agent.ThawFilesystems()
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), thawed)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("SetUserPassword", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/agent/set-user-password", url.Values{
+				"username": {"test_user"},
+				"password": {"test_password"},
+			}).
+			Return([]byte(`{"data": {"result": {}}}`), nil).
+			Once()
+
+		err := agent.SetUserPassword("test_user", "test_password", false)
+		require.NoError(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/agent/shutdown", url.Values(nil)).
+			Return([]byte(`{"data": {"result": {}}}`), nil).
+			Once()
+
+		require.NoError(t, agent.Shutdown())
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_qemu_{vmid}_agent_exec-status.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/agent/exec", url.Values{
+				"command":    {"/bin/sh", "-c", "cat"},
+				"input-data": {"hello\n"},
+			}).
+			Return([]byte(`{"data": {"pid": 1234}}`), nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/agent/exec-status", url.Values{
+				"pid": {"1234"},
+			}).
+			Return([]byte(`{"data": {"exited": 0}}`), nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/agent/exec-status", url.Values{
+				"pid": {"1234"},
+			}).
+			Return(response, nil).
+			Once()
+
+		status, err := agent.Run(qemu.AgentExecOptions{
+			Command:         []string{"/bin/sh", "-c", "cat"},
+			Input:           []byte("hello\n"),
+			PollingInterval: time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, qemu.AgentExecStatus{
+			Exited:   true,
+			ExitCode: 2,
+			Stdout:   []byte("hello\n"),
+			Stderr:   []byte("warning\n"),
+		}, status)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("RunTimeout", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/agent/exec", url.Values{
+				"command": {"sleep", "60"},
+			}).
+			Return([]byte(`{"data": {"pid": 1234}}`), nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/agent/exec-status", url.Values{
+				"pid": {"1234"},
+			}).
+			Return([]byte(`{"data": {"exited": 0}}`), nil)
+
+		_, err := agent.Run(qemu.AgentExecOptions{
+			Command:         []string{"sleep", "60"},
+			PollingInterval: time.Millisecond,
+			Timeout:         time.Duration(10) * time.Millisecond,
+		})
+		assert.Equal(t, qemu.ErrAgentExecTimeout, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("ReadFile", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/agent/file-read", url.Values{
+				"file": {"/etc/hostname"},
+			}).
+			Return([]byte(`{"data": {"content": "test_name\n", "truncated": false}}`), nil).
+			Once()
+
+		file, err := agent.ReadFile("/etc/hostname")
+		require.NoError(t, err)
+		assert.Equal(t, qemu.AgentFile{Content: []byte("test_name\n")}, file)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("WriteFile", func(t *testing.T) {
+		agent, exc := newGuestAgent(t)
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/agent/file-write", url.Values{
+				"file":    {"/etc/hostname"},
+				"content": {"test_name\n"},
+			}).
+			Return([]byte(`{"data": null}`), nil).
+			Once()
+
+		err := agent.WriteFile("/etc/hostname", []byte("test_name\n"))
+		require.NoError(t, err)
+
+		exc.AssertExpectations(t)
+	})
+}
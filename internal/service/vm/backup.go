@@ -0,0 +1,79 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func (svc *Service) backup(
+	node string,
+	values request.Values,
+	opts vm.BackupOptions,
+) (task.Task, error) {
+	optsValues, err := opts.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range optsValues {
+		values[k] = v
+	}
+
+	var task string
+	if err := svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/vzdump", node), values, &task); err != nil {
+		return nil, err
+	}
+
+	return svc.api.Task().Get(task)
+}
+
+func (svc *Service) Backup(
+	node string,
+	selection vm.BackupSelection,
+	opts vm.BackupOptions,
+) (task.Task, error) {
+	if node == "" {
+		return nil, fmt.Errorf("node is required to backup virtual machines")
+	}
+
+	values, err := selection.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.backup(node, values, opts)
+}
+
+func (obj *VirtualMachine) Backup(opts vm.BackupOptions) (task.Task, error) {
+	values := request.Values{}
+	values.AddUint("vmid", obj.vmid)
+
+	return obj.svc.backup(obj.node, values, opts)
+}
+
+func (svc *Service) RestoreQEMU(opts vm.RestoreOptions) (task.Task, error) {
+	values, err := opts.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	values.AddString("archive", opts.Archive)
+
+	return svc.createVM("qemu", opts.VMID, opts.Node, values)
+}
+
+func (svc *Service) RestoreLXC(opts vm.RestoreOptions) (task.Task, error) {
+	values, err := opts.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	values.AddString("ostemplate", opts.Archive)
+	values.AddBool("restore", true)
+
+	return svc.createVM("lxc", opts.VMID, opts.Node, values)
+}
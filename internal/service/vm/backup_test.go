@@ -0,0 +1,240 @@
+package vm_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	node "github.com/xabinapal/gopve/internal/service/node/test"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestVirtualMachineBackup(t *testing.T) {
+	virtualMachine, api, exc := test.NewVirtualMachine()
+
+	exc.
+		On("Request", http.MethodPost, "nodes/test_node/vzdump", url.Values{
+			"vmid":             {"100"},
+			"mode":             {"snapshot"},
+			"compress":         {"zstd"},
+			"storage":          {"local"},
+			"notes-template":   {"{{guestname}}"},
+			"protected":        {"1"},
+			"mailto":           {"root@example.com,admin@example.com"},
+			"mailnotification": {"failure"},
+			"remove":           {"0"},
+		}).
+		Return(
+			[]byte(
+				"{\"data\":\"UPID:test_node::::vzdump:100:root@pam:\"}",
+			),
+			nil,
+		).
+		Once()
+
+	expectedTask, _, _ := task.NewTask(
+		"test_node",
+		"::",
+		"vzdump",
+		"100",
+		"root@pam",
+		"",
+	)
+
+	api.TaskService.
+		On("Get", "UPID:test_node::::vzdump:100:root@pam:").
+		Return(expectedTask, nil)
+
+	removeOld := false
+
+	task, err := virtualMachine.Backup(types.BackupOptions{
+		Mode:             types.BackupModeSnapshot,
+		Compression:      types.BackupCompressionZStandard,
+		Storage:          "local",
+		NotesTemplate:    "{{guestname}}",
+		Protected:        true,
+		MailTo:           []string{"root@example.com", "admin@example.com"},
+		MailNotification: types.BackupMailNotificationFailure,
+		RemoveOld:        &removeOld,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expectedTask, task)
+
+	exc.AssertExpectations(t)
+}
+
+func TestServiceBackup(t *testing.T) {
+	svc, api, exc := test.NewService()
+
+	t.Run("All", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/vzdump", url.Values{
+				"all":     {"1"},
+				"exclude": {"100,101"},
+				"mode":    {"stop"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::vzdump::root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		expectedTask, _, _ := task.NewTask(
+			"test_node",
+			"::",
+			"vzdump",
+			"",
+			"root@pam",
+			"",
+		)
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::vzdump::root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := svc.Backup("test_node", types.BackupSelection{
+			All:     true,
+			Exclude: []uint{100, 101},
+		}, types.BackupOptions{
+			Mode: types.BackupModeStop,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("InvalidSelection", func(t *testing.T) {
+		for _, selection := range []types.BackupSelection{
+			{},
+			{VMIDs: []uint{100}, Pool: "test_pool"},
+			{VMIDs: []uint{100}, Exclude: []uint{101}},
+		} {
+			_, err := svc.Backup("test_node", selection, types.BackupOptions{})
+			assert.Error(t, err)
+		}
+
+		_, err := svc.Backup("", types.BackupSelection{
+			VMIDs: []uint{100},
+		}, types.BackupOptions{})
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+}
+
+func TestServiceRestore(t *testing.T) {
+	testNode, _ := node.NewNode()
+
+	t.Run("QEMU", func(t *testing.T) {
+		svc, api, exc := test.NewService()
+
+		exc.On("StartAtomicBlock").Return().Once()
+		exc.On("EndAtomicBlock").Return().Once()
+
+		api.NodeService.On("Get", "test_node").Return(testNode, nil).Once()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu", url.Values{
+				"vmid":    {"100"},
+				"archive": {"local:backup/vzdump-qemu-100.vma.zst"},
+				"storage": {"local-lvm"},
+				"unique":  {"1"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::qmrestore:100:root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		expectedTask, _, _ := task.NewTask(
+			"test_node",
+			"::",
+			"qmrestore",
+			"100",
+			"root@pam",
+			"",
+		)
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::qmrestore:100:root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := svc.RestoreQEMU(types.RestoreOptions{
+			VMID:    100,
+			Node:    "test_node",
+			Archive: "local:backup/vzdump-qemu-100.vma.zst",
+			Storage: "local-lvm",
+			Unique:  true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("LXC", func(t *testing.T) {
+		svc, api, exc := test.NewService()
+
+		exc.On("StartAtomicBlock").Return().Once()
+		exc.On("EndAtomicBlock").Return().Once()
+
+		api.NodeService.On("Get", "test_node").Return(testNode, nil).Once()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/lxc", url.Values{
+				"vmid":       {"100"},
+				"ostemplate": {"local:backup/vzdump-lxc-100.tar.zst"},
+				"restore":    {"1"},
+				"force":      {"1"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::vzrestore:100:root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		expectedTask, _, _ := task.NewTask(
+			"test_node",
+			"::",
+			"vzrestore",
+			"100",
+			"root@pam",
+			"",
+		)
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::vzrestore:100:root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := svc.RestoreLXC(types.RestoreOptions{
+			VMID:    100,
+			Node:    "test_node",
+			Archive: "local:backup/vzdump-lxc-100.tar.zst",
+			Force:   true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("MissingArchive", func(t *testing.T) {
+		svc, _, exc := test.NewService()
+
+		_, err := svc.RestoreQEMU(types.RestoreOptions{VMID: 100})
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+}
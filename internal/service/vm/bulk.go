@@ -0,0 +1,202 @@
+package vm
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+// RunBulk applies the action to every guest and returns one result per guest
+// in the same order. Unless IgnoreOrder is set, guests are grouped by their
+// startup order and each group finishes before the next one begins: starting
+// actions go in ascending order with unordered guests last, stopping actions
+// in the reverse order. Failures are reported in the results and do not
+// abort the remaining guests.
+func (svc *Service) RunBulk(
+	guests []vm.VirtualMachine,
+	action vm.PowerAction,
+	opts vm.BulkOptions,
+) ([]vm.BulkResult, error) {
+	if !action.IsValid() {
+		return nil, fmt.Errorf("invalid power action %s", action)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency == 0 {
+		concurrency = vm.DefaultBulkConcurrency
+	}
+
+	results := make([]vm.BulkResult, len(guests))
+	props := make([]vm.Properties, len(guests))
+
+	groups := map[int][]int{}
+
+	for i, guest := range guests {
+		results[i].VMID = guest.VMID()
+
+		if opts.IgnoreOrder {
+			groups[0] = append(groups[0], i)
+			continue
+		}
+
+		p, err := guest.GetProperties()
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		props[i] = p
+
+		order := p.StartupOrder
+		if order < 0 {
+			order = vm.DefaultPropertyStartupOrder
+		}
+
+		groups[order] = append(groups[order], i)
+	}
+
+	orders := make([]int, 0, len(groups))
+	for order := range groups {
+		orders = append(orders, order)
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		switch {
+		case orders[i] == vm.DefaultPropertyStartupOrder:
+			return false
+		case orders[j] == vm.DefaultPropertyStartupOrder:
+			return true
+		default:
+			return orders[i] < orders[j]
+		}
+	})
+
+	if action.IsStopping() {
+		for i, j := 0, len(orders)-1; i < j; i, j = i+1, j-1 {
+			orders[i], orders[j] = orders[j], orders[i]
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+
+	for i, order := range orders {
+		last := i == len(orders)-1
+		wait := opts.Wait || !last
+
+		var wg sync.WaitGroup
+
+		for _, idx := range groups[order] {
+			wg.Add(1)
+			sem <- struct{}{}
+
+			go func(idx int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				runBulkGuest(guests[idx], props[idx], action, opts, wait, &results[idx])
+			}(idx)
+		}
+
+		wg.Wait()
+
+		if !last && action == vm.PowerActionStart {
+			var delay int
+			for _, idx := range groups[order] {
+				if props[idx].StartDelay > delay {
+					delay = props[idx].StartDelay
+				}
+			}
+
+			time.Sleep(time.Duration(delay) * time.Second)
+		}
+	}
+
+	return results, nil
+}
+
+func runBulkGuest(
+	guest vm.VirtualMachine,
+	props vm.Properties,
+	action vm.PowerAction,
+	opts vm.BulkOptions,
+	wait bool,
+	result *vm.BulkResult,
+) {
+	var fn func() (task.Task, error)
+
+	switch action {
+	case vm.PowerActionStart:
+		fn = guest.Start
+	case vm.PowerActionStop:
+		fn = guest.Stop
+	case vm.PowerActionShutdown:
+		fn = guest.Shutdown
+	case vm.PowerActionReboot:
+		fn = guest.Reboot
+	case vm.PowerActionSuspend:
+		fn = guest.Suspend
+	case vm.PowerActionResume:
+		fn = guest.Resume
+	}
+
+	timeout := opts.ShutdownTimeout
+	if props.ShutdownTimeout > 0 {
+		timeout = time.Duration(props.ShutdownTimeout) * time.Second
+	}
+
+	if action == vm.PowerActionShutdown && timeout != 0 {
+		if opts.ForceStop {
+			runBulkForcedShutdown(guest, timeout, result)
+			return
+		}
+
+		fn = func() (task.Task, error) {
+			return guest.ShutdownWithOptions(vm.ShutdownOptions{
+				Timeout: timeout,
+			})
+		}
+	}
+
+	t, err := fn()
+	if err != nil {
+		result.Err = err
+		return
+	}
+
+	result.Task = t
+
+	if wait {
+		result.Err = t.Wait()
+	}
+}
+
+// runBulkForcedShutdown lets PVE stop the guest once the timeout is reached,
+// as the shutdown task holds the guest lock until it finishes. The guest is
+// reported as forced when the shutdown lasted the whole timeout.
+func runBulkForcedShutdown(
+	guest vm.VirtualMachine,
+	timeout time.Duration,
+	result *vm.BulkResult,
+) {
+	timeout = (timeout + time.Second - 1).Truncate(time.Second)
+	start := time.Now()
+
+	t, err := guest.ShutdownWithOptions(vm.ShutdownOptions{
+		Timeout:   timeout,
+		ForceStop: true,
+	})
+	if err != nil {
+		result.Err = err
+		return
+	}
+
+	result.Task = t
+
+	if result.Err = t.Wait(); result.Err == nil {
+		result.Forced = time.Since(start) >= timeout
+	}
+}
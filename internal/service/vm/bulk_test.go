@@ -0,0 +1,227 @@
+package vm_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	"github.com/xabinapal/gopve/internal/service/vm"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	task_types "github.com/xabinapal/gopve/pkg/types/task"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type sleepingTask struct {
+	task_types.Task
+	duration time.Duration
+}
+
+func (obj sleepingTask) Wait() error {
+	time.Sleep(obj.duration)
+	return nil
+}
+
+func TestServiceRunBulk(t *testing.T) {
+	newGuests := func(svc *vm.Service) []types.VirtualMachine {
+		var guests []types.VirtualMachine
+
+		for vmid, order := range map[uint]int{
+			100: 2,
+			101: 1,
+			102: types.DefaultPropertyStartupOrder,
+		} {
+			guests = append(guests, vm.NewVirtualMachine(
+				svc,
+				vmid,
+				types.KindQEMU,
+				"test_node",
+				fmt.Sprintf("test_%d", vmid),
+				false,
+				&types.Properties{
+					StartupOrder:    order,
+					StartDelay:      types.DefaultPropertyStartDelay,
+					ShutdownTimeout: types.DefaultPropertyShutdownTimeout,
+				},
+			))
+		}
+
+		return guests
+	}
+
+	for _, tc := range []struct {
+		Action        types.PowerAction
+		ExpectedOrder []uint
+	}{
+		{types.PowerActionStart, []uint{101, 100, 102}},
+		{types.PowerActionShutdown, []uint{102, 100, 101}},
+	} {
+		tc := tc
+
+		t.Run(string(tc.Action), func(t *testing.T) {
+			svc, api, exc := test.NewService()
+			guests := newGuests(svc)
+
+			var (
+				mu    sync.Mutex
+				order []uint
+			)
+
+			for _, guest := range guests {
+				vmid := guest.VMID()
+				upid := fmt.Sprintf("UPID:test_node::::qm%s:%d:root@pam:", tc.Action, vmid)
+
+				exc.
+					On("Request", http.MethodPost, fmt.Sprintf("nodes/test_node/qemu/%d/status/%s", vmid, tc.Action), url.Values(nil)).
+					Run(func(mock.Arguments) {
+						mu.Lock()
+						defer mu.Unlock()
+						order = append(order, vmid)
+					}).
+					Return([]byte(fmt.Sprintf("{\"data\":\"%s\"}", upid)), nil).
+					Once()
+
+				expectedTask, _, taskExc := task.NewTask(
+					"test_node",
+					"::",
+					"qm"+string(tc.Action),
+					fmt.Sprintf("%d", vmid),
+					"root@pam",
+					"",
+				)
+
+				taskExc.
+					On("Request", http.MethodGet, fmt.Sprintf("nodes/test_node/tasks/%s/status", expectedTask.UPID()), url.Values(nil)).
+					Return([]byte(`{"data":{"status":"stopped"}}`), nil)
+
+				api.TaskService.On("Get", upid).Return(expectedTask, nil).Once()
+			}
+
+			results, err := svc.RunBulk(guests, tc.Action, types.BulkOptions{
+				Wait: true,
+			})
+			require.NoError(t, err)
+			require.Len(t, results, len(guests))
+
+			for i, result := range results {
+				assert.Equal(t, guests[i].VMID(), result.VMID)
+				assert.NoError(t, result.Err)
+				assert.NotNil(t, result.Task)
+				assert.False(t, result.Forced)
+			}
+
+			assert.Equal(t, tc.ExpectedOrder, order)
+
+			exc.AssertExpectations(t)
+		})
+	}
+
+	t.Run("ForceStop", func(t *testing.T) {
+		svc, api, exc := test.NewService()
+
+		guest := vm.NewVirtualMachine(
+			svc,
+			100,
+			types.KindQEMU,
+			"test_node",
+			"test_name",
+			false,
+			nil,
+		)
+
+		upid := "UPID:test_node::::qmshutdown:100:root@pam:"
+		shutdownTask := sleepingTask{duration: time.Second}
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/status/shutdown", url.Values{
+				"timeout":   {"1"},
+				"forceStop": {"1"},
+			}).
+			Return([]byte("{\"data\":\""+upid+"\"}"), nil).
+			Once()
+
+		api.TaskService.
+			On("Get", upid).
+			Return(shutdownTask, nil).
+			Once()
+
+		results, err := svc.RunBulk(
+			[]types.VirtualMachine{guest},
+			types.PowerActionShutdown,
+			types.BulkOptions{
+				IgnoreOrder:     true,
+				ShutdownTimeout: 10 * time.Millisecond,
+				ForceStop:       true,
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []types.BulkResult{{
+			VMID:   100,
+			Task:   shutdownTask,
+			Forced: true,
+		}}, results)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("GuestShutdownTimeout", func(t *testing.T) {
+		svc, api, exc := test.NewService()
+
+		guest := vm.NewVirtualMachine(
+			svc,
+			100,
+			types.KindQEMU,
+			"test_node",
+			"test_name",
+			false,
+			&types.Properties{
+				StartupOrder:    types.DefaultPropertyStartupOrder,
+				StartDelay:      types.DefaultPropertyStartDelay,
+				ShutdownTimeout: 30,
+			},
+		)
+
+		upid := "UPID:test_node::::qmshutdown:100:root@pam:"
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/status/shutdown", url.Values{
+				"timeout": {"30"},
+			}).
+			Return([]byte("{\"data\":\""+upid+"\"}"), nil).
+			Once()
+
+		api.TaskService.
+			On("Get", upid).
+			Return(finishedTask{}, nil).
+			Once()
+
+		results, err := svc.RunBulk(
+			[]types.VirtualMachine{guest},
+			types.PowerActionShutdown,
+			types.BulkOptions{
+				Wait:            true,
+				ShutdownTimeout: time.Minute,
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []types.BulkResult{{
+			VMID: 100,
+			Task: finishedTask{},
+		}}, results)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("InvalidAction", func(t *testing.T) {
+		svc, _, _ := test.NewService()
+
+		_, err := svc.RunBulk(nil, types.PowerAction("explode"), types.BulkOptions{})
+		assert.Error(t, err)
+	})
+}
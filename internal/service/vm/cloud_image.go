@@ -0,0 +1,74 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+// CreateQEMUFromCloudImage runs every step of the workflow in order, waiting
+// for each task before starting the next one. The tasks run so far are
+// returned even when a step fails.
+func (svc *Service) CreateQEMUFromCloudImage(
+	opts qemu.CloudImageOptions,
+) ([]task.Task, error) {
+	var tasks []task.Task
+
+	run := func(t task.Task, err error) error {
+		if err != nil {
+			return err
+		}
+
+		tasks = append(tasks, t)
+
+		return t.Wait()
+	}
+
+	importFrom := opts.Disk.ImportFrom
+
+	if opts.Download != nil {
+		node, err := svc.getNode(opts.Node)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Node = node
+
+		s, err := svc.api.Storage().Get(opts.DownloadStorage)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := run(s.DownloadURL(node, *opts.Download)); err != nil {
+			return tasks, err
+		}
+
+		if importFrom, err = opts.Download.VolumeID(opts.DownloadStorage); err != nil {
+			return tasks, err
+		}
+	}
+
+	createOpts, err := opts.CreateOptions(importFrom)
+	if err != nil {
+		return tasks, err
+	}
+
+	createTask, err := svc.CreateQEMU(createOpts)
+	if err := run(createTask, err); err != nil {
+		return tasks, err
+	}
+
+	if opts.Template {
+		vmTask, ok := createTask.(task.VirtualMachineTask)
+		if !ok {
+			return tasks, fmt.Errorf("unexpected task %s", createTask.UPID())
+		}
+
+		if err := run(svc.convertQEMUToTemplate(vmTask.Node(), vmTask.VMID())); err != nil {
+			return tasks, err
+		}
+	}
+
+	return tasks, nil
+}
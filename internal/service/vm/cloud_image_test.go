@@ -0,0 +1,160 @@
+package vm_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	node "github.com/xabinapal/gopve/internal/service/node/test"
+	"github.com/xabinapal/gopve/internal/service/storage"
+	storage_test "github.com/xabinapal/gopve/internal/service/storage/test"
+	task_service "github.com/xabinapal/gopve/internal/service/task"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	"github.com/xabinapal/gopve/pkg/request/mocks"
+	storage_types "github.com/xabinapal/gopve/pkg/types/storage"
+	task_types "github.com/xabinapal/gopve/pkg/types/task"
+	vm_types "github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+func TestServiceCreateQEMUFromCloudImage(t *testing.T) {
+	testNode, _ := node.NewNode()
+
+	svc, api, exc := test.NewService()
+
+	storageSvc, storageAPI, storageExc := storage_test.NewService()
+	downloadStorage := storage.NewStorage(
+		storageSvc,
+		"local",
+		storage_types.KindDir,
+		&storage_types.Properties{Content: storage_types.ContentImport},
+	)
+
+	var taskExcs []*mocks.Executor
+
+	expectTask := func(action string) (string, *task_service.Task) {
+		upid := "UPID:test_node::::" + action + ":100:root@pam:"
+
+		t, _, taskExc := task.NewTask(
+			"test_node",
+			"::",
+			action,
+			"100",
+			"root@pam",
+			"",
+		)
+
+		taskExc.
+			On("Request", http.MethodGet, "nodes/test_node/tasks/"+upid+"/status", url.Values(nil)).
+			Return([]byte("{\"data\":{\"status\":\"stopped\"}}"), nil).
+			Once()
+
+		taskExcs = append(taskExcs, taskExc)
+
+		return upid, t
+	}
+
+	api.NodeService.On("Get", "test_node").Return(testNode, nil).Twice()
+	api.StorageService.On("Get", "local").Return(downloadStorage, nil).Once()
+
+	downloadUPID, downloadTask := expectTask("download")
+
+	storageExc.
+		On("Request", http.MethodPost, "nodes/test_node/storage/local/download-url", url.Values{
+			"url":      {"https://example.com/debian-12-genericcloud-amd64.qcow2"},
+			"filename": {"debian-12.qcow2"},
+			"content":  {"import"},
+		}).
+		Return([]byte("{\"data\":\""+downloadUPID+"\"}"), nil).
+		Once()
+
+	storageAPI.TaskService.On("Get", downloadUPID).Return(downloadTask, nil).Once()
+
+	exc.On("StartAtomicBlock").Return().Once()
+	exc.On("EndAtomicBlock").Return().Once()
+
+	createUPID, rawCreateTask := expectTask("qmcreate")
+	createTask, err := task_service.NewVirtualMachineTask(rawCreateTask)
+	require.NoError(t, err)
+
+	exc.
+		On("Request", http.MethodPost, "nodes/test_node/qemu", url.Values{
+			"vmid":       {"100"},
+			"protection": {"0"},
+			"onboot":     {"0"},
+			"acpi":       {"0"},
+			"kvm":        {"0"},
+			"tablet":     {"0"},
+			"boot":       {"order=scsi0"},
+			"cpu":        {"kvm64"},
+			"sockets":    {"1"},
+			"cores":      {"1"},
+			"numa":       {"0"},
+			"freeze":     {"0"},
+			"memory":     {"512"},
+			"balloon":    {"0"},
+			"shares":     {"0"},
+			"scsi0":      {"local-lvm:0,import-from=local:import/debian-12.qcow2"},
+			"ide2":       {"local-lvm:cloudinit,media=cdrom"},
+			"agent":      {"0,freeze-fs-on-backup=0"},
+		}).
+		Return([]byte("{\"data\":\""+createUPID+"\"}"), nil).
+		Once()
+
+	api.TaskService.On("Get", createUPID).Return(createTask, nil).Once()
+
+	templateUPID, templateTask := expectTask("qmtemplate")
+
+	exc.
+		On("Request", http.MethodPost, "nodes/test_node/qemu/100/template", url.Values(nil)).
+		Return([]byte("{\"data\":\""+templateUPID+"\"}"), nil).
+		Once()
+
+	api.TaskService.On("Get", templateUPID).Return(templateTask, nil).Once()
+
+	tasks, err := svc.CreateQEMUFromCloudImage(qemu.CloudImageOptions{
+		VMID: 100,
+		Node: "test_node",
+		Download: &storage_types.DownloadURLOptions{
+			URL:      "https://example.com/debian-12-genericcloud-amd64.qcow2",
+			FileName: "debian-12.qcow2",
+			Content:  storage_types.ContentImport,
+		},
+		DownloadStorage: "local",
+		Disk: qemu.HardDriveProperties{
+			DeviceBus: qemu.BusSCSI,
+			DriveStorageProperties: qemu.DriveStorageProperties{
+				StorageName: "local-lvm",
+			},
+			Backup:    true,
+			Replicate: true,
+		},
+		CloudInitStorage: "local-lvm",
+		Template:         true,
+		Properties: qemu.Properties{
+			Properties: vm_types.Properties{
+				StartupOrder:    vm_types.DefaultPropertyStartupOrder,
+				StartDelay:      vm_types.DefaultPropertyStartDelay,
+				ShutdownTimeout: vm_types.DefaultPropertyShutdownTimeout,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]task_types.Task{downloadTask, createTask, templateTask},
+		tasks,
+	)
+
+	exc.AssertExpectations(t)
+	storageExc.AssertExpectations(t)
+	api.NodeService.AssertExpectations(t)
+
+	for _, taskExc := range taskExcs {
+		taskExc.AssertExpectations(t)
+	}
+}
@@ -0,0 +1,33 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+func (obj *QEMUVirtualMachine) RegenerateCloudInit() error {
+	return obj.svc.client.Request(http.MethodPut, fmt.Sprintf("nodes/%s/qemu/%d/cloudinit", obj.node, obj.vmid), nil, nil)
+}
+
+func (obj *QEMUVirtualMachine) DumpCloudInit(
+	kind qemu.CloudInitDumpType,
+) (string, error) {
+	if !kind.IsValid() {
+		return "", fmt.Errorf("invalid cloud-init dump type %s", kind)
+	}
+
+	values := request.Values{}
+	if err := values.AddObject("type", kind); err != nil {
+		return "", err
+	}
+
+	var res string
+	if err := obj.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/qemu/%d/cloudinit/dump", obj.node, obj.vmid), values, &res); err != nil {
+		return "", err
+	}
+
+	return res, nil
+}
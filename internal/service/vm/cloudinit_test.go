@@ -0,0 +1,54 @@
+package vm_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+func TestVirtualMachineCloudInit(t *testing.T) {
+	t.Run("Regenerate", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewQEMU()
+
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/cloudinit", url.Values(nil)).
+			Return([]byte(`{"data": null}`), nil).
+			Once()
+
+		err := virtualMachine.RegenerateCloudInit()
+		require.NoError(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Dump", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewQEMU()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/cloudinit/dump", url.Values{
+				"type": {"user"},
+			}).
+			Return([]byte(`{"data": "#cloud-config\nuser: root\n"}`), nil).
+			Once()
+
+		data, err := virtualMachine.DumpCloudInit(qemu.CloudInitDumpTypeUser)
+		require.NoError(t, err)
+		assert.Equal(t, "#cloud-config\nuser: root\n", data)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("DumpInvalidType", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewQEMU()
+
+		_, err := virtualMachine.DumpCloudInit(qemu.CloudInitDumpType("vendor"))
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,96 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+
+	internal_console "github.com/xabinapal/gopve/internal/service/console"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/console"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func (obj *VirtualMachine) VNCProxy(
+	opts console.VNCProxyOptions,
+) (console.VNCTicket, error) {
+	if opts.GeneratePassword && obj.kind != vm.KindQEMU {
+		return console.VNCTicket{}, fmt.Errorf(
+			"vnc passwords can only be generated for qemu virtual machines",
+		)
+	}
+
+	values := request.Values{}
+	values.ConditionalAddBool("websocket", true, opts.Websocket)
+	values.ConditionalAddBool(
+		"generate-password",
+		true,
+		opts.GeneratePassword,
+	)
+
+	var res internal_console.VNCTicketResponseJSON
+	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/%s/%d/vncproxy", obj.node, obj.kind.String(), obj.vmid), values, &res); err != nil {
+		return console.VNCTicket{}, err
+	}
+
+	return res.Map()
+}
+
+func (obj *VirtualMachine) SPICEProxy(
+	opts console.SPICEProxyOptions,
+) (console.SPICETicket, error) {
+	values := request.Values{}
+	values.ConditionalAddString("proxy", opts.Proxy, opts.Proxy != "")
+
+	var res internal_console.SPICETicketResponseJSON
+	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/%s/%d/spiceproxy", obj.node, obj.kind.String(), obj.vmid), values, &res); err != nil {
+		return console.SPICETicket{}, err
+	}
+
+	return res.Map()
+}
+
+func (obj *VirtualMachine) TermProxy(
+	opts console.TermProxyOptions,
+) (console.TermTicket, error) {
+	if opts.Command != "" {
+		return console.TermTicket{}, fmt.Errorf(
+			"terminal commands can only be used in node shells",
+		)
+	} else if opts.Serial != "" && obj.kind != vm.KindQEMU {
+		return console.TermTicket{}, fmt.Errorf(
+			"serial terminals are only available for qemu virtual machines",
+		)
+	}
+
+	values := request.Values{}
+	values.ConditionalAddString("serial", opts.Serial, opts.Serial != "")
+
+	var res internal_console.TermTicketResponseJSON
+	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/%s/%d/termproxy", obj.node, obj.kind.String(), obj.vmid), values, &res); err != nil {
+		return console.TermTicket{}, err
+	}
+
+	return res.Map()
+}
+
+func (obj *VirtualMachine) OpenTerminal(
+	opts console.TermProxyOptions,
+) (*console.Terminal, error) {
+	ticket, err := obj.TermProxy(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	values := request.Values{
+		"vncticket": {ticket.Ticket},
+	}
+
+	values.AddUint("port", ticket.Port)
+
+	conn, err := obj.svc.client.Websocket(fmt.Sprintf("nodes/%s/%s/%d/vncwebsocket", obj.node, obj.kind.String(), obj.vmid), values)
+	if err != nil {
+		return nil, err
+	}
+
+	return console.NewTerminal(conn, ticket.User, ticket.Ticket)
+}
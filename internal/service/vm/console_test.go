@@ -0,0 +1,149 @@
+package vm_test
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	"github.com/xabinapal/gopve/pkg/types/console"
+)
+
+func TestVirtualMachineConsole(t *testing.T) {
+	t.Run("VNCProxy", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewQEMU()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/vncproxy", url.Values{
+				"websocket":         {"1"},
+				"generate-password": {"1"},
+			}).
+			Return([]byte(`{"data": {"user": "root@pam", "ticket": "PVEVNC:TEST", "port": "5900", "cert": "test_cert", "password": "test_password", "upid": "UPID:test_node:00000000:00000000:00000000:vncproxy:100:root@pam:"}}`), nil).
+			Once()
+
+		ticket, err := virtualMachine.VNCProxy(console.VNCProxyOptions{
+			Websocket:        true,
+			GeneratePassword: true,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, console.VNCTicket{
+			User:        "root@pam",
+			Ticket:      "PVEVNC:TEST",
+			Port:        5900,
+			Certificate: "test_cert",
+			Password:    "test_password",
+			UPID:        "UPID:test_node:00000000:00000000:00000000:vncproxy:100:root@pam:",
+		}, ticket)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("VNCProxyPasswordLXC", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewLXC()
+
+		_, err := virtualMachine.VNCProxy(console.VNCProxyOptions{
+			GeneratePassword: true,
+		})
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("SPICEProxy", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewQEMU()
+
+		response, err := ioutil.ReadFile(
+			"./testdata/post_nodes_{node}_{kind}_{vmid}_spiceproxy.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/spiceproxy", url.Values{
+				"proxy": {"test_node"},
+			}).
+			Return(response, nil).
+			Once()
+
+		ticket, err := virtualMachine.SPICEProxy(console.SPICEProxyOptions{
+			Proxy: "test_node",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(61000), ticket.TLSPort)
+		assert.Equal(t, "test_password", ticket.Password)
+		assert.True(t, ticket.DeleteThisFile)
+		assert.Contains(
+			t,
+			ticket.VirtViewerFile(),
+			`ca=-----BEGIN CERTIFICATE-----\nTEST\n-----END CERTIFICATE-----\n`,
+		)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("TermProxySerialLXC", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewLXC()
+
+		_, err := virtualMachine.TermProxy(console.TermProxyOptions{
+			Serial: "serial0",
+		})
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("OpenTerminal", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewQEMU()
+
+		client, server := net.Pipe()
+
+		go func() {
+			defer server.Close()
+
+			r := bufio.NewReader(server)
+
+			login, err := r.ReadString('\n')
+			assert.NoError(t, err)
+			assert.Equal(t, "root@pam:PVEVNC:TEST\n", login)
+
+			_, err = server.Write([]byte("OKlogin: "))
+			assert.NoError(t, err)
+		}()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/termproxy", url.Values{
+				"serial": {"serial0"},
+			}).
+			Return([]byte(`{"data": {"user": "root@pam", "ticket": "PVEVNC:TEST", "port": 5900, "upid": "UPID:test_node:00000000:00000000:00000000:vncproxy:100:root@pam:"}}`), nil).
+			Once()
+
+		exc.
+			On("Websocket", "nodes/test_node/qemu/100/vncwebsocket", url.Values{
+				"port":      {"5900"},
+				"vncticket": {"PVEVNC:TEST"},
+			}).
+			Return(client, nil).
+			Once()
+
+		terminal, err := virtualMachine.OpenTerminal(console.TermProxyOptions{
+			Serial: "serial0",
+		})
+		require.NoError(t, err)
+
+		output := make([]byte, len("login: "))
+		_, err = io.ReadFull(terminal, output)
+		require.NoError(t, err)
+		assert.Equal(t, "login: ", string(output))
+
+		require.NoError(t, terminal.Close())
+
+		exc.AssertExpectations(t)
+	})
+}
@@ -2,11 +2,15 @@ package vm
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/xabinapal/gopve/pkg/request"
 	"github.com/xabinapal/gopve/pkg/types/node"
+	"github.com/xabinapal/gopve/pkg/types/storage"
 	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
 	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
 	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
 )
@@ -16,6 +20,7 @@ func (svc *Service) createVM(
 	vmid uint,
 	node string,
 	values request.Values,
+	validators ...func(node string) error,
 ) (task.Task, error) {
 	svc.client.StartAtomicBlock()
 	defer svc.client.EndAtomicBlock()
@@ -34,6 +39,12 @@ func (svc *Service) createVM(
 		return nil, err
 	}
 
+	for _, validate := range validators {
+		if err := validate(node); err != nil {
+			return nil, err
+		}
+	}
+
 	var task string
 	if err := svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/%s", node, kind), values, &task); err != nil {
 		return nil, err
@@ -51,13 +62,149 @@ func (svc *Service) CreateQEMU(opts qemu.CreateOptions) (task.Task, error) {
 	return svc.createVM("qemu", opts.VMID, opts.Node, values)
 }
 
-func (svc *Service) CreateLXC(opts lxc.CreateOptions) (task.Task, error) {
+func (svc *Service) CreateLXC(
+	opts lxc.CreateOptions,
+) (task.VirtualMachineTask, error) {
 	values, err := opts.MapToValues()
 	if err != nil {
 		return nil, err
 	}
 
-	return svc.createVM("lxc", opts.VMID, opts.Node, values)
+	t, err := svc.createVM(
+		"lxc",
+		opts.VMID,
+		opts.Node,
+		values,
+		func(node string) error {
+			return svc.validateOSTemplate(node, opts)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vmTask, ok := t.(task.VirtualMachineTask)
+	if !ok {
+		return nil, fmt.Errorf("unexpected task %s", t.UPID())
+	}
+
+	if opts.WaitUntilReady {
+		if err := svc.waitUntilLXCReady(vmTask, opts); err != nil {
+			return vmTask, err
+		}
+	}
+
+	return vmTask, nil
+}
+
+func (svc *Service) validateOSTemplate(
+	node string,
+	opts lxc.CreateOptions,
+) error {
+	s, err := svc.api.Storage().Get(opts.OSTemplateStorage)
+	if err != nil {
+		return err
+	}
+
+	if s.Content()&storage.ContentContainerTemplate == 0 {
+		return lxc.ErrInvalidTemplateStorage
+	}
+
+	var res []struct {
+		VolumeID string `json:"volid"`
+	}
+
+	if err := svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/storage/%s/content", node, opts.OSTemplateStorage), request.Values{
+		"content": {"vztmpl"},
+	}, &res); err != nil {
+		return err
+	}
+
+	for _, volume := range res {
+		if volume.VolumeID == opts.OSTemplateVolume() {
+			return nil
+		}
+	}
+
+	return lxc.ErrTemplateNotFound
+}
+
+func (svc *Service) waitUntilLXCReady(
+	t task.VirtualMachineTask,
+	opts lxc.CreateOptions,
+) error {
+	if err := t.Wait(); err != nil {
+		return err
+	}
+
+	interval := opts.PollingInterval
+	if interval == 0 {
+		interval = lxc.DefaultCreatePollingInterval
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = lxc.DefaultCreateTimeout
+	}
+
+	start := time.Now()
+
+	for {
+		if ready, err := svc.isLXCReady(t.Node(), t.VMID()); err != nil || ready {
+			return err
+		}
+
+		if time.Since(start) >= timeout {
+			return lxc.ErrCreateTimeout
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// isLXCReady reports whether the container is running and any interface
+// other than loopback has an address. Link-local addresses are ignored, as
+// they are assigned before DHCP or SLAAC finish.
+func (svc *Service) isLXCReady(node string, vmid uint) (bool, error) {
+	var status getCurrentStatusResponseJSON
+	if err := svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/lxc/%d/status/current", node, vmid), nil, &status); err != nil {
+		return false, err
+	}
+
+	if status.Status != vm.StatusRunning {
+		return false, nil
+	}
+
+	var interfaces []struct {
+		Name string `json:"name"`
+		IPv4 string `json:"inet"`
+		IPv6 string `json:"inet6"`
+	}
+
+	if err := svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/lxc/%d/interfaces", node, vmid), nil, &interfaces); err != nil {
+		return false, err
+	}
+
+	for _, iface := range interfaces {
+		if iface.Name != "lo" && (isRoutableAddress(iface.IPv4) || isRoutableAddress(iface.IPv6)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func isRoutableAddress(cidr string) bool {
+	if cidr == "" {
+		return false
+	}
+
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil {
+		ip = net.ParseIP(cidr)
+	}
+
+	return ip != nil && !ip.IsLinkLocalUnicast()
 }
 
 func (svc *Service) getVMID(vmid uint) (uint, error) {
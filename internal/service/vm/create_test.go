@@ -0,0 +1,259 @@
+package vm_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	node "github.com/xabinapal/gopve/internal/service/node/test"
+	"github.com/xabinapal/gopve/internal/service/storage"
+	storage_test "github.com/xabinapal/gopve/internal/service/storage/test"
+	task_service "github.com/xabinapal/gopve/internal/service/task"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	"github.com/xabinapal/gopve/pkg/service"
+	storage_types "github.com/xabinapal/gopve/pkg/types/storage"
+	vm_types "github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+)
+
+func TestServiceCreateLXC(t *testing.T) {
+	testNode, _ := node.NewNode()
+
+	storageSvc, _, _ := storage_test.NewService()
+	templateStorage := storage.NewStorage(
+		storageSvc,
+		"local",
+		storage_types.KindDir,
+		&storage_types.Properties{
+			Content: storage_types.ContentContainerTemplate,
+		},
+	)
+
+	opts := lxc.CreateOptions{
+		VMID:              100,
+		Node:              "test_node",
+		OSTemplateStorage: "local",
+		OSTemplate:        "debian-12-standard_12.2-1_amd64.tar.zst",
+		Password:          "test_password",
+		SSHPublicKeys:     []string{"ssh-ed25519 AAAA1", "ssh-ed25519 AAAA2"},
+		Pool:              "test_pool",
+		Unique:            true,
+		Properties: lxc.Properties{
+			Properties: vm_types.Properties{
+				StartupOrder:    vm_types.DefaultPropertyStartupOrder,
+				StartDelay:      vm_types.DefaultPropertyStartDelay,
+				ShutdownTimeout: vm_types.DefaultPropertyShutdownTimeout,
+			},
+			GlobalProperties: lxc.GlobalProperties{OSType: lxc.OSTypeDebian},
+			CPU:              lxc.CPUProperties{Cores: 1},
+			Memory:           lxc.MemoryProperties{Memory: 512},
+			RootFS: lxc.RootFSProperties{
+				VolumeProperties: lxc.VolumeProperties{
+					Volume:    "local-lvm:8",
+					Replicate: true,
+				},
+			},
+		},
+	}
+
+	setup := func(
+		opts lxc.CreateOptions,
+	) (service.VirtualMachine, *task_service.VirtualMachineTask, func(t *testing.T)) {
+		svc, api, exc := test.NewService()
+
+		exc.On("StartAtomicBlock").Return().Once()
+		exc.On("EndAtomicBlock").Return().Once()
+
+		api.NodeService.On("Get", "test_node").Return(testNode, nil).Once()
+		api.StorageService.On("Get", "local").Return(templateStorage, nil).Once()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/storage/local/content", url.Values{
+				"content": {"vztmpl"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":[{\"volid\":\"local:vztmpl/debian-12-standard_12.2-1_amd64.tar.zst\"}]}",
+				),
+				nil,
+			).
+			Once()
+
+		values := url.Values{
+			"vmid":            {"100"},
+			"ostemplate":      {"local:vztmpl/debian-12-standard_12.2-1_amd64.tar.zst"},
+			"password":        {"test_password"},
+			"ssh-public-keys": {"ssh-ed25519 AAAA1\nssh-ed25519 AAAA2"},
+			"pool":            {"test_pool"},
+			"unique":          {"1"},
+			"protection":      {"0"},
+			"onboot":          {"0"},
+			"ostype":          {"debian"},
+			"unprivileged":    {"0"},
+			"cores":           {"1"},
+			"memory":          {"512"},
+			"swap":            {"0"},
+			"rootfs":          {"local-lvm:8"},
+		}
+
+		if opts.WaitUntilReady {
+			values["start"] = []string{"1"}
+		}
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/lxc", values).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::vzcreate:100:root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		rawTask, _, taskExc := task.NewTask(
+			"test_node",
+			"::",
+			"vzcreate",
+			"100",
+			"root@pam",
+			"",
+		)
+
+		expectedTask, err := task_service.NewVirtualMachineTask(rawTask)
+		require.NoError(t, err)
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::vzcreate:100:root@pam:").
+			Return(expectedTask, nil)
+
+		if opts.WaitUntilReady {
+			taskExc.
+				On("Request", http.MethodGet, "nodes/test_node/tasks/UPID:test_node::::vzcreate:100:root@pam:/status", url.Values(nil)).
+				Return([]byte("{\"data\":{\"status\":\"stopped\"}}"), nil).
+				Once()
+
+			exc.
+				On("Request", http.MethodGet, "nodes/test_node/lxc/100/status/current", url.Values(nil)).
+				Return([]byte("{\"data\":{\"status\":\"running\"}}"), nil).
+				Twice()
+
+			exc.
+				On("Request", http.MethodGet, "nodes/test_node/lxc/100/interfaces", url.Values(nil)).
+				Return([]byte("{\"data\":[{\"name\":\"lo\",\"inet\":\"127.0.0.1/8\"},{\"name\":\"eth0\",\"inet6\":\"fe80::1/64\"}]}"), nil).
+				Once()
+
+			exc.
+				On("Request", http.MethodGet, "nodes/test_node/lxc/100/interfaces", url.Values(nil)).
+				Return([]byte("{\"data\":[{\"name\":\"lo\",\"inet\":\"127.0.0.1/8\"},{\"name\":\"eth0\",\"inet\":\"10.0.0.2/24\"}]}"), nil).
+				Once()
+		}
+
+		return svc, expectedTask, func(t *testing.T) {
+			exc.AssertExpectations(t)
+			taskExc.AssertExpectations(t)
+		}
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		svc, expectedTask, assertExpectations := setup(opts)
+
+		task, err := svc.CreateLXC(opts)
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+		assert.Equal(t, uint(100), task.VMID())
+		assert.True(t, task.IsLXC())
+
+		assertExpectations(t)
+	})
+
+	t.Run("WaitUntilReady", func(t *testing.T) {
+		opts := opts
+		opts.WaitUntilReady = true
+		opts.PollingInterval = time.Millisecond
+
+		svc, expectedTask, assertExpectations := setup(opts)
+
+		task, err := svc.CreateLXC(opts)
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		assertExpectations(t)
+	})
+}
+
+func TestServiceCreateLXCValidation(t *testing.T) {
+	testNode, _ := node.NewNode()
+
+	storageSvc, _, _ := storage_test.NewService()
+
+	opts := lxc.CreateOptions{
+		Node:              "test_node",
+		VMID:              100,
+		OSTemplateStorage: "local",
+		OSTemplate:        "missing.tar.zst",
+		Properties: lxc.Properties{
+			RootFS: lxc.RootFSProperties{
+				VolumeProperties: lxc.VolumeProperties{Volume: "local-lvm:8"},
+			},
+		},
+	}
+
+	t.Run("InvalidStorageContent", func(t *testing.T) {
+		svc, api, exc := test.NewService()
+
+		exc.On("StartAtomicBlock").Return().Once()
+		exc.On("EndAtomicBlock").Return().Once()
+
+		api.NodeService.On("Get", "test_node").Return(testNode, nil).Once()
+		api.StorageService.
+			On("Get", "local").
+			Return(storage.NewStorage(
+				storageSvc,
+				"local",
+				storage_types.KindDir,
+				&storage_types.Properties{Content: storage_types.ContentISO},
+			), nil).
+			Once()
+
+		_, err := svc.CreateLXC(opts)
+		assert.Equal(t, lxc.ErrInvalidTemplateStorage, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("TemplateNotFound", func(t *testing.T) {
+		svc, api, exc := test.NewService()
+
+		exc.On("StartAtomicBlock").Return().Once()
+		exc.On("EndAtomicBlock").Return().Once()
+
+		api.NodeService.On("Get", "test_node").Return(testNode, nil).Once()
+		api.StorageService.
+			On("Get", "local").
+			Return(storage.NewStorage(
+				storageSvc,
+				"local",
+				storage_types.KindDir,
+				&storage_types.Properties{
+					Content: storage_types.ContentContainerTemplate,
+				},
+			), nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/storage/local/content", url.Values{
+				"content": {"vztmpl"},
+			}).
+			Return([]byte("{\"data\":[]}"), nil).
+			Once()
+
+		_, err := svc.CreateLXC(opts)
+		assert.Equal(t, lxc.ErrTemplateNotFound, err)
+
+		exc.AssertExpectations(t)
+	})
+}
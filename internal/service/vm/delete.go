@@ -4,19 +4,41 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/xabinapal/gopve/pkg/request"
 	"github.com/xabinapal/gopve/pkg/types/task"
 	"github.com/xabinapal/gopve/pkg/types/vm"
 )
 
 func (svc *Service) deleteVM(
-	kind string,
+	kind vm.Kind,
 	vmid uint,
-	node string,
-	purge bool,
-	force bool,
+	opts vm.DeleteOptions,
 ) (task.Task, error) {
+	virtualMachine, err := svc.Get(vmid)
+	if err != nil {
+		return nil, err
+	}
+
+	if virtualMachine.Kind() != kind {
+		return nil, fmt.Errorf("invalid virtual machine kind")
+	}
+
+	if opts.Force && kind != vm.KindLXC {
+		return nil, fmt.Errorf("only containers can be force deleted")
+	}
+
+	if !opts.IgnoreLinkedClones {
+		if err := svc.checkTemplateDependents(virtualMachine); err != nil {
+			return nil, err
+		}
+	}
+
+	values := request.Values{}
+	values.ConditionalAddBool("purge", true, opts.Purge)
+	values.ConditionalAddBool("force", true, opts.Force)
+
 	var task string
-	if err := svc.client.Request(http.MethodDelete, fmt.Sprintf("nodes/%s/%s/%d", node, kind, vmid), nil, &task); err != nil {
+	if err := svc.client.Request(http.MethodDelete, fmt.Sprintf("nodes/%s/%s/%d", virtualMachine.Node(), kind, vmid), values, &task); err != nil {
 		return nil, err
 	}
 
@@ -25,34 +47,14 @@ func (svc *Service) deleteVM(
 
 func (svc *Service) DeleteQEMU(
 	vmid uint,
-	purge bool,
-	force bool,
+	opts vm.DeleteOptions,
 ) (task.Task, error) {
-	virtualMachine, err := svc.Get(vmid)
-	if err != nil {
-		return nil, err
-	}
-
-	if virtualMachine.Kind() == vm.KindQEMU {
-		return svc.deleteVM("qemu", vmid, virtualMachine.Node(), purge, force)
-	}
-
-	return nil, fmt.Errorf("invalid virtual machine kind")
+	return svc.deleteVM(vm.KindQEMU, vmid, opts)
 }
 
 func (svc *Service) DeleteLXC(
 	vmid uint,
-	purge bool,
-	force bool,
+	opts vm.DeleteOptions,
 ) (task.Task, error) {
-	virtualMachine, err := svc.Get(vmid)
-	if err != nil {
-		return nil, err
-	}
-
-	if virtualMachine.Kind() == vm.KindLXC {
-		return svc.deleteVM("lxc", vmid, virtualMachine.Node(), purge, force)
-	}
-
-	return nil, fmt.Errorf("invalid virtual machine kind")
+	return svc.deleteVM(vm.KindLXC, vmid, opts)
 }
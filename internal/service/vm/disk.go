@@ -0,0 +1,223 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+func getMoveDiskValues(opts vm.MoveDiskOptions) (request.Values, error) {
+	if opts.TargetStorage == "" {
+		return nil, fmt.Errorf("target storage is required to move a disk")
+	}
+
+	values := request.Values{
+		"storage": {opts.TargetStorage},
+	}
+
+	values.ConditionalAddBool("delete", true, opts.DeleteSource)
+	values.ConditionalAddUint(
+		"bwlimit",
+		opts.BandwidthLimit,
+		opts.BandwidthLimit != 0,
+	)
+	values.ConditionalAddString("digest", opts.Digest, opts.Digest != "")
+
+	return values, nil
+}
+
+func (obj *QEMUVirtualMachine) ResizeDisk(
+	disk string,
+	size vm.DiskSize,
+) (task.Task, error) {
+	values := request.Values{
+		"disk": {disk},
+	}
+
+	if err := values.AddObject("size", size); err != nil {
+		return nil, err
+	}
+
+	var upid string
+	if err := obj.svc.client.Request(http.MethodPut, fmt.Sprintf("nodes/%s/qemu/%d/resize", obj.node, obj.vmid), values, &upid); err != nil {
+		return nil, err
+	}
+
+	obj.props = nil
+
+	if upid == "" {
+		return task.NewCompletedTask(obj.node, strconv.Itoa(int(obj.vmid))), nil
+	}
+
+	return obj.svc.api.Task().Get(upid)
+}
+
+func (obj *QEMUVirtualMachine) MoveDisk(
+	disk string,
+	opts vm.MoveDiskOptions,
+) (task.Task, error) {
+	values, err := getMoveDiskValues(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	values.AddString("disk", disk)
+
+	if opts.Format != 0 {
+		if err := values.AddObject("format", opts.Format); err != nil {
+			return nil, err
+		}
+	}
+
+	var task string
+	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/qemu/%d/move_disk", obj.node, obj.vmid), values, &task); err != nil {
+		return nil, err
+	}
+
+	obj.props = nil
+
+	return obj.svc.api.Task().Get(task)
+}
+
+func (obj *QEMUVirtualMachine) ImportDisk(
+	disk qemu.HardDriveProperties,
+) (task.Task, error) {
+	if disk.ImportFrom == "" {
+		return nil, fmt.Errorf("disk %s has nothing to import", disk.Name())
+	}
+
+	disk.StorageFile = "0"
+
+	values := request.Values{}
+	if err := values.AddObject(disk.Name(), disk); err != nil {
+		return nil, err
+	}
+
+	var task string
+	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/qemu/%d/config", obj.node, obj.vmid), values, &task); err != nil {
+		return nil, err
+	}
+
+	obj.VirtualMachine.props = nil
+	obj.props = nil
+
+	return obj.svc.api.Task().Get(task)
+}
+
+func (obj *QEMUVirtualMachine) unlinkDisk(disk string, force bool) error {
+	values := request.Values{
+		"idlist": {disk},
+	}
+
+	values.ConditionalAddBool("force", true, force)
+
+	if err := obj.svc.client.Request(http.MethodPut, fmt.Sprintf("nodes/%s/qemu/%d/unlink", obj.node, obj.vmid), values, nil); err != nil {
+		return err
+	}
+
+	obj.props = nil
+
+	return nil
+}
+
+func (obj *QEMUVirtualMachine) DetachDisk(disk string) error {
+	if strings.HasPrefix(disk, "unused") {
+		return fmt.Errorf("disk %s is already detached", disk)
+	}
+
+	return obj.unlinkDisk(disk, false)
+}
+
+func (obj *QEMUVirtualMachine) DeleteUnusedDisk(disk string) error {
+	if !strings.HasPrefix(disk, "unused") {
+		return fmt.Errorf("disk %s must be detached before deleting it", disk)
+	}
+
+	return obj.unlinkDisk(disk, true)
+}
+
+func (obj *LXCVirtualMachine) ResizeVolume(
+	volume string,
+	size vm.DiskSize,
+) (task.Task, error) {
+	values := request.Values{
+		"disk": {volume},
+	}
+
+	if err := values.AddObject("size", size); err != nil {
+		return nil, err
+	}
+
+	var task string
+	if err := obj.svc.client.Request(http.MethodPut, fmt.Sprintf("nodes/%s/lxc/%d/resize", obj.node, obj.vmid), values, &task); err != nil {
+		return nil, err
+	}
+
+	obj.props = nil
+
+	return obj.svc.api.Task().Get(task)
+}
+
+func (obj *LXCVirtualMachine) MoveVolume(
+	volume string,
+	opts vm.MoveDiskOptions,
+) (task.Task, error) {
+	if opts.Format != 0 {
+		return nil, fmt.Errorf(
+			"image format can't be converted when moving lxc volumes",
+		)
+	}
+
+	values, err := getMoveDiskValues(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	values.AddString("volume", volume)
+
+	var task string
+	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/lxc/%d/move_volume", obj.node, obj.vmid), values, &task); err != nil {
+		return nil, err
+	}
+
+	obj.props = nil
+
+	return obj.svc.api.Task().Get(task)
+}
+
+func (obj *LXCVirtualMachine) deleteVolume(volume string) error {
+	if err := obj.svc.client.Request(http.MethodPut, fmt.Sprintf("nodes/%s/lxc/%d/config", obj.node, obj.vmid), request.Values{
+		"delete": {volume},
+	}, nil); err != nil {
+		return err
+	}
+
+	obj.props = nil
+
+	return nil
+}
+
+func (obj *LXCVirtualMachine) DetachVolume(volume string) error {
+	if !strings.HasPrefix(volume, "mp") {
+		return fmt.Errorf("only mount points can be detached")
+	}
+
+	return obj.deleteVolume(volume)
+}
+
+func (obj *LXCVirtualMachine) DeleteUnusedVolume(volume string) error {
+	if !strings.HasPrefix(volume, "unused") {
+		return fmt.Errorf(
+			"volume %s must be detached before deleting it",
+			volume,
+		)
+	}
+
+	return obj.deleteVolume(volume)
+}
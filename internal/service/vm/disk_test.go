@@ -0,0 +1,212 @@
+package vm_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	"github.com/xabinapal/gopve/pkg/types/storage"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestQEMUDisk(t *testing.T) {
+	virtualMachine, api, exc := test.NewQEMU()
+
+	t.Run("Resize", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/resize", url.Values{
+				"disk": {"scsi0"},
+				"size": {"+10G"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::resize:100:root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		expectedTask, _, _ := task.NewTask(
+			"test_node",
+			"::",
+			"resize",
+			"100",
+			"root@pam",
+			"",
+		)
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::resize:100:root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := virtualMachine.ResizeDisk("scsi0", types.DiskSize{
+			Bytes:     10 * types.DiskSizeGibibyte,
+			Increment: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("ResizeWithoutTask", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/resize", url.Values{
+				"disk": {"scsi0"},
+				"size": {"40G"},
+			}).
+			Return([]byte("{\"data\":null}"), nil).
+			Once()
+
+		task, err := virtualMachine.ResizeDisk("scsi0", types.DiskSize{
+			Bytes: 40 * types.DiskSizeGibibyte,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, task)
+		assert.Equal(t, "test_node", task.Node())
+		assert.NoError(t, task.Wait())
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Move", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/move_disk", url.Values{
+				"disk":    {"scsi0"},
+				"storage": {"local"},
+				"format":  {"qcow2"},
+				"delete":  {"1"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::qmmove:100:root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		expectedTask, _, _ := task.NewTask(
+			"test_node",
+			"::",
+			"qmmove",
+			"100",
+			"root@pam",
+			"",
+		)
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::qmmove:100:root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := virtualMachine.MoveDisk("scsi0", types.MoveDiskOptions{
+			TargetStorage: "local",
+			Format:        storage.ImageFormatQcow2,
+			DeleteSource:  true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Detach", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/unlink", url.Values{
+				"idlist": {"scsi1"},
+			}).
+			Return(nil, nil).
+			Once()
+
+		err := virtualMachine.DetachDisk("scsi1")
+		require.NoError(t, err)
+
+		err = virtualMachine.DetachDisk("unused0")
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("DeleteUnused", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/unlink", url.Values{
+				"idlist": {"unused0"},
+				"force":  {"1"},
+			}).
+			Return(nil, nil).
+			Once()
+
+		err := virtualMachine.DeleteUnusedDisk("unused0")
+		require.NoError(t, err)
+
+		err = virtualMachine.DeleteUnusedDisk("scsi0")
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+}
+
+func TestLXCVolume(t *testing.T) {
+	virtualMachine, api, exc := test.NewLXC()
+
+	t.Run("Resize", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/lxc/100/resize", url.Values{
+				"disk": {"rootfs"},
+				"size": {"16G"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::resize:100:root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		expectedTask, _, _ := task.NewTask(
+			"test_node",
+			"::",
+			"resize",
+			"100",
+			"root@pam",
+			"",
+		)
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::resize:100:root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := virtualMachine.ResizeVolume("rootfs", types.DiskSize{
+			Bytes: 16 * types.DiskSizeGibibyte,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Move", func(t *testing.T) {
+		_, err := virtualMachine.MoveVolume("mp0", types.MoveDiskOptions{
+			TargetStorage: "local",
+			Format:        storage.ImageFormatQcow2,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("DeleteUnused", func(t *testing.T) {
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/lxc/100/config", url.Values{
+				"delete": {"unused0"},
+			}).
+			Return(nil, nil).
+			Once()
+
+		err := virtualMachine.DeleteUnusedVolume("unused0")
+		require.NoError(t, err)
+
+		exc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,332 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/export"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+func (svc *Service) Export(vmid uint) (export.Document, error) {
+	guest, err := svc.Get(vmid)
+	if err != nil {
+		return export.Document{}, err
+	}
+
+	doc := export.Document{
+		Version:  export.DocumentVersion,
+		VMID:     guest.VMID(),
+		Kind:     guest.Kind(),
+		Node:     guest.Node(),
+		Name:     guest.Name(),
+		Template: guest.Template(),
+	}
+
+	var config request.Values
+
+	switch guest := guest.(type) {
+	case qemu.VirtualMachine:
+		props, err := guest.GetQEMUProperties()
+		if err != nil {
+			return doc, err
+		}
+
+		if config, err = props.MapToValues(); err != nil {
+			return doc, err
+		}
+
+		config.ConditionalAddString("name", doc.Name, doc.Name != "")
+
+		for _, unused := range props.Storage.Unused {
+			doc.Unused = append(
+				doc.Unused,
+				fmt.Sprintf("%s:%s", unused.StorageName, unused.StorageFile),
+			)
+		}
+	case lxc.VirtualMachine:
+		props, err := guest.GetLXCProperties()
+		if err != nil {
+			return doc, err
+		}
+
+		if config, err = props.MapToValues(); err != nil {
+			return doc, err
+		}
+	default:
+		return doc, vm.ErrInvalidKind
+	}
+
+	doc.Config = flattenValues(config)
+
+	if doc.Firewall, err = exportFirewall(guest); err != nil {
+		return doc, err
+	}
+
+	snapshots, err := guest.ListSnapshots()
+	if err != nil {
+		return doc, err
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.IsCurrent() {
+			continue
+		}
+
+		doc.Snapshots = append(doc.Snapshots, export.Snapshot{
+			Name:        snapshot.Name(),
+			Description: snapshot.Description(),
+			Parent:      snapshot.Parent(),
+			Time:        snapshot.Timestamp(),
+			WithRAM:     snapshot.WithRAM(),
+		})
+	}
+
+	resources, err := svc.ListResources()
+	if err != nil {
+		return doc, err
+	}
+
+	for _, r := range resources {
+		if r.VMID == vmid {
+			doc.Pool = r.Pool
+		}
+	}
+
+	ha, err := guest.GetHAProperties()
+	if err != nil {
+		return doc, err
+	} else if ha != nil {
+		doc.HA = export.NewHA(*ha)
+	}
+
+	return doc, nil
+}
+
+func exportFirewall(guest vm.VirtualMachine) (*export.Firewall, error) {
+	props, err := guest.GetFirewallProperties()
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := props.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &export.Firewall{
+		Options: flattenValues(options),
+	}
+
+	rules, err := guest.ListFirewallRules()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		values, err := rule.MapToValues(false)
+		if err != nil {
+			return nil, err
+		}
+
+		fw.Rules = append(fw.Rules, flattenValues(values))
+	}
+
+	aliases, err := guest.ListFirewallAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, alias := range aliases {
+		fw.Aliases = append(fw.Aliases, export.Alias{
+			Name:    alias.Name(),
+			CIDR:    alias.Address(),
+			Comment: alias.Description(),
+		})
+	}
+
+	ipsets, err := guest.ListFirewallIPSets()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ipset := range ipsets {
+		addresses, err := ipset.ListAddresses()
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]export.IPSetEntry, len(addresses))
+		for i, address := range addresses {
+			entries[i] = export.IPSetEntry{
+				CIDR:    address.Address,
+				Comment: address.Description,
+				NoMatch: address.NoMatch,
+			}
+		}
+
+		fw.IPSets = append(fw.IPSets, export.IPSet{
+			Name:    ipset.Name(),
+			Comment: ipset.Description(),
+			Entries: entries,
+		})
+	}
+
+	return fw, nil
+}
+
+// flattenValues drops the keys that only make sense for the source guest.
+func flattenValues(values request.Values) map[string]string {
+	m := make(map[string]string, len(values))
+
+	for k := range values {
+		if k == "digest" || k == "delete" {
+			continue
+		}
+
+		m[k] = url.Values(values).Get(k)
+	}
+
+	return m
+}
+
+func (svc *Service) Import(
+	doc export.Document,
+	opts export.ImportOptions,
+) ([]task.Task, error) {
+	values, err := doc.CreateValues(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	vmid := doc.VMID
+	if opts.VMID != 0 {
+		vmid = opts.VMID
+	}
+
+	node := doc.Node
+	if opts.Node != "" {
+		node = opts.Node
+	}
+
+	t, err := svc.createVM(doc.Kind.String(), vmid, node, values)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []task.Task{t}
+
+	if err := t.Wait(); err != nil {
+		return tasks, err
+	}
+
+	path := fmt.Sprintf("nodes/%s/%s/%d/firewall", node, doc.Kind, vmid)
+
+	if doc.Firewall != nil && !opts.SkipFirewall {
+		if err := svc.importFirewall(path, *doc.Firewall); err != nil {
+			return tasks, err
+		}
+	}
+
+	if doc.Pool != "" && !opts.SkipPool {
+		p, err := svc.api.Pool().Get(doc.Pool)
+		if err != nil {
+			return tasks, err
+		}
+
+		if err := p.AddVirtualMachine(vmid); err != nil {
+			return tasks, err
+		}
+	}
+
+	if (doc.HA == nil || opts.SkipHA) && !doc.Template {
+		return tasks, nil
+	}
+
+	guest, err := svc.Get(vmid)
+	if err != nil {
+		return tasks, err
+	}
+
+	if doc.HA != nil && !opts.SkipHA {
+		props := doc.HA.Properties()
+		if err := guest.SetHAProperties(&props); err != nil {
+			return tasks, err
+		}
+	}
+
+	if doc.Template {
+		if err := guest.ConvertToTemplate(); err != nil {
+			return tasks, err
+		}
+	}
+
+	return tasks, nil
+}
+
+func (svc *Service) importFirewall(path string, fw export.Firewall) error {
+	if len(fw.Options) != 0 {
+		if err := svc.client.Request(http.MethodPut, path+"/options", unflattenValues(fw.Options), nil); err != nil {
+			return err
+		}
+	}
+
+	for _, alias := range fw.Aliases {
+		values := request.Values{}
+		values.AddString("name", alias.Name)
+		values.AddString("cidr", alias.CIDR)
+		values.ConditionalAddString("comment", alias.Comment, alias.Comment != "")
+
+		if err := svc.client.Request(http.MethodPost, path+"/aliases", values, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, ipset := range fw.IPSets {
+		values := request.Values{}
+		values.AddString("name", ipset.Name)
+		values.ConditionalAddString("comment", ipset.Comment, ipset.Comment != "")
+
+		if err := svc.client.Request(http.MethodPost, path+"/ipset", values, nil); err != nil {
+			return err
+		}
+
+		for _, entry := range ipset.Entries {
+			values := request.Values{}
+			values.AddString("cidr", entry.CIDR)
+			values.ConditionalAddString("comment", entry.Comment, entry.Comment != "")
+			values.ConditionalAddBool("nomatch", entry.NoMatch, entry.NoMatch)
+
+			if err := svc.client.Request(http.MethodPost, fmt.Sprintf("%s/ipset/%s", path, ipset.Name), values, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Rules are inserted at their position, as PVE adds new rules on top.
+	for i, rule := range fw.Rules {
+		values := unflattenValues(rule)
+		values.AddUint("pos", uint(i))
+
+		if err := svc.client.Request(http.MethodPost, path+"/rules", values, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unflattenValues(m map[string]string) request.Values {
+	values := make(request.Values, len(m))
+
+	for k, v := range m {
+		values.AddString(k, v)
+	}
+
+	return values
+}
@@ -0,0 +1,104 @@
+package vm_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	node "github.com/xabinapal/gopve/internal/service/node/test"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	task_types "github.com/xabinapal/gopve/pkg/types/task"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/export"
+)
+
+type finishedTask struct {
+	task_types.Task
+}
+
+func (finishedTask) Wait() error {
+	return nil
+}
+
+func TestServiceImport(t *testing.T) {
+	svc, api, exc := test.NewService()
+	testNode, _ := node.NewNode()
+
+	doc := export.Document{
+		Version: export.DocumentVersion,
+		VMID:    100,
+		Kind:    types.KindQEMU,
+		Node:    "source_node",
+		Config: map[string]string{
+			"name":  "web",
+			"scsi0": "local-lvm:vm-100-disk-0,size=32G",
+			"net0":  "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0",
+		},
+		Firewall: &export.Firewall{
+			Options: map[string]string{"enable": "1"},
+			Rules: []map[string]string{
+				{"type": "in", "action": "ACCEPT", "enable": "1"},
+				{"type": "in", "action": "DROP", "enable": "1"},
+			},
+			Aliases: []export.Alias{{Name: "gateway", CIDR: "10.0.0.1"}},
+			IPSets: []export.IPSet{{
+				Name:    "trusted",
+				Entries: []export.IPSetEntry{{CIDR: "10.0.0.0/8", NoMatch: true}},
+			}},
+		},
+	}
+
+	exc.On("StartAtomicBlock").Return().Once()
+	exc.On("EndAtomicBlock").Return().Once()
+
+	api.NodeService.On("Get", "test_node").Return(testNode, nil).Once()
+
+	exc.
+		On("Request", http.MethodPost, "nodes/test_node/qemu", url.Values{
+			"vmid":  {"200"},
+			"name":  {"web"},
+			"scsi0": {"ceph:32"},
+			"net0":  {"virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr1"},
+		}).
+		Return([]byte(`{"data":"test_upid"}`), nil).
+		Once()
+
+	api.TaskService.
+		On("Get", "test_upid").
+		Return(finishedTask{}, nil).
+		Once()
+
+	path := "nodes/test_node/qemu/200/firewall"
+
+	for _, call := range []struct {
+		Method string
+		Path   string
+		Values url.Values
+	}{
+		{http.MethodPut, path + "/options", url.Values{"enable": {"1"}}},
+		{http.MethodPost, path + "/aliases", url.Values{"name": {"gateway"}, "cidr": {"10.0.0.1"}}},
+		{http.MethodPost, path + "/ipset", url.Values{"name": {"trusted"}}},
+		{http.MethodPost, path + "/ipset/trusted", url.Values{"cidr": {"10.0.0.0/8"}, "nomatch": {"1"}}},
+		{http.MethodPost, path + "/rules", url.Values{"type": {"in"}, "action": {"ACCEPT"}, "enable": {"1"}, "pos": {"0"}}},
+		{http.MethodPost, path + "/rules", url.Values{"type": {"in"}, "action": {"DROP"}, "enable": {"1"}, "pos": {"1"}}},
+	} {
+		exc.
+			On("Request", call.Method, call.Path, call.Values).
+			Return([]byte{}, nil).
+			Once()
+	}
+
+	tasks, err := svc.Import(doc, export.ImportOptions{
+		VMID:       200,
+		Node:       "test_node",
+		StorageMap: map[string]string{"local-lvm": "ceph"},
+		BridgeMap:  map[string]string{"vmbr0": "vmbr1"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	exc.AssertExpectations(t)
+	api.TaskService.AssertExpectations(t)
+}
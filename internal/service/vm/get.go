@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"time"
 
 	internal_types "github.com/xabinapal/gopve/internal/types"
 	"github.com/xabinapal/gopve/pkg/request"
@@ -19,6 +20,50 @@ type listResponseJSON struct {
 	Node     string                 `json:"node"`
 	Name     string                 `json:"name"`
 	Template internal_types.PVEBool `json:"template"`
+
+	Status vm.Status `json:"status"`
+	Uptime uint64    `json:"uptime"`
+	Lock   string    `json:"lock"`
+
+	CPU  float64 `json:"cpu"`
+	CPUs float64 `json:"maxcpu"`
+
+	Memory      uint64 `json:"mem"`
+	MemoryTotal uint64 `json:"maxmem"`
+
+	Disk      uint64 `json:"disk"`
+	DiskTotal uint64 `json:"maxdisk"`
+
+	Pool    string  `json:"pool"`
+	HAState string  `json:"hastate"`
+	Tags    vm.Tags `json:"tags"`
+}
+
+func (res listResponseJSON) MapResource() vm.Resource {
+	return vm.Resource{
+		VMID:     res.VMID,
+		Kind:     res.Kind,
+		Node:     res.Node,
+		Name:     res.Name,
+		Template: res.Template.Bool(),
+
+		Status: res.Status,
+		Uptime: time.Duration(res.Uptime) * time.Second,
+		Lock:   res.Lock,
+
+		CPU:  res.CPU,
+		CPUs: uint(res.CPUs),
+
+		Memory:      res.Memory,
+		MemoryTotal: res.MemoryTotal,
+
+		Disk:      res.Disk,
+		DiskTotal: res.DiskTotal,
+
+		Pool:    res.Pool,
+		HAState: res.HAState,
+		Tags:    res.Tags,
+	}
 }
 
 func (res listResponseJSON) Map(svc *Service) (vm.VirtualMachine, error) {
@@ -88,6 +133,95 @@ func (svc *Service) ListByKind(kind vm.Kind) ([]vm.VirtualMachine, error) {
 	return vms, nil
 }
 
+// ListByTags returns the guests whose tags match the filter, using the tags
+// reported by the cluster resources so no guest config is loaded.
+func (svc *Service) ListByTags(filter vm.TagFilter) ([]vm.VirtualMachine, error) {
+	var res []listResponseJSON
+	if err := svc.client.Request(http.MethodGet, "cluster/resources", request.Values{
+		"type": {"vm"},
+	}, &res); err != nil {
+		return nil, err
+	}
+
+	var vms []vm.VirtualMachine
+
+	for _, vm := range res {
+		if filter.Matches(vm.Tags) {
+			out, err := vm.Map(svc)
+			if err != nil {
+				return nil, err
+			}
+
+			vms = append(vms, out)
+		}
+	}
+
+	sort.Slice(vms, func(i, j int) bool {
+		return vms[i].VMID() < vms[j].VMID()
+	})
+
+	return vms, nil
+}
+
+func (svc *Service) ListResources() ([]vm.Resource, error) {
+	var res []listResponseJSON
+	if err := svc.client.Request(http.MethodGet, "cluster/resources", request.Values{
+		"type": {"vm"},
+	}, &res); err != nil {
+		return nil, err
+	}
+
+	resources := make([]vm.Resource, len(res))
+	for i, r := range res {
+		resources[i] = r.MapResource()
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].VMID < resources[j].VMID
+	})
+
+	return resources, nil
+}
+
+func (svc *Service) QueryResources(
+	query *vm.ResourceQuery,
+) ([]vm.Resource, error) {
+	resources, err := svc.ListResources()
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Apply(resources), nil
+}
+
+// Hydrate returns the guest of each resource without loading their config,
+// which is only requested once a property is accessed.
+func (svc *Service) Hydrate(
+	resources ...vm.Resource,
+) ([]vm.VirtualMachine, error) {
+	vms := make([]vm.VirtualMachine, len(resources))
+
+	for i, r := range resources {
+		out, err := NewDynamicVirtualMachine(
+			svc,
+			r.VMID,
+			r.Kind,
+			r.Node,
+			r.Name,
+			r.Template,
+			nil,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		vms[i] = out
+	}
+
+	return vms, nil
+}
+
 type getResponseJSON struct {
 	Name     string                 `json:"name"`
 	Template internal_types.PVEBool `json:"template"`
@@ -0,0 +1,96 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type getHAResourceResponseJSON struct {
+	SID         string     `json:"sid"`
+	State       vm.HAState `json:"state"`
+	Group       string     `json:"group"`
+	MaxRestart  *uint      `json:"max_restart"`
+	MaxRelocate *uint      `json:"max_relocate"`
+	Comment     string     `json:"comment"`
+}
+
+func (res getHAResourceResponseJSON) Map() vm.HAProperties {
+	props := vm.NewHAProperties()
+
+	if res.State != "" {
+		props.State = res.State
+	}
+
+	props.Group = res.Group
+	props.Comment = res.Comment
+
+	if res.MaxRestart != nil {
+		props.MaxRestart = *res.MaxRestart
+	}
+
+	if res.MaxRelocate != nil {
+		props.MaxRelocate = *res.MaxRelocate
+	}
+
+	return props
+}
+
+func (obj *VirtualMachine) GetHAProperties() (*vm.HAProperties, error) {
+	var res []getHAResourceResponseJSON
+	if err := obj.svc.client.Request(http.MethodGet, "cluster/ha/resources", nil, &res); err != nil {
+		return nil, err
+	}
+
+	sid := obj.getHighAvailabilitySID()
+
+	for _, r := range res {
+		if r.SID == sid {
+			props := r.Map()
+			return &props, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (obj *VirtualMachine) SetHAProperties(props *vm.HAProperties) error {
+	current, err := obj.GetHAProperties()
+	if err != nil {
+		return err
+	}
+
+	sid := obj.getHighAvailabilitySID()
+
+	if props == nil {
+		if current == nil {
+			return nil
+		}
+
+		return obj.svc.client.Request(http.MethodDelete, fmt.Sprintf("cluster/ha/resources/%s", sid), nil, nil)
+	}
+
+	form, err := props.MapToValues()
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		form.AddString("sid", sid)
+		return obj.svc.client.Request(http.MethodPost, "cluster/ha/resources", form, nil)
+	}
+
+	var keys []string
+	for _, k := range []string{"comment", "group"} {
+		if _, ok := form[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	delete := internal_types.NewPVEList(",", keys)
+	form.ConditionalAddObject("delete", delete, delete.Len() != 0)
+
+	return obj.svc.client.Request(http.MethodPut, fmt.Sprintf("cluster/ha/resources/%s", sid), form, nil)
+}
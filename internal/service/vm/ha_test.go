@@ -0,0 +1,117 @@
+package vm_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestVirtualMachineHAProperties(t *testing.T) {
+	svc, _, exc := test.NewService()
+
+	virtualMachine := vm.NewVirtualMachine(
+		svc,
+		100,
+		types.KindQEMU,
+		"test_node",
+		"test_name",
+		false,
+		nil,
+	)
+
+	resources := func(body string) {
+		exc.
+			On("Request", http.MethodGet, "cluster/ha/resources", url.Values(nil)).
+			Return([]byte(body), nil).
+			Once()
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		resources(`{"data":[
+			{"sid":"ct:100","state":"stopped"},
+			{"sid":"vm:100","state":"started","group":"prod","max_restart":3,"max_relocate":0}
+		]}`)
+
+		props, err := virtualMachine.GetHAProperties()
+		require.NoError(t, err)
+		assert.Equal(t, &types.HAProperties{
+			State:       types.HAStateStarted,
+			Group:       "prod",
+			MaxRestart:  3,
+			MaxRelocate: 0,
+		}, props)
+
+		resources(`{"data":[{"sid":"vm:101","state":"started"}]}`)
+
+		props, err = virtualMachine.GetHAProperties()
+		require.NoError(t, err)
+		assert.Nil(t, props)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		resources(`{"data":[]}`)
+
+		exc.
+			On("Request", http.MethodPost, "cluster/ha/resources", url.Values{
+				"sid":          {"vm:100"},
+				"state":        {"started"},
+				"max_restart":  {"1"},
+				"max_relocate": {"1"},
+			}).
+			Return([]byte{}, nil).
+			Once()
+
+		props := types.NewHAProperties()
+		require.NoError(t, virtualMachine.SetHAProperties(&props))
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		resources(`{"data":[{"sid":"vm:100","state":"started","group":"prod"}]}`)
+
+		exc.
+			On("Request", http.MethodPut, "cluster/ha/resources/vm:100", url.Values{
+				"state":        {"stopped"},
+				"max_restart":  {"1"},
+				"max_relocate": {"1"},
+				"delete":       {"comment,group"},
+			}).
+			Return([]byte{}, nil).
+			Once()
+
+		props := types.NewHAProperties()
+		props.State = types.HAStateStopped
+		require.NoError(t, virtualMachine.SetHAProperties(&props))
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		resources(`{"data":[{"sid":"vm:100","state":"started"}]}`)
+
+		exc.
+			On("Request", http.MethodDelete, "cluster/ha/resources/vm:100", url.Values(nil)).
+			Return([]byte{}, nil).
+			Once()
+
+		require.NoError(t, virtualMachine.SetHAProperties(nil))
+
+		resources(`{"data":[]}`)
+		require.NoError(t, virtualMachine.SetHAProperties(nil))
+
+		props := types.HAProperties{State: "unknown"}
+		resources(`{"data":[]}`)
+		assert.Error(t, virtualMachine.SetHAProperties(&props))
+
+		exc.AssertExpectations(t)
+	})
+}
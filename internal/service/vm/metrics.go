@@ -0,0 +1,24 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/types/metrics"
+)
+
+func (obj *VirtualMachine) GetMetrics(
+	opts metrics.Options,
+) (metrics.GuestMetrics, error) {
+	form, err := opts.MapToValues()
+	if err != nil {
+		return metrics.GuestMetrics{}, err
+	}
+
+	var res []metrics.Sample
+	if err := obj.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/%s/%d/rrddata", obj.node, obj.kind.String(), obj.vmid), form, &res); err != nil {
+		return metrics.GuestMetrics{}, err
+	}
+
+	return metrics.NewGuestMetrics(res), nil
+}
@@ -0,0 +1,66 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	"github.com/xabinapal/gopve/pkg/types/metrics"
+)
+
+func TestVirtualMachineMetrics(t *testing.T) {
+	virtualMachine, _, exc := test.NewVirtualMachine()
+
+	t.Run("Get", func(t *testing.T) {
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_{kind}_{vmid}_rrddata.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/test_kind/100/rrddata", url.Values{
+				"timeframe": {"hour"},
+				"cf":        {"AVERAGE"},
+			}).
+			Return(response, nil).
+			Once()
+
+		guestMetrics, err := virtualMachine.GetMetrics(metrics.Options{
+			Timeframe:             metrics.TimeframeHour,
+			ConsolidationFunction: metrics.ConsolidationFunctionAverage,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, metrics.Series{
+			Unit: metrics.UnitRatio,
+			Points: []metrics.Point{
+				{Time: time.Unix(1609459260, 0).UTC(), Value: 0.25},
+				{Time: time.Unix(1609459320, 0).UTC(), Value: 0.75},
+			},
+		}, guestMetrics.CPU)
+
+		assert.Equal(t, metrics.UnitBytes, guestMetrics.Memory.Unit)
+		assert.Equal(t, float64(805306368), guestMetrics.Memory.Max())
+
+		assert.Equal(t, metrics.UnitBytesPerSecond, guestMetrics.NetIn.Unit)
+		assert.Equal(t, float64(2048), guestMetrics.NetIn.Average())
+
+		last, ok := guestMetrics.DiskWrite.Last()
+		require.True(t, ok)
+		assert.Equal(t, float64(16384), last.Value)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("InvalidTimeframe", func(t *testing.T) {
+		_, err := virtualMachine.GetMetrics(metrics.Options{})
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+}
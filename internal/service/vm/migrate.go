@@ -0,0 +1,190 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type getMigrationPreconditionsResponseJSON struct {
+	Running internal_types.PVEBool `json:"running"`
+
+	AllowedNodes    []string `json:"allowed_nodes"`
+	NotAllowedNodes map[string]struct {
+		UnavailableStorages []string `json:"unavailable_storages"`
+	} `json:"not_allowed_nodes"`
+
+	LocalDisks []struct {
+		VolumeID  string                 `json:"volid"`
+		DriveName string                 `json:"drivename"`
+		Size      uint64                 `json:"size"`
+		CDROM     internal_types.PVEBool `json:"cdrom"`
+		Unused    internal_types.PVEBool `json:"is_unused"`
+	} `json:"local_disks"`
+	LocalResources []string `json:"local_resources"`
+}
+
+func (res getMigrationPreconditionsResponseJSON) Map() (vm.MigrationPreconditions, error) {
+	obj := vm.MigrationPreconditions{
+		Running: res.Running.Bool(),
+
+		AllowedNodes:    res.AllowedNodes,
+		NotAllowedNodes: make(map[string][]string, len(res.NotAllowedNodes)),
+
+		LocalResources: res.LocalResources,
+	}
+
+	for node, reasons := range res.NotAllowedNodes {
+		obj.NotAllowedNodes[node] = reasons.UnavailableStorages
+	}
+
+	for _, disk := range res.LocalDisks {
+		obj.LocalDisks = append(obj.LocalDisks, vm.MigrationLocalDisk{
+			VolumeID:  disk.VolumeID,
+			DriveName: disk.DriveName,
+			Size:      disk.Size,
+
+			CDROM:  disk.CDROM.Bool(),
+			Unused: disk.Unused.Bool(),
+		})
+	}
+
+	return obj, nil
+}
+
+func (obj *VirtualMachine) GetMigrationPreconditions(
+	target string,
+) (vm.MigrationPreconditions, error) {
+	if obj.kind != vm.KindQEMU {
+		return vm.MigrationPreconditions{}, fmt.Errorf(
+			"migration preconditions are only available for qemu virtual machines",
+		)
+	}
+
+	form := request.Values{}
+	form.ConditionalAddString("target", target, target != "")
+
+	var res getMigrationPreconditionsResponseJSON
+	if err := obj.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/qemu/%d/migrate", obj.node, obj.vmid), form, &res); err != nil {
+		return vm.MigrationPreconditions{}, err
+	}
+
+	return res.Map()
+}
+
+func (obj *VirtualMachine) Migrate(
+	target string,
+	options vm.MigrateOptions,
+) (task.Task, error) {
+	if target == obj.node {
+		return nil, vm.ErrMigrateSameNode
+	}
+
+	values := request.Values{
+		"target": {target},
+	}
+
+	values.ConditionalAddUint(
+		"bwlimit",
+		options.BandwidthLimit,
+		options.BandwidthLimit != 0,
+	)
+
+	targetStorage := getMigrationTargetStorage(options)
+
+	switch obj.kind {
+	case vm.KindQEMU:
+		if options.Restart || options.RestartTimeout != 0 {
+			return nil, fmt.Errorf(
+				"restart mode can only be used in lxc migrations",
+			)
+		}
+
+		values.ConditionalAddBool("online", true, options.Online)
+		values.ConditionalAddBool(
+			"with-local-disks",
+			true,
+			options.WithLocalDisks,
+		)
+		values.ConditionalAddString(
+			"targetstorage",
+			targetStorage,
+			targetStorage != "",
+		)
+		values.ConditionalAddString(
+			"migration_network",
+			options.MigrationNetwork,
+			options.MigrationNetwork != "",
+		)
+
+	case vm.KindLXC:
+		if options.Online {
+			return nil, fmt.Errorf(
+				"online mode can't be used in lxc migrations, use restart mode instead",
+			)
+		} else if options.WithLocalDisks {
+			return nil, fmt.Errorf(
+				"local disks can only be migrated in qemu migrations",
+			)
+		} else if options.MigrationNetwork != "" {
+			return nil, fmt.Errorf(
+				"migration network can only be specified in qemu migrations",
+			)
+		} else if options.RestartTimeout != 0 && !options.Restart {
+			return nil, fmt.Errorf(
+				"restart timeout can only be specified in restart mode",
+			)
+		}
+
+		values.ConditionalAddBool("restart", true, options.Restart)
+		values.ConditionalAddUint(
+			"timeout",
+			uint(options.RestartTimeout.Seconds()),
+			options.RestartTimeout != 0,
+		)
+		values.ConditionalAddString(
+			"target-storage",
+			targetStorage,
+			targetStorage != "",
+		)
+
+	default:
+		return nil, vm.ErrInvalidKind
+	}
+
+	var task string
+	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/%s/%d/migrate", obj.node, obj.kind.String(), obj.vmid), values, &task); err != nil {
+		return nil, err
+	}
+
+	return obj.svc.api.Task().Get(task)
+}
+
+func getMigrationTargetStorage(options vm.MigrateOptions) string {
+	storages := internal_types.PVEList{Separator: ","}
+
+	sources := make([]string, 0, len(options.StorageMapping))
+	for source := range options.StorageMapping {
+		sources = append(sources, source)
+	}
+
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		storages.Append(
+			fmt.Sprintf("%s:%s", source, options.StorageMapping[source]),
+		)
+	}
+
+	if options.TargetStorage != "" {
+		storages.Append(options.TargetStorage)
+	}
+
+	s, _ := storages.Marshal()
+	return s
+}
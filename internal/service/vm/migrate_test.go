@@ -0,0 +1,181 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestVirtualMachineMigrationPreconditions(t *testing.T) {
+	virtualMachine, _, exc := test.NewQEMU()
+
+	response, err := ioutil.ReadFile(
+		"./testdata/get_nodes_{node}_qemu_{vmid}_migrate.json",
+	)
+	require.NoError(t, err)
+
+	exc.
+		On("Request", http.MethodGet, "nodes/test_node/qemu/100/migrate", url.Values{
+			"target": {"second_node"},
+		}).
+		Return(response, nil).
+		Once()
+
+	expectedPreconditions := types.MigrationPreconditions{
+		Running: true,
+
+		AllowedNodes: []string{"second_node"},
+		NotAllowedNodes: map[string][]string{
+			"third_node": {"local-lvm"},
+		},
+
+		LocalDisks: []types.MigrationLocalDisk{
+			{
+				VolumeID:  "local-lvm:vm-100-disk-0",
+				DriveName: "scsi0",
+				Size:      34359738368,
+			},
+			{
+				VolumeID:  "local:iso/debian.iso",
+				DriveName: "ide2",
+				Size:      394264576,
+				CDROM:     true,
+			},
+		},
+		LocalResources: []string{"hostpci0"},
+	}
+
+	preconditions, err := virtualMachine.GetMigrationPreconditions(
+		"second_node",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expectedPreconditions, preconditions)
+
+	assert.True(t, preconditions.IsAllowedNode("second_node"))
+	assert.False(t, preconditions.IsAllowedNode("third_node"))
+	assert.Len(t, preconditions.BlockingDisks(true), 1)
+	assert.Len(t, preconditions.BlockingDisks(false), 2)
+	assert.True(t, preconditions.IsBlocked(true))
+
+	exc.AssertExpectations(t)
+}
+
+func TestVirtualMachineMigrate(t *testing.T) {
+	expectedTask, _, _ := task.NewTask(
+		"test_node",
+		"::",
+		"qmigrate",
+		"100",
+		"root@pam",
+		"",
+	)
+
+	t.Run("QEMU", func(t *testing.T) {
+		virtualMachine, api, exc := test.NewQEMU()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/migrate", url.Values{
+				"target":            {"second_node"},
+				"online":            {"1"},
+				"with-local-disks":  {"1"},
+				"targetstorage":     {"local-lvm:ceph,local-zfs"},
+				"bwlimit":           {"102400"},
+				"migration_network": {"10.0.0.0/24"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::qmigrate:100:root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::qmigrate:100:root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := virtualMachine.Migrate("second_node", types.MigrateOptions{
+			Online:         true,
+			WithLocalDisks: true,
+
+			TargetStorage: "local-zfs",
+			StorageMapping: map[string]string{
+				"local-lvm": "ceph",
+			},
+
+			BandwidthLimit:   102400,
+			MigrationNetwork: "10.0.0.0/24",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("LXC", func(t *testing.T) {
+		virtualMachine, api, exc := test.NewLXC()
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/lxc/100/migrate", url.Values{
+				"target":         {"second_node"},
+				"restart":        {"1"},
+				"timeout":        {"120"},
+				"target-storage": {"local-zfs"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::qmigrate:100:root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::qmigrate:100:root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := virtualMachine.Migrate("second_node", types.MigrateOptions{
+			TargetStorage: "local-zfs",
+
+			Restart:        true,
+			RestartTimeout: time.Duration(2) * time.Minute,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("SameNode", func(t *testing.T) {
+		virtualMachine, _, _ := test.NewQEMU()
+
+		_, err := virtualMachine.Migrate("test_node", types.MigrateOptions{})
+		assert.EqualError(t, err, types.ErrMigrateSameNode.Error())
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		qemuVirtualMachine, _, _ := test.NewQEMU()
+
+		_, err := qemuVirtualMachine.Migrate(
+			"second_node",
+			types.MigrateOptions{Restart: true},
+		)
+		assert.Error(t, err)
+
+		lxcVirtualMachine, _, _ := test.NewLXC()
+
+		_, err = lxcVirtualMachine.Migrate(
+			"second_node",
+			types.MigrateOptions{Online: true},
+		)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,74 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+type getPendingChangesResponseJSON []struct {
+	Key     string      `json:"key"`
+	Value   interface{} `json:"value"`
+	Pending interface{} `json:"pending"`
+	Delete  uint        `json:"delete"`
+}
+
+func (res getPendingChangesResponseJSON) Map() (vm.PendingChanges, error) {
+	changes := make(vm.PendingChanges, len(res))
+
+	for i, change := range res {
+		changes[i] = vm.PendingChange{
+			Key: change.Key,
+
+			Value:        change.Value,
+			PendingValue: change.Pending,
+
+			HasValue:        change.Value != nil,
+			HasPendingValue: change.Pending != nil,
+
+			Delete:      change.Delete != 0,
+			ForceDelete: change.Delete == 2,
+		}
+	}
+
+	return changes, nil
+}
+
+func (obj *VirtualMachine) GetPendingChanges() (vm.PendingChanges, error) {
+	var res getPendingChangesResponseJSON
+	if err := obj.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/%s/%d/pending", obj.node, obj.kind.String(), obj.vmid), nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Map()
+}
+
+func (obj *VirtualMachine) RebootRequired() (bool, error) {
+	changes, err := obj.GetPendingChanges()
+	if err != nil {
+		return false, err
+	}
+
+	return changes.RebootRequired(), nil
+}
+
+func (obj *QEMUVirtualMachine) GetPendingQEMUProperties() (qemu.PendingProperties, error) {
+	changes, err := obj.GetPendingChanges()
+	if err != nil {
+		return qemu.PendingProperties{}, err
+	}
+
+	return qemu.NewPendingProperties(changes)
+}
+
+func (obj *LXCVirtualMachine) GetPendingLXCProperties() (lxc.PendingProperties, error) {
+	changes, err := obj.GetPendingChanges()
+	if err != nil {
+		return lxc.PendingProperties{}, err
+	}
+
+	return lxc.NewPendingProperties(changes)
+}
@@ -0,0 +1,135 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestVirtualMachinePendingChanges(t *testing.T) {
+	response, err := ioutil.ReadFile(
+		"./testdata/get_nodes_{node}_{kind}_{vmid}_pending.json",
+	)
+	require.NoError(t, err)
+
+	t.Run("GetPendingChanges", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewVirtualMachine()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/test_kind/100/pending", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		changes, err := virtualMachine.GetPendingChanges()
+		require.NoError(t, err)
+		require.Len(t, changes, 9)
+
+		assert.Equal(t, types.PendingChange{
+			Key:             "memory",
+			Value:           float64(2048),
+			PendingValue:    float64(4096),
+			HasValue:        true,
+			HasPendingValue: true,
+		}, changes[4])
+
+		assert.Equal(t, types.PendingChange{
+			Key:      "net1",
+			Value:    "virtio=AA:BB:CC:DD:EE:00,bridge=vmbr1",
+			HasValue: true,
+			Delete:   true,
+		}, changes[7])
+
+		assert.Equal(
+			t,
+			[]string{"cores", "description", "memory", "net1"},
+			changes.Keys(),
+		)
+		assert.True(t, changes.RebootRequired())
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("RebootRequired", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewVirtualMachine()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/test_kind/100/pending", url.Values(nil)).
+			Return([]byte(`{"data": [{"key": "memory", "value": 2048}]}`), nil).
+			Once()
+
+		rebootRequired, err := virtualMachine.RebootRequired()
+		require.NoError(t, err)
+		assert.False(t, rebootRequired)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("GetPendingQEMUProperties", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewQEMU()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/pending", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		props, err := virtualMachine.GetPendingQEMUProperties()
+		require.NoError(t, err)
+
+		assert.True(t, props.RebootRequired())
+
+		assert.Equal(t, "", props.Current.Description)
+		assert.Equal(t, "test_description", props.Pending.Description)
+
+		assert.Equal(t, uint(2), props.Current.CPU.Cores)
+		assert.Equal(t, uint(4), props.Pending.CPU.Cores)
+
+		assert.Equal(t, uint(2048), props.Current.Memory.Memory)
+		assert.Equal(t, uint(4096), props.Pending.Memory.Memory)
+
+		assert.Len(t, props.Current.Network, 2)
+		assert.Len(t, props.Pending.Network, 1)
+
+		exc.AssertExpectations(t)
+	})
+	t.Run("GetPendingLXCProperties", func(t *testing.T) {
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_lxc_{vmid}_pending.json",
+		)
+		require.NoError(t, err)
+
+		virtualMachine, _, exc := test.NewLXC()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/lxc/100/pending", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		props, err := virtualMachine.GetPendingLXCProperties()
+		require.NoError(t, err)
+
+		assert.True(t, props.RebootRequired())
+
+		assert.Equal(t, "test_name", props.Current.Hostname)
+		assert.Equal(t, "test_name", props.Pending.Hostname)
+
+		assert.Equal(t, uint(1), props.Current.CPU.Cores)
+		assert.Equal(t, uint(2), props.Pending.CPU.Cores)
+
+		assert.Equal(t, uint(512), props.Current.Memory.Memory)
+		assert.Equal(t, uint(1024), props.Pending.Memory.Memory)
+
+		assert.Len(t, props.Current.MountPoints, 1)
+		assert.Len(t, props.Pending.MountPoints, 0)
+
+		assert.Len(t, props.Current.Network, 1)
+		assert.Len(t, props.Pending.Network, 1)
+
+		exc.AssertExpectations(t)
+	})
+}
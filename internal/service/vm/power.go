@@ -3,18 +3,24 @@ package vm
 import (
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/xabinapal/gopve/pkg/request"
 	"github.com/xabinapal/gopve/pkg/types/task"
 	"github.com/xabinapal/gopve/pkg/types/vm"
 )
 
-func postStatus(obj *VirtualMachine, command string) (task.Task, error) {
+func postStatus(
+	obj *VirtualMachine,
+	command string,
+	form request.Values,
+) (task.Task, error) {
 	if obj.template {
 		return nil, fmt.Errorf("unsupported action on template virtual machine")
 	}
 
 	var task string
-	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/%s/%d/status/%s", obj.node, string(obj.kind), obj.vmid, command), nil, &task); err != nil {
+	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/%s/%d/status/%s", obj.node, string(obj.kind), obj.vmid, command), form, &task); err != nil {
 		return nil, err
 	}
 
@@ -22,11 +28,11 @@ func postStatus(obj *VirtualMachine, command string) (task.Task, error) {
 }
 
 func (obj *VirtualMachine) Start() (task.Task, error) {
-	return postStatus(obj, "start")
+	return postStatus(obj, "start", nil)
 }
 
 func (obj *VirtualMachine) Stop() (task.Task, error) {
-	return postStatus(obj, "stop")
+	return postStatus(obj, "stop", nil)
 }
 
 func (obj *VirtualMachine) Reset() (task.Task, error) {
@@ -34,25 +40,38 @@ func (obj *VirtualMachine) Reset() (task.Task, error) {
 	case vm.KindLXC:
 		obj.svc.client.StartAtomicBlock()
 		defer obj.svc.client.EndAtomicBlock()
-		postStatus(obj, "stop")
-		return postStatus(obj, "start")
+		postStatus(obj, "stop", nil)
+		return postStatus(obj, "start", nil)
 	default:
-		return postStatus(obj, "reset")
+		return postStatus(obj, "reset", nil)
 	}
 }
 
 func (obj *VirtualMachine) Shutdown() (task.Task, error) {
-	return postStatus(obj, "shutdown")
+	return postStatus(obj, "shutdown", nil)
+}
+
+func (obj *VirtualMachine) ShutdownWithOptions(
+	opts vm.ShutdownOptions,
+) (task.Task, error) {
+	form := make(request.Values)
+
+	timeout := uint((opts.Timeout + time.Second - 1) / time.Second)
+
+	form.ConditionalAddUint("timeout", timeout, timeout != 0)
+	form.ConditionalAddBool("forceStop", true, opts.ForceStop)
+
+	return postStatus(obj, "shutdown", form)
 }
 
 func (obj *VirtualMachine) Reboot() (task.Task, error) {
-	return postStatus(obj, "reboot")
+	return postStatus(obj, "reboot", nil)
 }
 
 func (obj *VirtualMachine) Suspend() (task.Task, error) {
-	return postStatus(obj, "suspend")
+	return postStatus(obj, "suspend", nil)
 }
 
 func (obj *VirtualMachine) Resume() (task.Task, error) {
-	return postStatus(obj, "resume")
+	return postStatus(obj, "resume", nil)
 }
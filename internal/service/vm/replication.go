@@ -0,0 +1,28 @@
+package vm
+
+import (
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func (obj *VirtualMachine) ListReplicationJobs() ([]vm.ReplicationJob, error) {
+	jobs, err := obj.svc.api.Cluster().ListReplicationJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	var guestJobs []vm.ReplicationJob
+
+	for _, job := range jobs {
+		if job.VMID() == obj.vmid {
+			guestJobs = append(guestJobs, job)
+		}
+	}
+
+	return guestJobs, nil
+}
+
+func (obj *VirtualMachine) CreateReplicationJob(
+	props vm.ReplicationJobProperties,
+) (vm.ReplicationJob, error) {
+	return obj.svc.api.Cluster().CreateReplicationJob(obj.vmid, props)
+}
@@ -0,0 +1,59 @@
+package vm_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cluster "github.com/xabinapal/gopve/internal/service/cluster/test"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestVirtualMachineReplicationJobs(t *testing.T) {
+	virtualMachine, api, _ := test.NewVirtualMachine()
+
+	clusterService, _, exc := cluster.NewService()
+
+	exc.
+		On("Request", http.MethodGet, "cluster/replication", url.Values(nil)).
+		Return([]byte(`{"data":[
+			{"id":"100-0","type":"local","guest":100,"jobnum":0,"target":"test_node_2"},
+			{"id":"101-0","type":"local","guest":101,"jobnum":0,"target":"test_node_2"}
+		]}`), nil).
+		Once()
+
+	jobs, err := clusterService.ListReplicationJobs()
+	require.NoError(t, err)
+
+	t.Run("List", func(t *testing.T) {
+		api.ClusterService.
+			On("ListReplicationJobs").
+			Return(jobs, nil).
+			Once()
+
+		guestJobs, err := virtualMachine.ListReplicationJobs()
+		require.NoError(t, err)
+		require.Len(t, guestJobs, 1)
+		assert.Equal(t, "100-0", guestJobs[0].ID())
+
+		api.ClusterService.AssertExpectations(t)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		props := types.ReplicationJobProperties{Target: "test_node_2"}
+
+		api.ClusterService.
+			On("CreateReplicationJob", uint(100), props).
+			Return(jobs[0], nil).
+			Once()
+
+		job, err := virtualMachine.CreateReplicationJob(props)
+		require.NoError(t, err)
+		assert.Equal(t, "100-0", job.ID())
+
+		api.ClusterService.AssertExpectations(t)
+	})
+}
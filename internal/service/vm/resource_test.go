@@ -0,0 +1,96 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestServiceResources(t *testing.T) {
+	svc, _, exc := test.NewService()
+
+	response, err := ioutil.ReadFile("./testdata/service_list_vms.json")
+	require.NoError(t, err)
+
+	exc.
+		On("Request", http.MethodGet, "cluster/resources", url.Values{
+			"type": {"vm"},
+		}).
+		Return(response, nil)
+
+	t.Run("List", func(t *testing.T) {
+		resources, err := svc.ListResources()
+		require.NoError(t, err)
+		require.Len(t, resources, 36)
+
+		assert.Equal(t, uint(100), resources[0].VMID)
+		assert.True(t, resources[0].Template)
+
+		var resource types.Resource
+		for _, r := range resources {
+			if r.VMID == 129 {
+				resource = r
+			}
+		}
+
+		assert.Equal(t, types.Resource{
+			VMID:        129,
+			Kind:        types.KindQEMU,
+			Node:        "janus",
+			Name:        "prueba",
+			Status:      types.StatusRunning,
+			Uptime:      143 * time.Second,
+			CPU:         0.265948617458948,
+			CPUs:        1,
+			Memory:      33102461,
+			MemoryTotal: 2147483648,
+			DiskTotal:   34359738368,
+			Pool:        "test",
+		}, resource)
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		resources, err := svc.QueryResources(
+			types.NewResourceQuery().
+				InPool("test").
+				SortBy(types.ResourceSortKeyDiskTotal, true).
+				SortBy(types.ResourceSortKeyVMID, false),
+		)
+		require.NoError(t, err)
+		require.Len(t, resources, 3)
+
+		assert.Equal(t, uint(129), resources[0].VMID)
+		assert.Equal(t, uint(127), resources[1].VMID)
+		assert.Equal(t, uint(128), resources[2].VMID)
+	})
+
+	t.Run("Hydrate", func(t *testing.T) {
+		resources, err := svc.QueryResources(
+			types.NewResourceQuery().
+				WithStatus(types.StatusRunning).
+				OnNode("janus").
+				SortBy(types.ResourceSortKeyCPU, true).
+				Limit(2),
+		)
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+
+		vms, err := svc.Hydrate(resources...)
+		require.NoError(t, err)
+		require.Len(t, vms, 2)
+
+		assert.Equal(t, uint(1000), vms[0].VMID())
+		assert.Equal(t, uint(129), vms[1].VMID())
+		assert.Equal(t, types.KindQEMU, vms[0].Kind())
+		assert.Equal(t, "janus", vms[0].Node())
+	})
+
+	exc.AssertExpectations(t)
+}
@@ -23,7 +23,7 @@ type getSnapshotResponseJSON struct {
 func (obj getSnapshotResponseJSON) Map(
 	virtualMachine *VirtualMachine,
 ) (vm.Snapshot, error) {
-	if obj.Name == "current" {
+	if obj.Name == vm.CurrentSnapshotName {
 		return NewCurrentSnapshot(virtualMachine, obj.Parent), nil
 	}
 
@@ -77,10 +77,23 @@ func (obj *VirtualMachine) GetSnapshot(name string) (vm.Snapshot, error) {
 	return nil, vm.ErrNoSnapshot
 }
 
+func (obj *VirtualMachine) GetSnapshotTree() (*vm.SnapshotTree, error) {
+	snapshots, err := obj.ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	return vm.NewSnapshotTree(snapshots)
+}
+
 func (obj *VirtualMachine) CreateSnapshot(
 	name string,
 	props vm.SnapshotProperties,
 ) (task.Task, error) {
+	if props.WithRAM && obj.kind != vm.KindQEMU {
+		return nil, fmt.Errorf("only qemu snapshots can include ram")
+	}
+
 	form, err := props.MapToValues()
 	if err != nil {
 		return nil, err
@@ -104,3 +117,30 @@ func (obj *VirtualMachine) RollbackToSnapshot(name string) (task.Task, error) {
 
 	return obj.svc.api.Task().Get(task)
 }
+
+func (obj *VirtualMachine) PruneSnapshots(
+	retention vm.SnapshotRetention,
+) ([]task.Task, error) {
+	snapshots, err := obj.ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := vm.SelectSnapshotsToPrune(snapshots, retention, time.Now())
+	tasks := make([]task.Task, 0, len(selected))
+
+	for _, snapshot := range selected {
+		t, err := snapshot.Delete(false)
+		if err != nil {
+			return tasks, err
+		}
+
+		tasks = append(tasks, t)
+
+		if err := t.Wait(); err != nil {
+			return tasks, err
+		}
+	}
+
+	return tasks, nil
+}
@@ -1,6 +1,7 @@
 package vm_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -12,6 +13,7 @@ import (
 	task "github.com/xabinapal/gopve/internal/service/task/test"
 	"github.com/xabinapal/gopve/internal/service/vm"
 	"github.com/xabinapal/gopve/internal/service/vm/test"
+	task_types "github.com/xabinapal/gopve/pkg/types/task"
 	types "github.com/xabinapal/gopve/pkg/types/vm"
 )
 
@@ -158,4 +160,155 @@ func TestVirtualMachineSnapshot(t *testing.T) {
 
 		exc.AssertExpectations(t)
 	})
+
+	t.Run("Tree", func(t *testing.T) {
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_{kind}_{vmid}_snapshot__tree.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/test_kind/100/snapshot", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		tree, err := virtualMachine.GetSnapshotTree()
+		require.NoError(t, err)
+
+		require.Len(t, tree.Roots, 1)
+		root := tree.Roots[0]
+		assert.Equal(t, "first", root.Snapshot.Name())
+
+		require.Len(t, root.Children, 2)
+		assert.Equal(t, "second", root.Children[0].Snapshot.Name())
+		assert.Equal(t, "branch", root.Children[1].Snapshot.Name())
+
+		require.NotNil(t, tree.Current)
+		assert.Equal(t, uint(3), tree.Current.Depth)
+
+		var path []string
+		for _, node := range tree.PathToCurrent() {
+			path = append(path, node.Snapshot.Name())
+		}
+
+		assert.Equal(t, []string{"first", "second", "third", "current"}, path)
+
+		branch, ok := tree.Find("branch")
+		require.True(t, ok)
+		assert.Equal(t, uint(1), branch.Depth)
+		assert.Equal(t, root, branch.Parent)
+
+		_, ok = tree.Find("missing")
+		assert.False(t, ok)
+
+		assert.Len(t, tree.Snapshots(), 4)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("CreateWithRAM", func(t *testing.T) {
+		_, err := virtualMachine.CreateSnapshot(
+			"first",
+			types.SnapshotProperties{WithRAM: true},
+		)
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_{kind}_{vmid}_snapshot__tree.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/test_kind/100/snapshot", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		var expectedTasks []task_types.Task
+
+		for _, name := range []string{"first", "second"} {
+			upid := fmt.Sprintf("UPID:test_node::::qmdelsnapshot:%s:root@pam:", name)
+
+			exc.
+				On("Request", http.MethodDelete, fmt.Sprintf("nodes/test_node/test_kind/100/snapshot/%s", name), url.Values(nil)).
+				Return([]byte(fmt.Sprintf("{\"data\":\"%s\"}", upid)), nil).
+				Once()
+
+			expectedTask, _, taskExc := task.NewTask(
+				"test_node",
+				"::",
+				"qmdelsnapshot",
+				name,
+				"root@pam",
+				"",
+			)
+
+			taskExc.
+				On("Request", http.MethodGet, fmt.Sprintf("nodes/test_node/tasks/%s/status", upid), url.Values(nil)).
+				Return([]byte("{\"data\":{\"status\":\"stopped\"}}"), nil).
+				Once()
+
+			api.TaskService.
+				On("Get", upid).
+				Return(expectedTask, nil).
+				Once()
+
+			expectedTasks = append(expectedTasks, expectedTask)
+		}
+
+		tasks, err := virtualMachine.PruneSnapshots(types.SnapshotRetention{
+			KeepLast:  2,
+			OlderThan: 24 * time.Hour,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTasks, tasks)
+
+		exc.AssertExpectations(t)
+	})
+}
+
+func TestVirtualMachineSnapshotWithRAM(t *testing.T) {
+	virtualMachine, api, exc := test.NewQEMU()
+
+	exc.
+		On("Request", http.MethodPost, "nodes/test_node/qemu/100/snapshot", url.Values{
+			"snapname":    {"first"},
+			"description": {"First snapshot"},
+			"vmstate":     {"1"},
+		}).
+		Return(
+			[]byte(
+				"{\"data\":\"UPID:test_node::::qmsnapshot:100:root@pam:\"}",
+			),
+			nil,
+		).
+		Once()
+
+	expectedTask, _, _ := task.NewTask(
+		"test_node",
+		"::",
+		"qmsnapshot",
+		"100",
+		"root@pam",
+		"",
+	)
+
+	api.TaskService.
+		On("Get", "UPID:test_node::::qmsnapshot:100:root@pam:").
+		Return(expectedTask, nil)
+
+	task, err := virtualMachine.CreateSnapshot(
+		"first",
+		types.SnapshotProperties{
+			Description: "First snapshot",
+			WithRAM:     true,
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expectedTask, task)
+
+	exc.AssertExpectations(t)
 }
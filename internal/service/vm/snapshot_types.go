@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/task"
 	"github.com/xabinapal/gopve/pkg/types/vm"
 )
 
@@ -57,7 +58,7 @@ func (obj *Snapshot) Name() string {
 }
 
 func (obj *Snapshot) Description() string {
-	if obj.name == "current" {
+	if obj.IsCurrent() {
 		return "You are here!"
 	}
 
@@ -65,7 +66,7 @@ func (obj *Snapshot) Description() string {
 }
 
 func (obj *Snapshot) Timestamp() time.Time {
-	if obj.name == "current" {
+	if obj.IsCurrent() {
 		return time.Now()
 	}
 
@@ -73,13 +74,17 @@ func (obj *Snapshot) Timestamp() time.Time {
 }
 
 func (obj *Snapshot) WithRAM() bool {
-	if obj.name == "current" {
+	if obj.IsCurrent() {
 		return true
 	}
 
 	return obj.withRAM
 }
 
+func (obj *Snapshot) IsCurrent() bool {
+	return obj.name == vm.CurrentSnapshotName
+}
+
 func (obj *Snapshot) Parent() string {
 	return obj.parent
 }
@@ -93,7 +98,7 @@ func (obj *Snapshot) GetParent() (vm.Snapshot, error) {
 }
 
 func (obj *Snapshot) GetProperties() (vm.SnapshotProperties, error) {
-	if obj.name == "current" {
+	if obj.IsCurrent() {
 		return vm.SnapshotProperties{
 			Description: "You are here!",
 		}, nil
@@ -101,11 +106,12 @@ func (obj *Snapshot) GetProperties() (vm.SnapshotProperties, error) {
 
 	return vm.SnapshotProperties{
 		Description: obj.description,
+		WithRAM:     obj.withRAM,
 	}, nil
 }
 
 func (obj *Snapshot) SetProperties(props vm.SnapshotProperties) error {
-	if obj.name == "current" {
+	if obj.IsCurrent() {
 		return vm.ErrUpdateCurrentSnapshot
 	}
 
@@ -120,21 +126,21 @@ func (obj *Snapshot) SetProperties(props vm.SnapshotProperties) error {
 	return nil
 }
 
-func (obj *Snapshot) Delete() error {
-	if obj.name == "current" {
-		return vm.ErrDeleteCurrentSnapshot
+func (obj *Snapshot) Delete(force bool) (task.Task, error) {
+	if obj.IsCurrent() {
+		return nil, vm.ErrDeleteCurrentSnapshot
+	}
+
+	var form request.Values
+	if force {
+		form = request.Values{}
+		form.AddBool("force", true)
+	}
+
+	var task string
+	if err := obj.vm.svc.client.Request(http.MethodDelete, fmt.Sprintf("nodes/%s/%s/%d/snapshot/%s", obj.vm.node, obj.vm.kind.String(), obj.vm.vmid, obj.name), form, &task); err != nil {
+		return nil, err
 	}
 
-	return obj.vm.svc.client.Request(
-		http.MethodDelete,
-		fmt.Sprintf(
-			"nodes/%s/%s/%d/snapshot/%s",
-			obj.vm.node,
-			obj.vm.kind.String(),
-			obj.vm.vmid,
-			obj.name,
-		),
-		nil,
-		nil,
-	)
+	return obj.vm.svc.api.Task().Get(task)
 }
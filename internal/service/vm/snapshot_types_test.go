@@ -9,13 +9,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
 	"github.com/xabinapal/gopve/internal/service/vm"
 	"github.com/xabinapal/gopve/internal/service/vm/test"
 	types "github.com/xabinapal/gopve/pkg/types/vm"
 )
 
 func TestSnapshot(t *testing.T) {
-	virtualMachine, _, exc := test.NewVirtualMachine()
+	virtualMachine, api, exc := test.NewVirtualMachine()
 
 	loc, err := time.LoadLocation("UTC")
 	require.NoError(t, err)
@@ -104,6 +105,7 @@ func TestSnapshot(t *testing.T) {
 
 		expectedProperties := types.SnapshotProperties{
 			Description: "test_description",
+			WithRAM:     true,
 		}
 
 		properties, err := snapshot.GetProperties()
@@ -136,10 +138,50 @@ func TestSnapshot(t *testing.T) {
 
 		exc.
 			On("Request", http.MethodDelete, "nodes/test_node/test_kind/100/snapshot/test_snapshot", url.Values(nil)).
-			Return(nil, nil).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::qmdelsnapshot:100:root@pam:\"}",
+				),
+				nil,
+			).
+			Once()
+
+		expectedTask, _, _ := task.NewTask(
+			"test_node",
+			"::",
+			"qmdelsnapshot",
+			"100",
+			"root@pam",
+			"",
+		)
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::qmdelsnapshot:100:root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := snapshot.Delete(false)
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("DeleteForce", func(t *testing.T) {
+		snapshot := getSnapshot("")
+
+		exc.
+			On("Request", http.MethodDelete, "nodes/test_node/test_kind/100/snapshot/test_snapshot", url.Values{
+				"force": {"1"},
+			}).
+			Return(
+				[]byte(
+					"{\"data\":\"UPID:test_node::::qmdelsnapshot:100:root@pam:\"}",
+				),
+				nil,
+			).
 			Once()
 
-		err := snapshot.Delete()
+		_, err := snapshot.Delete(true)
 		require.NoError(t, err)
 
 		exc.AssertExpectations(t)
@@ -148,7 +190,7 @@ func TestSnapshot(t *testing.T) {
 	t.Run("DeleteCurrent", func(t *testing.T) {
 		snapshot := vm.NewCurrentSnapshot(virtualMachine, "")
 
-		err := snapshot.Delete()
+		_, err := snapshot.Delete(false)
 		require.EqualError(t, err, types.ErrDeleteCurrentSnapshot.Error())
 
 		exc.AssertExpectations(t)
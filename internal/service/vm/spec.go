@@ -0,0 +1,288 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/pkg/types/vm/spec"
+)
+
+func (svc *Service) Plan(s spec.Spec) (spec.Plan, error) {
+	if err := s.Validate(); err != nil {
+		return spec.Plan{}, err
+	}
+
+	resources, err := svc.ListResources()
+	if err != nil {
+		return spec.Plan{}, err
+	}
+
+	var live spec.LiveState
+
+	for _, r := range resources {
+		if r.VMID != s.VMID {
+			continue
+		}
+
+		if r.Kind != s.Kind {
+			return spec.Plan{}, spec.ErrKindMismatch
+		}
+
+		live = spec.LiveState{
+			Exists:  true,
+			Running: r.IsRunning(),
+			Node:    r.Node,
+			Name:    r.Name,
+			Pool:    r.Pool,
+		}
+	}
+
+	if !live.Exists {
+		return spec.NewPlan(s, live)
+	}
+
+	guest, err := svc.Get(s.VMID)
+	if err != nil {
+		return spec.Plan{}, err
+	}
+
+	switch guest := guest.(type) {
+	case qemu.VirtualMachine:
+		props, err := guest.GetQEMUProperties()
+		if err != nil {
+			return spec.Plan{}, err
+		}
+
+		live.QEMU = &props
+	case lxc.VirtualMachine:
+		props, err := guest.GetLXCProperties()
+		if err != nil {
+			return spec.Plan{}, err
+		}
+
+		live.LXC = &props
+	default:
+		return spec.Plan{}, vm.ErrInvalidKind
+	}
+
+	if s.Firewall != nil {
+		props, err := guest.GetFirewallProperties()
+		if err != nil {
+			return spec.Plan{}, err
+		}
+
+		live.Firewall = &props
+	}
+
+	if s.HA != nil {
+		if live.HA, err = guest.GetHAProperties(); err != nil {
+			return spec.Plan{}, err
+		}
+	}
+
+	return spec.NewPlan(s, live)
+}
+
+func (svc *Service) Apply(
+	plan spec.Plan,
+	opts spec.ApplyOptions,
+) ([]task.Task, error) {
+	if plan.IsDestructive() && !opts.AllowDestructive {
+		return nil, spec.ErrDestructivePlan
+	}
+
+	s := plan.Spec
+	path := fmt.Sprintf("nodes/%s/%s/%d", plan.Node, s.Kind, s.VMID)
+
+	var tasks []task.Task
+
+	if !plan.Exists {
+		values := plan.ConfigValues()
+
+		if s.Kind == vm.KindLXC {
+			values.AddString("ostemplate", s.OSTemplate)
+
+			if s.Unprivileged != nil {
+				values.AddBool("unprivileged", *s.Unprivileged)
+			}
+		}
+
+		t, err := svc.createVM(s.Kind.String(), s.VMID, plan.Node, values)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, t)
+
+		if err := t.Wait(); err != nil {
+			return tasks, err
+		}
+	} else if values := plan.ConfigValues(); len(values) != 0 {
+		values.AddString("digest", plan.Digest)
+
+		if err := svc.client.Request(http.MethodPut, path+"/config", values, nil); err != nil {
+			return tasks, err
+		}
+	}
+
+	if moves := plan.Filter(spec.ChangeScopeConfig, spec.ChangeActionMove); len(moves) != 0 {
+		guest, err := svc.Get(s.VMID)
+		if err != nil {
+			return tasks, err
+		}
+
+		for _, change := range moves {
+			t, err := moveDisk(guest, change.Key, vm.MoveDiskOptions{
+				TargetStorage: change.To,
+				DeleteSource:  true,
+			})
+			if err != nil {
+				return tasks, err
+			}
+
+			tasks = append(tasks, t)
+
+			if err := t.Wait(); err != nil {
+				return tasks, err
+			}
+		}
+	}
+
+	if resizes := plan.Filter(spec.ChangeScopeConfig, spec.ChangeActionResize); len(resizes) != 0 {
+		guest, err := svc.Get(s.VMID)
+		if err != nil {
+			return tasks, err
+		}
+
+		for _, change := range resizes {
+			size, err := vm.NewDiskSize(change.To)
+			if err != nil {
+				return tasks, err
+			}
+
+			t, err := resizeDisk(guest, change.Key, size)
+			if err != nil {
+				return tasks, err
+			}
+
+			tasks = append(tasks, t)
+
+			if err := t.Wait(); err != nil {
+				return tasks, err
+			}
+		}
+	}
+
+	for _, change := range plan.Filter(spec.ChangeScopePool) {
+		if change.From != "" {
+			p, err := svc.api.Pool().Get(change.From)
+			if err != nil {
+				return tasks, err
+			}
+
+			if err := p.DeleteVirtualMachine(s.VMID); err != nil {
+				return tasks, err
+			}
+		}
+
+		if change.To != "" {
+			p, err := svc.api.Pool().Get(change.To)
+			if err != nil {
+				return tasks, err
+			}
+
+			if err := p.AddVirtualMachine(s.VMID); err != nil {
+				return tasks, err
+			}
+		}
+	}
+
+	reboot := opts.Reboot && plan.RequiresReboot()
+
+	if !plan.HasScope(spec.ChangeScopeFirewall) &&
+		!plan.HasScope(spec.ChangeScopeHA) && !reboot {
+		return tasks, nil
+	}
+
+	guest, err := svc.Get(s.VMID)
+	if err != nil {
+		return tasks, err
+	}
+
+	if plan.HasScope(spec.ChangeScopeFirewall) {
+		props, err := guest.GetFirewallProperties()
+		if err != nil {
+			return tasks, err
+		}
+
+		if props, err = s.Firewall.Overlay(props); err != nil {
+			return tasks, err
+		}
+
+		if err := guest.SetFirewallProperties(props); err != nil {
+			return tasks, err
+		}
+	}
+
+	if plan.HasScope(spec.ChangeScopeHA) {
+		current, err := guest.GetHAProperties()
+		if err != nil {
+			return tasks, err
+		}
+
+		if err := guest.SetHAProperties(s.HA.Overlay(current)); err != nil {
+			return tasks, err
+		}
+	}
+
+	if reboot {
+		t, err := guest.Reboot()
+		if err != nil {
+			return tasks, err
+		}
+
+		tasks = append(tasks, t)
+
+		if err := t.Wait(); err != nil {
+			return tasks, err
+		}
+	}
+
+	return tasks, nil
+}
+
+func resizeDisk(
+	guest vm.VirtualMachine,
+	disk string,
+	size vm.DiskSize,
+) (task.Task, error) {
+	switch guest := guest.(type) {
+	case qemu.VirtualMachine:
+		return guest.ResizeDisk(disk, size)
+	case lxc.VirtualMachine:
+		return guest.ResizeVolume(disk, size)
+	default:
+		return nil, vm.ErrInvalidKind
+	}
+}
+
+// moveDisk moves a disk or volume keeping its data, and deletes the source
+// once it's copied, so no unused volume is left behind.
+func moveDisk(
+	guest vm.VirtualMachine,
+	disk string,
+	opts vm.MoveDiskOptions,
+) (task.Task, error) {
+	switch guest := guest.(type) {
+	case qemu.VirtualMachine:
+		return guest.MoveDisk(disk, opts)
+	case lxc.VirtualMachine:
+		return guest.MoveVolume(disk, opts)
+	default:
+		return nil, vm.ErrInvalidKind
+	}
+}
@@ -0,0 +1,238 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/spec"
+)
+
+func TestServicePlanAndApply(t *testing.T) {
+	svc, api, exc := test.NewService()
+
+	firewallOptions, err := ioutil.ReadFile(
+		"./testdata/get_nodes_{node}_{kind}_{vmid}_firewall_options.json",
+	)
+	require.NoError(t, err)
+
+	loadGuest := func() {
+		exc.
+			On("Request", http.MethodGet, "cluster/resources", url.Values{
+				"type": {"vm"},
+			}).
+			Return([]byte(`{"data":[
+				{"vmid":100,"type":"qemu","node":"test_node","name":"web","status":"running"}
+			]}`), nil).
+			Once()
+	}
+
+	loadConfig := func() {
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/config", url.Values(nil)).
+			Return([]byte(`{"data":{
+				"name":"web",
+				"ostype":"l26",
+				"sockets":1,
+				"cores":2,
+				"memory":2048,
+				"balloon":0,
+				"scsi0":"local-lvm:vm-100-disk-0,size=32G",
+				"net0":"virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0",
+				"digest":"0000000000000000000000000000000000000000"
+			}}`), nil).
+			Once()
+	}
+
+	loadFirewall := func() {
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/firewall/options", url.Values(nil)).
+			Return(firewallOptions, nil).
+			Once()
+	}
+
+	loadHA := func() {
+		exc.
+			On("Request", http.MethodGet, "cluster/ha/resources", url.Values(nil)).
+			Return([]byte(`{"data":[]}`), nil).
+			Once()
+	}
+
+	s := spec.Spec{
+		VMID:     100,
+		Node:     "test_node",
+		Kind:     types.KindQEMU,
+		Name:     "web",
+		CPU:      &spec.CPUSpec{Cores: 4},
+		Tags:     []string{"prod"},
+		Disks:    []spec.DiskSpec{{Name: "scsi0", Storage: "local-lvm", Size: "40G"}},
+		Firewall: &spec.FirewallSpec{PolicyIn: "REJECT"},
+		HA:       &spec.HASpec{State: types.HAStateStarted},
+	}
+
+	var plan spec.Plan
+
+	t.Run("Plan", func(t *testing.T) {
+		loadGuest()
+		loadGuest()
+		loadConfig()
+		loadFirewall()
+		loadHA()
+
+		plan, err = svc.Plan(s)
+		require.NoError(t, err)
+
+		assert.True(t, plan.Exists)
+		assert.True(t, plan.RequiresReboot())
+		assert.False(t, plan.IsDestructive())
+		assert.Equal(t, []spec.Change{
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionUpdate, Key: "cores", From: "2", To: "4", RequiresReboot: true},
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionCreate, Key: "tags", To: "prod"},
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionResize, Key: "scsi0", From: "32G", To: "40G"},
+			{Scope: spec.ChangeScopeFirewall, Action: spec.ChangeActionUpdate, Key: "policy_in", From: "DROP", To: "REJECT"},
+			{Scope: spec.ChangeScopeHA, Action: spec.ChangeActionCreate, Key: "max_relocate", To: "1"},
+			{Scope: spec.ChangeScopeHA, Action: spec.ChangeActionCreate, Key: "max_restart", To: "1"},
+			{Scope: spec.ChangeScopeHA, Action: spec.ChangeActionCreate, Key: "state", To: "started"},
+		}, plan.Changes)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("KindMismatch", func(t *testing.T) {
+		loadGuest()
+
+		s := s
+		s.Kind = types.KindLXC
+		s.Disks = nil
+		s.OSTemplate = "local:vztmpl/debian.tar.zst"
+
+		_, err := svc.Plan(s)
+		assert.Equal(t, spec.ErrKindMismatch, err)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		resizeUPID := "UPID:test_node:00000001:00000001:00000001:resize:100:root@pam:"
+
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/config", url.Values{
+				"cores":  {"4"},
+				"tags":   {"prod"},
+				"digest": {"0000000000000000000000000000000000000000"},
+			}).
+			Return([]byte{}, nil).
+			Once()
+
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/resize", url.Values{
+				"disk": {"scsi0"},
+				"size": {"40G"},
+			}).
+			Return([]byte(`{"data":"`+resizeUPID+`"}`), nil).
+			Once()
+
+		api.TaskService.
+			On("Get", resizeUPID).
+			Return(finishedTask{}, nil).
+			Once()
+
+		loadGuest()
+		loadConfig()
+		loadGuest()
+		loadConfig()
+		loadFirewall()
+
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/firewall/options", url.Values{
+				"enable":        {"1"},
+				"log_level_in":  {"info"},
+				"log_level_out": {"warning"},
+				"policy_in":     {"REJECT"},
+				"policy_out":    {"ACCEPT"},
+				"ndp":           {"1"},
+				"radv":          {"1"},
+				"dhcp":          {"1"},
+				"macfilter":     {"1"},
+				"ipfilter":      {"1"},
+				"digest":        {"0000000000000000000000000000000000000000"},
+			}).
+			Return([]byte{}, nil).
+			Once()
+
+		loadHA()
+		loadHA()
+
+		exc.
+			On("Request", http.MethodPost, "cluster/ha/resources", url.Values{
+				"sid":          {"vm:100"},
+				"state":        {"started"},
+				"max_restart":  {"1"},
+				"max_relocate": {"1"},
+			}).
+			Return([]byte{}, nil).
+			Once()
+
+		tasks, err := svc.Apply(plan, spec.ApplyOptions{})
+		require.NoError(t, err)
+		assert.Len(t, tasks, 1)
+
+		exc.AssertExpectations(t)
+		api.TaskService.AssertExpectations(t)
+	})
+
+	t.Run("Move", func(t *testing.T) {
+		plan := spec.Plan{
+			Spec:   s,
+			Exists: true,
+			Node:   "test_node",
+			Changes: []spec.Change{
+				{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionMove, Key: "scsi0", From: "local-lvm", To: "ceph"},
+			},
+		}
+
+		loadGuest()
+		loadConfig()
+
+		upid := "UPID:test_node:00000001:00000001:00000001:qmmove:100:root@pam:"
+
+		exc.
+			On("Request", http.MethodPost, "nodes/test_node/qemu/100/move_disk", url.Values{
+				"disk":    {"scsi0"},
+				"storage": {"ceph"},
+				"delete":  {"1"},
+			}).
+			Return([]byte(`{"data":"`+upid+`"}`), nil).
+			Once()
+
+		api.TaskService.
+			On("Get", upid).
+			Return(finishedTask{}, nil).
+			Once()
+
+		tasks, err := svc.Apply(plan, spec.ApplyOptions{})
+		require.NoError(t, err)
+		assert.Len(t, tasks, 1)
+
+		exc.AssertExpectations(t)
+		api.TaskService.AssertExpectations(t)
+	})
+
+	t.Run("Destructive", func(t *testing.T) {
+		plan := spec.Plan{
+			Spec:   s,
+			Exists: true,
+			Changes: []spec.Change{
+				{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionDelete, Key: "scsi1", Destructive: true},
+			},
+		}
+
+		_, err := svc.Apply(plan, spec.ApplyOptions{})
+		assert.Equal(t, spec.ErrDestructivePlan, err)
+	})
+}
@@ -0,0 +1,119 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type getCurrentStatusResponseJSON struct {
+	Status    vm.Status    `json:"status"`
+	QMPStatus vm.QMPStatus `json:"qmpstatus"`
+
+	Uptime uint64      `json:"uptime"`
+	PID    json.Number `json:"pid"`
+	Lock   string      `json:"lock"`
+
+	CPU  float64 `json:"cpu"`
+	CPUs float64 `json:"cpus"`
+
+	Memory      uint64 `json:"mem"`
+	MemoryTotal uint64 `json:"maxmem"`
+	Balloon     uint64 `json:"balloon"`
+	Swap        uint64 `json:"swap"`
+	SwapTotal   uint64 `json:"maxswap"`
+
+	Disk      uint64 `json:"disk"`
+	DiskTotal uint64 `json:"maxdisk"`
+	DiskRead  uint64 `json:"diskread"`
+	DiskWrite uint64 `json:"diskwrite"`
+
+	NetIn  uint64 `json:"netin"`
+	NetOut uint64 `json:"netout"`
+
+	HA struct {
+		Managed types.PVEBool `json:"managed"`
+		State   string        `json:"state"`
+		Group   string        `json:"group"`
+	} `json:"ha"`
+
+	RunningMachine string `json:"running-machine"`
+	RunningQEMU    string `json:"running-qemu"`
+
+	Agent types.PVEBool `json:"agent"`
+}
+
+func (res getCurrentStatusResponseJSON) Map() (vm.CurrentStatus, error) {
+	if err := res.Status.IsValid(); err != nil {
+		return vm.CurrentStatus{}, err
+	}
+
+	var pid uint64
+
+	if res.PID != "" {
+		var err error
+		if pid, err = strconv.ParseUint(res.PID.String(), 10, 64); err != nil {
+			return vm.CurrentStatus{}, err
+		}
+	}
+
+	return vm.CurrentStatus{
+		Status:    res.Status,
+		QMPStatus: res.QMPStatus,
+
+		Uptime: time.Duration(res.Uptime) * time.Second,
+		PID:    uint(pid),
+		Lock:   res.Lock,
+
+		CPU:  res.CPU,
+		CPUs: uint(res.CPUs),
+
+		Memory:      res.Memory,
+		MemoryTotal: res.MemoryTotal,
+		Balloon:     res.Balloon,
+		Swap:        res.Swap,
+		SwapTotal:   res.SwapTotal,
+
+		Disk:      res.Disk,
+		DiskTotal: res.DiskTotal,
+		DiskRead:  res.DiskRead,
+		DiskWrite: res.DiskWrite,
+
+		NetIn:  res.NetIn,
+		NetOut: res.NetOut,
+
+		HA: vm.HAStatus{
+			Managed: res.HA.Managed.Bool(),
+			State:   res.HA.State,
+			Group:   res.HA.Group,
+		},
+
+		RunningMachine: res.RunningMachine,
+		RunningQEMU:    res.RunningQEMU,
+
+		AgentEnabled: res.Agent.Bool(),
+	}, nil
+}
+
+func (obj *VirtualMachine) GetStatus() (vm.Status, error) {
+	status, err := obj.GetCurrentStatus()
+	if err != nil {
+		return vm.StatusStopped, err
+	}
+
+	return status.Status, nil
+}
+
+func (obj *VirtualMachine) GetCurrentStatus() (vm.CurrentStatus, error) {
+	var res getCurrentStatusResponseJSON
+	if err := obj.svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/%s/%d/status/current", obj.node, obj.kind.String(), obj.vmid), nil, &res); err != nil {
+		return vm.CurrentStatus{}, err
+	}
+
+	return res.Map()
+}
@@ -0,0 +1,131 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestVirtualMachineStatus(t *testing.T) {
+	t.Run("QEMU", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewQEMU()
+
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_qemu_{vmid}_status_current.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/status/current", url.Values(nil)).
+			Return(response, nil).
+			Twice()
+
+		status, err := virtualMachine.GetCurrentStatus()
+		require.NoError(t, err)
+
+		assert.Equal(t, types.CurrentStatus{
+			Status:    types.StatusRunning,
+			QMPStatus: types.QMPStatusPaused,
+
+			Uptime: time.Hour,
+			PID:    4321,
+			Lock:   "backup",
+
+			CPU:  0.125,
+			CPUs: 2,
+
+			Memory:      536870912,
+			MemoryTotal: 1073741824,
+			Balloon:     1073741824,
+
+			DiskTotal: 34359738368,
+			DiskRead:  104857600,
+			DiskWrite: 52428800,
+
+			NetIn:  2048,
+			NetOut: 1024,
+
+			HA: types.HAStatus{
+				Managed: true,
+				State:   "started",
+				Group:   "test_group",
+			},
+
+			RunningMachine: "pc-i440fx-6.0+pve0",
+			RunningQEMU:    "6.0.0",
+
+			AgentEnabled: true,
+		}, status)
+
+		assert.True(t, status.IsRunning())
+		assert.True(t, status.IsPaused())
+
+		simpleStatus, err := virtualMachine.GetStatus()
+		require.NoError(t, err)
+		assert.Equal(t, types.StatusRunning, simpleStatus)
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("LXC", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewLXC()
+
+		response, err := ioutil.ReadFile(
+			"./testdata/get_nodes_{node}_lxc_{vmid}_status_current.json",
+		)
+		require.NoError(t, err)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/lxc/100/status/current", url.Values(nil)).
+			Return(response, nil).
+			Once()
+
+		status, err := virtualMachine.GetCurrentStatus()
+		require.NoError(t, err)
+
+		assert.Equal(t, types.CurrentStatus{
+			Status: types.StatusRunning,
+
+			Uptime: time.Minute,
+			PID:    9876,
+
+			CPU:  0.01,
+			CPUs: 1,
+
+			Memory:      33554432,
+			MemoryTotal: 536870912,
+			SwapTotal:   536870912,
+
+			Disk:      419430400,
+			DiskTotal: 8589934592,
+
+			NetIn:  512,
+			NetOut: 256,
+		}, status)
+
+		assert.False(t, status.IsPaused())
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		virtualMachine, _, exc := test.NewVirtualMachine()
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/test_kind/100/status/current", url.Values(nil)).
+			Return([]byte(`{"data": {"status": "exploded"}}`), nil).
+			Once()
+
+		_, err := virtualMachine.GetStatus()
+		assert.Error(t, err)
+
+		exc.AssertExpectations(t)
+	})
+}
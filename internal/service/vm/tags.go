@@ -0,0 +1,85 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func (obj *VirtualMachine) Tags() (vm.Tags, error) {
+	props, err := obj.GetProperties()
+	if err != nil {
+		return nil, err
+	}
+
+	return props.Tags, nil
+}
+
+func (obj *VirtualMachine) AddTags(tags ...string) error {
+	for _, tag := range tags {
+		if !vm.IsValidTag(tag) {
+			return vm.ErrInvalidTag
+		}
+	}
+
+	return obj.editTags(func(current *vm.Tags) {
+		current.Add(tags...)
+	})
+}
+
+func (obj *VirtualMachine) RemoveTags(tags ...string) error {
+	return obj.editTags(func(current *vm.Tags) {
+		current.Remove(tags...)
+	})
+}
+
+// editTags updates only the tags key, using the digest to avoid overwriting
+// concurrent changes.
+func (obj *VirtualMachine) editTags(fn func(current *vm.Tags)) error {
+	props, err := obj.GetProperties()
+	if err != nil {
+		return err
+	}
+
+	tags := props.Tags.Copy()
+	fn(&tags)
+
+	form := request.Values{}
+	form.AddString("digest", props.Digest)
+
+	if len(tags) == 0 {
+		form.AddString("delete", "tags")
+	} else if err := form.AddObject("tags", tags); err != nil {
+		return err
+	}
+
+	if err := obj.svc.client.Request(http.MethodPut, fmt.Sprintf("nodes/%s/%s/%d/config", obj.node, string(obj.kind), obj.vmid), form, nil); err != nil {
+		return err
+	}
+
+	obj.props = nil
+
+	return nil
+}
+
+func (obj *QEMUVirtualMachine) AddTags(tags ...string) error {
+	obj.props = nil
+	return obj.VirtualMachine.AddTags(tags...)
+}
+
+func (obj *QEMUVirtualMachine) RemoveTags(tags ...string) error {
+	obj.props = nil
+	return obj.VirtualMachine.RemoveTags(tags...)
+}
+
+func (obj *LXCVirtualMachine) AddTags(tags ...string) error {
+	obj.props = nil
+	return obj.VirtualMachine.AddTags(tags...)
+}
+
+func (obj *LXCVirtualMachine) RemoveTags(tags ...string) error {
+	obj.props = nil
+	return obj.VirtualMachine.RemoveTags(tags...)
+}
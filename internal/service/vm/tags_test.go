@@ -0,0 +1,92 @@
+package vm_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/internal/service/vm"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestVirtualMachineTags(t *testing.T) {
+	svc, _, exc := test.NewService()
+
+	newVirtualMachine := func() *vm.VirtualMachine {
+		return vm.NewVirtualMachine(
+			svc,
+			100,
+			types.KindQEMU,
+			"test_node",
+			"test_name",
+			false,
+			&types.Properties{
+				Tags:   types.NewTags("prod", "web"),
+				Digest: "0000000000000000000000000000000000000000",
+			},
+		)
+	}
+
+	t.Run("AddTags", func(t *testing.T) {
+		virtualMachine := newVirtualMachine()
+
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/config", url.Values{
+				"tags":   {"owner-ops;prod;web"},
+				"digest": {"0000000000000000000000000000000000000000"},
+			}).
+			Return([]byte{}, nil).
+			Once()
+
+		require.NoError(t, virtualMachine.AddTags("owner-ops", "prod"))
+		assert.Equal(t, types.ErrInvalidTag, virtualMachine.AddTags("bad tag"))
+
+		exc.AssertExpectations(t)
+	})
+
+	t.Run("RemoveTags", func(t *testing.T) {
+		virtualMachine := newVirtualMachine()
+
+		exc.
+			On("Request", http.MethodPut, "nodes/test_node/qemu/100/config", url.Values{
+				"delete": {"tags"},
+				"digest": {"0000000000000000000000000000000000000000"},
+			}).
+			Return([]byte{}, nil).
+			Once()
+
+		require.NoError(t, virtualMachine.RemoveTags("prod", "web"))
+
+		exc.AssertExpectations(t)
+	})
+}
+
+func TestServiceListByTags(t *testing.T) {
+	svc, _, exc := test.NewService()
+
+	exc.
+		On("Request", http.MethodGet, "cluster/resources", url.Values{
+			"type": {"vm"},
+		}).
+		Return([]byte(`{"data":[
+			{"vmid":102,"type":"qemu","node":"test_node","name":"db","tags":"prod;db"},
+			{"vmid":100,"type":"qemu","node":"test_node","name":"web","tags":"prod;web"},
+			{"vmid":101,"type":"lxc","node":"test_node","name":"dev","tags":"dev;web"},
+			{"vmid":103,"type":"lxc","node":"test_node","name":"untagged"}
+		]}`), nil).
+		Once()
+
+	vms, err := svc.ListByTags(types.TagFilter{
+		AllOf:  []string{"prod"},
+		NoneOf: []string{"dev"},
+	})
+	require.NoError(t, err)
+	require.Len(t, vms, 2)
+	assert.Equal(t, uint(100), vms[0].VMID())
+	assert.Equal(t, uint(102), vms[1].VMID())
+
+	exc.AssertExpectations(t)
+}
@@ -0,0 +1,89 @@
+package vm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func (svc *Service) convertQEMUToTemplate(
+	node string,
+	vmid uint,
+) (task.Task, error) {
+	var task string
+	if err := svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/qemu/%d/template", node, vmid), nil, &task); err != nil {
+		return nil, err
+	}
+
+	return svc.api.Task().Get(task)
+}
+
+func (svc *Service) GetLinkedCloneGraph() (vm.LinkedCloneGraph, error) {
+	vms, err := svc.List()
+	if err != nil {
+		return vm.LinkedCloneGraph{}, err
+	}
+
+	volumes := make(map[uint][]string, len(vms))
+
+	var unreadable []uint
+
+	for _, virtualMachine := range vms {
+		var res types.Properties
+		if err := svc.client.Request(http.MethodGet, fmt.Sprintf("nodes/%s/%s/%d/config", virtualMachine.Node(), virtualMachine.Kind(), virtualMachine.VMID()), nil, &res); err != nil {
+			unreadable = append(unreadable, virtualMachine.VMID())
+			continue
+		}
+
+		for _, v := range res {
+			media, ok := v.(string)
+			if !ok {
+				continue
+			}
+
+			// Disk properties start with the volume, followed by their
+			// comma separated options.
+			volume := strings.SplitN(media, ",", 2)[0]
+			if strings.Contains(volume, ":") {
+				volumes[virtualMachine.VMID()] = append(
+					volumes[virtualMachine.VMID()],
+					volume,
+				)
+			}
+		}
+	}
+
+	graph := vm.NewLinkedCloneGraph(volumes)
+	graph.Unreadable = unreadable
+
+	return graph, nil
+}
+
+func (svc *Service) checkTemplateDependents(
+	virtualMachine vm.VirtualMachine,
+) error {
+	if !virtualMachine.Template() {
+		return nil
+	}
+
+	graph, err := svc.GetLinkedCloneGraph()
+	if err != nil {
+		return err
+	}
+
+	if len(graph.Dependents(virtualMachine.VMID())) != 0 {
+		return vm.ErrTemplateHasLinkedClones
+	}
+
+	// Any unreadable guest could be a linked clone, so the template is
+	// only deleted when every configuration was checked.
+	if len(graph.Unreadable) != 0 {
+		return vm.ErrTemplateUnknownDependents
+	}
+
+	return nil
+}
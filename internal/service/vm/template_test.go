@@ -0,0 +1,203 @@
+package vm_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	task "github.com/xabinapal/gopve/internal/service/task/test"
+	"github.com/xabinapal/gopve/internal/service/vm/test"
+	"github.com/xabinapal/gopve/pkg/request/mocks"
+	types "github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func mockLinkedCloneGuests(exc *mocks.Executor) {
+	exc.
+		On("Request", http.MethodGet, "cluster/resources", url.Values{
+			"type": {"vm"},
+		}).
+		Return([]byte(`{"data":[
+			{"vmid":100,"type":"qemu","node":"test_node","name":"template","template":1},
+			{"vmid":101,"type":"qemu","node":"test_node","name":"clone","template":0},
+			{"vmid":102,"type":"lxc","node":"test_node","name":"container","template":0}
+		]}`), nil)
+
+	exc.
+		On("Request", http.MethodGet, "nodes/test_node/qemu/100/config", url.Values(nil)).
+		Return([]byte(`{"data":{"name":"template","template":1,"ostype":"l26","cpu":"host","sockets":1,"cores":1,"memory":512,"scsi0":"local-lvm:base-100-disk-0,size=8G","digest":"0000000000000000000000000000000000000000"}}`), nil)
+
+	exc.
+		On("Request", http.MethodGet, "nodes/test_node/qemu/101/config", url.Values(nil)).
+		Return([]byte(`{"data":{"name":"clone","scsi0":"local-lvm:base-100-disk-0/vm-101-disk-0,size=8G","ide2":"none,media=cdrom"}}`), nil)
+
+	exc.
+		On("Request", http.MethodGet, "nodes/test_node/lxc/102/config", url.Values(nil)).
+		Return([]byte(`{"data":{"hostname":"container","ostype":"debian","arch":"amd64","cores":1,"memory":512,"swap":512,"rootfs":"local-lvm:vm-102-disk-0,size=8G","digest":"0000000000000000000000000000000000000000"}}`), nil)
+}
+
+func TestServiceLinkedCloneGraph(t *testing.T) {
+	svc, _, exc := test.NewService()
+	mockLinkedCloneGuests(exc)
+
+	graph, err := svc.GetLinkedCloneGraph()
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint{100}, graph.Templates())
+	assert.Equal(t, []types.LinkedClone{{
+		VMID:   101,
+		Volume: "local-lvm:base-100-disk-0/vm-101-disk-0",
+	}}, graph.Clones(100))
+	assert.Empty(t, graph.Unreadable)
+
+	t.Run("OfflineNode", func(t *testing.T) {
+		svc, _, exc := test.NewService()
+
+		exc.
+			On("Request", http.MethodGet, "cluster/resources", url.Values{
+				"type": {"vm"},
+			}).
+			Return([]byte(`{"data":[
+				{"vmid":100,"type":"qemu","node":"test_node","name":"template","template":1},
+				{"vmid":101,"type":"qemu","node":"offline_node","name":"clone","template":0}
+			]}`), nil)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/config", url.Values(nil)).
+			Return([]byte(`{"data":{"scsi0":"local-lvm:base-100-disk-0,size=8G"}}`), nil)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/offline_node/qemu/101/config", url.Values(nil)).
+			Return(nil, fmt.Errorf("595 - no route to host"))
+
+		graph, err := svc.GetLinkedCloneGraph()
+		require.NoError(t, err)
+		assert.Empty(t, graph.Templates())
+		assert.Equal(t, []uint{101}, graph.Unreadable)
+	})
+}
+
+func TestServiceDeleteTemplate(t *testing.T) {
+	t.Run("Dependents", func(t *testing.T) {
+		svc, _, exc := test.NewService()
+		mockLinkedCloneGuests(exc)
+
+		_, err := svc.DeleteQEMU(100, types.DeleteOptions{Purge: true})
+		assert.Equal(t, types.ErrTemplateHasLinkedClones, err)
+
+		_, err = svc.DeleteQEMU(100, types.DeleteOptions{Force: true})
+		assert.Error(t, err)
+	})
+
+	t.Run("UnreadableGuest", func(t *testing.T) {
+		svc, _, exc := test.NewService()
+
+		exc.
+			On("Request", http.MethodGet, "cluster/resources", url.Values{
+				"type": {"vm"},
+			}).
+			Return([]byte(`{"data":[
+				{"vmid":100,"type":"qemu","node":"test_node","name":"template","template":1},
+				{"vmid":101,"type":"qemu","node":"offline_node","name":"clone","template":0}
+			]}`), nil)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/test_node/qemu/100/config", url.Values(nil)).
+			Return([]byte(`{"data":{"name":"template","template":1,"ostype":"l26","cpu":"host","sockets":1,"cores":1,"memory":512,"scsi0":"local-lvm:base-100-disk-0,size=8G","digest":"0000000000000000000000000000000000000000"}}`), nil)
+
+		exc.
+			On("Request", http.MethodGet, "nodes/offline_node/qemu/101/config", url.Values(nil)).
+			Return(nil, fmt.Errorf("595 - no route to host"))
+
+		_, err := svc.DeleteQEMU(100, types.DeleteOptions{Purge: true})
+		assert.Equal(t, types.ErrTemplateUnknownDependents, err)
+	})
+
+	t.Run("IgnoreLinkedClones", func(t *testing.T) {
+		svc, api, exc := test.NewService()
+		mockLinkedCloneGuests(exc)
+
+		exc.
+			On("Request", http.MethodDelete, "nodes/test_node/qemu/100", url.Values{
+				"purge": {"1"},
+			}).
+			Return([]byte("{\"data\":\"UPID:test_node::::qmdestroy:100:root@pam:\"}"), nil).
+			Once()
+
+		expectedTask, _, _ := task.NewTask(
+			"test_node",
+			"::",
+			"qmdestroy",
+			"100",
+			"root@pam",
+			"",
+		)
+
+		api.TaskService.
+			On("Get", "UPID:test_node::::qmdestroy:100:root@pam:").
+			Return(expectedTask, nil)
+
+		task, err := svc.DeleteQEMU(100, types.DeleteOptions{
+			Purge:              true,
+			IgnoreLinkedClones: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedTask, task)
+
+		exc.AssertNotCalled(
+			t,
+			"Request",
+			http.MethodGet,
+			"nodes/test_node/qemu/101/config",
+			url.Values(nil),
+		)
+	})
+}
+
+func TestServiceDeleteLXC(t *testing.T) {
+	svc, api, exc := test.NewService()
+	mockLinkedCloneGuests(exc)
+
+	exc.
+		On("Request", http.MethodDelete, "nodes/test_node/lxc/102", url.Values{
+			"force": {"1"},
+		}).
+		Return([]byte("{\"data\":\"UPID:test_node::::vzdestroy:102:root@pam:\"}"), nil).
+		Once()
+
+	expectedTask, _, _ := task.NewTask(
+		"test_node",
+		"::",
+		"vzdestroy",
+		"102",
+		"root@pam",
+		"",
+	)
+
+	api.TaskService.
+		On("Get", "UPID:test_node::::vzdestroy:102:root@pam:").
+		Return(expectedTask, nil)
+
+	task, err := svc.DeleteLXC(102, types.DeleteOptions{Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, expectedTask, task)
+
+	_, err = svc.DeleteQEMU(102, types.DeleteOptions{})
+	assert.Error(t, err)
+}
+
+func TestVirtualMachineConvertToTemplate(t *testing.T) {
+	virtualMachine, _, exc := test.NewLXC()
+
+	exc.
+		On("Request", http.MethodPost, "nodes/test_node/lxc/100/template", url.Values(nil)).
+		Return([]byte("{\"data\":null}"), nil).
+		Once()
+
+	require.NoError(t, virtualMachine.ConvertToTemplate())
+	assert.True(t, virtualMachine.Template())
+
+	exc.AssertExpectations(t)
+}
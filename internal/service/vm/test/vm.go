@@ -4,7 +4,8 @@ import (
 	"github.com/xabinapal/gopve/internal/service/vm"
 	"github.com/xabinapal/gopve/pkg/client/test"
 	"github.com/xabinapal/gopve/pkg/request/mocks"
-	types "github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types"
+	vm_types "github.com/xabinapal/gopve/pkg/types/vm"
 )
 
 func NewVirtualMachine() (*vm.VirtualMachine, *test.API, *mocks.Executor) {
@@ -13,7 +14,7 @@ func NewVirtualMachine() (*vm.VirtualMachine, *test.API, *mocks.Executor) {
 	return vm.NewVirtualMachine(
 		svc,
 		100,
-		types.Kind("test_kind"),
+		vm_types.Kind("test_kind"),
 		"test_node",
 		"test_name",
 		false,
@@ -22,17 +23,23 @@ func NewVirtualMachine() (*vm.VirtualMachine, *test.API, *mocks.Executor) {
 }
 
 func NewQEMU() (*vm.QEMUVirtualMachine, *test.API, *mocks.Executor) {
+	return NewQEMUWithProperties(nil)
+}
+
+func NewQEMUWithProperties(
+	props types.Properties,
+) (*vm.QEMUVirtualMachine, *test.API, *mocks.Executor) {
 	svc, api, exc := NewService()
 
 	obj, _ := vm.NewDynamicVirtualMachine(
 		svc,
 		100,
-		types.KindQEMU,
+		vm_types.KindQEMU,
 		"test_node",
 		"test_name",
 		false,
 		nil,
-		nil,
+		props,
 	)
 
 	return obj.(*vm.QEMUVirtualMachine), api, exc
@@ -44,7 +51,7 @@ func NewLXC() (*vm.LXCVirtualMachine, *test.API, *mocks.Executor) {
 	obj, _ := vm.NewDynamicVirtualMachine(
 		svc,
 		100,
-		types.KindLXC,
+		vm_types.KindLXC,
 		"test_node",
 		"test_name",
 		false,
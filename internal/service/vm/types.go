@@ -130,35 +130,25 @@ func (this *VirtualMachine) Digest() (string, error) {
 	return props.Digest, nil
 }
 
-func (obj *VirtualMachine) GetStatus() (vm.Status, error) {
-	var res struct {
-		Status vm.Status `json:"status"`
-	}
-
-	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("node/%s/qemu/%d/status/current", obj.node, obj.vmid), nil, &res); err != nil {
-		return vm.StatusStopped, err
-	}
+func (obj *VirtualMachine) ConvertToTemplate() error {
+	switch obj.kind {
+	case vm.KindQEMU:
+		t, err := obj.svc.convertQEMUToTemplate(obj.node, obj.vmid)
+		if err != nil {
+			return err
+		}
 
-	if err := res.Status.IsValid(); err != nil {
-		return vm.StatusStopped, err
-	}
+		if err := t.Wait(); err != nil {
+			return err
+		}
 
-	return res.Status, nil
-}
+	case vm.KindLXC:
+		if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("nodes/%s/lxc/%d/template", obj.node, obj.vmid), nil, nil); err != nil {
+			return err
+		}
 
-func (obj *VirtualMachine) ConvertToTemplate() error {
-	if err := obj.svc.client.Request(
-		http.MethodPost,
-		fmt.Sprintf(
-			"node/%s/%s/%d/template",
-			obj.node,
-			obj.kind.String(),
-			obj.vmid,
-		),
-		nil,
-		nil,
-	); err != nil {
-		return err
+	default:
+		return vm.ErrInvalidKind
 	}
 
 	obj.template = true
@@ -232,16 +222,22 @@ func (obj *QEMUVirtualMachine) GetQEMUProperties() (qemu.Properties, error) {
 func (obj *QEMUVirtualMachine) SetQEMUProperties(
 	props qemu.Properties,
 ) error {
-	form, err := props.MapToValues()
+	current, err := obj.GetQEMUProperties()
 	if err != nil {
 		return err
 	}
 
-	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("node/%s/qemu/%d/config", obj.node, obj.vmid), form, nil); err != nil {
+	form, err := props.MapToUpdateValues(current)
+	if err != nil {
 		return err
 	}
 
-	obj.props = &props
+	if err := obj.svc.client.Request(http.MethodPut, fmt.Sprintf("nodes/%s/qemu/%d/config", obj.node, obj.vmid), form, nil); err != nil {
+		return err
+	}
+
+	obj.VirtualMachine.props = nil
+	obj.props = nil
 
 	return nil
 }
@@ -327,16 +323,22 @@ func (obj *LXCVirtualMachine) GetLXCProperties() (lxc.Properties, error) {
 }
 
 func (obj *LXCVirtualMachine) SetLXCProperties(props lxc.Properties) error {
-	form, err := props.MapToValues()
+	current, err := obj.GetLXCProperties()
+	if err != nil {
+		return err
+	}
+
+	form, err := props.MapToUpdateValues(current)
 	if err != nil {
 		return err
 	}
 
-	if err := obj.svc.client.Request(http.MethodPost, fmt.Sprintf("node/%s/lxcqemu/%d/config", obj.node, obj.vmid), form, nil); err != nil {
+	if err := obj.svc.client.Request(http.MethodPut, fmt.Sprintf("nodes/%s/lxc/%d/config", obj.node, obj.vmid), form, nil); err != nil {
 		return err
 	}
 
-	obj.props = &props
+	obj.VirtualMachine.props = nil
+	obj.props = nil
 
 	return nil
 }
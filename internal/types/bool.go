@@ -47,9 +47,9 @@ func (obj PVEBool) Bool() bool {
 }
 
 func (obj *PVEBool) UnmarshalJSON(b []byte) error {
-	if bytes.Equal(b, []byte("1")) {
+	if bytes.Equal(b, []byte("1")) || bytes.Equal(b, []byte("true")) {
 		*obj = PVEBool(true)
-	} else if bytes.Equal(b, []byte("0")) || bytes.Equal(b, []byte("\"\"")) {
+	} else if bytes.Equal(b, []byte("0")) || bytes.Equal(b, []byte("false")) || bytes.Equal(b, []byte("\"\"")) {
 		*obj = PVEBool(false)
 	} else {
 		return fmt.Errorf("unknown boolean value %s", string(b))
@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
 	"time"
@@ -96,6 +97,13 @@ func (cli *Client) Request(
 	return nil
 }
 
+func (cli *Client) Websocket(
+	resource string,
+	form request.Values,
+) (io.ReadWriteCloser, error) {
+	return cli.executor.Websocket(resource, url.Values(form))
+}
+
 func (cli *Client) API() API {
 	return cli.api
 }
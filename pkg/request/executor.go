@@ -3,6 +3,7 @@ package request
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/cookiejar"
@@ -19,6 +20,7 @@ type Executor interface {
 	EndAtomicBlock()
 
 	Request(method, url string, form url.Values) ([]byte, error)
+	Websocket(url string, form url.Values) (io.ReadWriteCloser, error)
 
 	SetCSRFToken(token string)
 	SetAuthenticationTicket(ticket string, method AuthenticationMethod)
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 	request "github.com/xabinapal/gopve/pkg/request"
 
@@ -56,3 +58,26 @@ func (_m *Executor) SetCSRFToken(token string) {
 func (_m *Executor) StartAtomicBlock() {
 	_m.Called()
 }
+
+// Websocket provides a mock function with given fields: _a0, form
+func (_m *Executor) Websocket(_a0 string, form url.Values) (io.ReadWriteCloser, error) {
+	ret := _m.Called(_a0, form)
+
+	var r0 io.ReadWriteCloser
+	if rf, ok := ret.Get(0).(func(string, url.Values) io.ReadWriteCloser); ok {
+		r0 = rf(_a0, form)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadWriteCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, url.Values) error); ok {
+		r1 = rf(_a0, form)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
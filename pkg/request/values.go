@@ -2,6 +2,7 @@ package request
 
 import (
 	"net/url"
+	"sort"
 	"strconv"
 	"time"
 
@@ -77,3 +78,32 @@ func (v Values) ConditionalAddObject(k string, t types.Marshaler, cond bool) {
 		v.AddObject(k, t)
 	}
 }
+
+// AddDeleted appends a delete key with every key present in previous that
+// is missing in v, except the skipped ones, so PVE removes them.
+func (v Values) AddDeleted(previous Values, skip ...string) {
+	var keys []string
+
+	for k := range previous {
+		if _, ok := v[k]; ok || containsString(skip, k) {
+			continue
+		}
+
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	deleted := internal_types.NewPVEList(",", keys)
+	v.ConditionalAddObject("delete", deleted, deleted.Len() != 0)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
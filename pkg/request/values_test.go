@@ -90,3 +90,20 @@ func TestValuesConditionalNotAddValue(t *testing.T) {
 	values.ConditionalAddTime("timeKey", time.Unix(1609458356, 0), false)
 	assert.NotContains(t, values, "timeKey")
 }
+
+func TestValuesAddDeleted(t *testing.T) {
+	previous := request.Values{}
+	previous.AddString("digest", "0000")
+	previous.AddString("name", "test")
+	previous.AddString("net1", "virtio")
+	previous.AddString("net0", "virtio")
+
+	values := request.Values{}
+	values.AddString("name", "test")
+	values.AddDeleted(previous, "digest")
+	helpValuesContainsKVPair(t, values, "delete", "net0,net1")
+
+	values = request.Values{}
+	values.AddDeleted(request.Values{}, "digest")
+	assert.NotContains(t, values, "delete")
+}
@@ -0,0 +1,262 @@
+package request
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	websocketOpContinuation byte = 0x0
+	websocketOpText         byte = 0x1
+	websocketOpBinary       byte = 0x2
+	websocketOpClose        byte = 0x8
+	websocketOpPing         byte = 0x9
+	websocketOpPong         byte = 0xa
+)
+
+func (exc *PVEExecutor) Websocket(
+	path string,
+	form url.Values,
+) (io.ReadWriteCloser, error) {
+	absoluteURL, err := exc.getAbsoluteURL(http.MethodGet, path, form)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, absoluteURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req.Header.Add("Connection", "Upgrade")
+	req.Header.Add("Upgrade", "websocket")
+	req.Header.Add("Sec-WebSocket-Version", "13")
+	req.Header.Add("Sec-WebSocket-Key", key)
+	req.Header.Add("Sec-WebSocket-Protocol", "binary")
+
+	if exc.csrf != "" {
+		req.Header.Add("CSRFPreventionToken", exc.csrf)
+	}
+
+	if exc.ticket != "" {
+		req.Header.Add("Authorization", exc.ticket)
+	}
+
+	if exc.client.Jar != nil {
+		for _, cookie := range exc.client.Jar.Cookies(absoluteURL) {
+			req.AddCookie(cookie)
+		}
+	}
+
+	// The client timeout would also apply to the upgraded connection, so
+	// the transport is used directly instead.
+	transport := exc.client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		res.Body.Close()
+
+		status := string(errorRegExp.ReplaceAll([]byte(res.Status), nil))
+		return nil, fmt.Errorf("%d - %s", res.StatusCode, status)
+	}
+
+	accept := sha1.Sum([]byte(key + websocketGUID))
+	if res.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(accept[:]) {
+		res.Body.Close()
+		return nil, fmt.Errorf("invalid websocket handshake response")
+	}
+
+	rwc, ok := res.Body.(io.ReadWriteCloser)
+	if !ok {
+		res.Body.Close()
+		return nil, fmt.Errorf("websocket connection is not writable")
+	}
+
+	return NewWebsocketConn(rwc), nil
+}
+
+type WebsocketConn struct {
+	rwc io.ReadWriteCloser
+	br  *bufio.Reader
+
+	rmux *sync.Mutex
+	wmux *sync.Mutex
+
+	remaining uint64
+	closed    bool
+}
+
+func NewWebsocketConn(rwc io.ReadWriteCloser) *WebsocketConn {
+	return &WebsocketConn{
+		rwc: rwc,
+		br:  bufio.NewReader(rwc),
+
+		rmux: new(sync.Mutex),
+		wmux: new(sync.Mutex),
+	}
+}
+
+func (conn *WebsocketConn) Read(p []byte) (int, error) {
+	conn.rmux.Lock()
+	defer conn.rmux.Unlock()
+
+	for conn.remaining == 0 {
+		opcode, length, err := conn.readFrameHeader()
+		if err != nil {
+			return 0, err
+		}
+
+		switch opcode {
+		case websocketOpContinuation, websocketOpText, websocketOpBinary:
+			conn.remaining = length
+
+		case websocketOpPing, websocketOpPong, websocketOpClose:
+			payload := make([]byte, length)
+			if _, err := io.ReadFull(conn.br, payload); err != nil {
+				return 0, err
+			}
+
+			switch opcode {
+			case websocketOpPing:
+				if err := conn.writeFrame(websocketOpPong, payload); err != nil {
+					return 0, err
+				}
+
+			case websocketOpClose:
+				conn.writeClose(payload)
+				return 0, io.EOF
+			}
+
+		default:
+			return 0, fmt.Errorf("unknown websocket opcode %d", opcode)
+		}
+	}
+
+	if uint64(len(p)) > conn.remaining {
+		p = p[:conn.remaining]
+	}
+
+	n, err := conn.br.Read(p)
+	conn.remaining -= uint64(n)
+
+	return n, err
+}
+
+func (conn *WebsocketConn) Write(p []byte) (int, error) {
+	if err := conn.writeFrame(websocketOpBinary, p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (conn *WebsocketConn) Close() error {
+	conn.writeClose([]byte{0x03, 0xe8})
+	return conn.rwc.Close()
+}
+
+func (conn *WebsocketConn) readFrameHeader() (byte, uint64, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn.br, header); err != nil {
+		return 0, 0, err
+	}
+
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(conn.br, extended); err != nil {
+			return 0, 0, err
+		}
+
+		length = uint64(binary.BigEndian.Uint16(extended))
+
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(conn.br, extended); err != nil {
+			return 0, 0, err
+		}
+
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if masked {
+		return 0, 0, fmt.Errorf("websocket server frames must not be masked")
+	}
+
+	return opcode, length, nil
+}
+
+func (conn *WebsocketConn) writeFrame(opcode byte, payload []byte) error {
+	conn.wmux.Lock()
+	defer conn.wmux.Unlock()
+
+	if conn.closed {
+		return io.ErrClosedPipe
+	}
+
+	frame := []byte{0x80 | opcode}
+
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, 0x80|byte(length))
+
+	case length <= 0xffff:
+		frame = append(frame, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(length))
+
+	default:
+		frame = append(frame, 0x80|127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(length))
+	}
+
+	mask := make([]byte, 4)
+	if _, err := rand.Read(mask); err != nil {
+		return err
+	}
+
+	frame = append(frame, mask...)
+
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	_, err := conn.rwc.Write(frame)
+
+	return err
+}
+
+func (conn *WebsocketConn) writeClose(payload []byte) {
+	if err := conn.writeFrame(websocketOpClose, payload); err == nil {
+		conn.wmux.Lock()
+		conn.closed = true
+		conn.wmux.Unlock()
+	}
+}
@@ -0,0 +1,189 @@
+package request_test
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/request"
+)
+
+func helpWebsocketReadFrame(
+	t *testing.T,
+	r io.Reader,
+) (byte, []byte) {
+	t.Helper()
+
+	header := make([]byte, 2)
+	_, err := io.ReadFull(r, header)
+	require.NoError(t, err)
+
+	require.NotZero(t, header[1]&0x80, "client frames must be masked")
+
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		_, err := io.ReadFull(r, extended)
+		require.NoError(t, err)
+
+		length = uint64(binary.BigEndian.Uint16(extended))
+
+	case 127:
+		extended := make([]byte, 8)
+		_, err := io.ReadFull(r, extended)
+		require.NoError(t, err)
+
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	mask := make([]byte, 4)
+	_, err = io.ReadFull(r, mask)
+	require.NoError(t, err)
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(r, payload)
+	require.NoError(t, err)
+
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return header[0] & 0x0f, payload
+}
+
+func helpWebsocketWriteFrame(
+	t *testing.T,
+	w io.Writer,
+	opcode byte,
+	payload []byte,
+) {
+	t.Helper()
+
+	frame := []byte{0x80 | opcode}
+
+	if len(payload) < 126 {
+		frame = append(frame, byte(len(payload)))
+	} else {
+		frame = append(frame, 126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	}
+
+	_, err := w.Write(append(frame, payload...))
+	require.NoError(t, err)
+}
+
+func helpWebsocketCreateServer(
+	t *testing.T,
+	handler func(conn net.Conn, r *bufio.Reader),
+) *request.PVEExecutor {
+	t.Helper()
+
+	srv := helpExecutorCreateServer(
+		t,
+		func(res http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "/api2/json/test", req.URL.Path)
+			assert.Equal(t, "test", req.URL.Query().Get("test"))
+			assert.Equal(t, "websocket", req.Header.Get("Upgrade"))
+			assert.Equal(t, "binary", req.Header.Get("Sec-WebSocket-Protocol"))
+
+			accept := sha1.Sum([]byte(
+				req.Header.Get("Sec-WebSocket-Key") +
+					"258EAFA5-E914-47DA-95CA-C5AB0DC85B11",
+			))
+
+			hijacker, ok := res.(http.Hijacker)
+			require.True(t, ok)
+
+			conn, rw, err := hijacker.Hijack()
+			require.NoError(t, err)
+
+			defer conn.Close()
+
+			fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\n")
+			fmt.Fprintf(rw, "Upgrade: websocket\r\n")
+			fmt.Fprintf(rw, "Connection: Upgrade\r\n")
+			fmt.Fprintf(
+				rw,
+				"Sec-WebSocket-Accept: %s\r\n\r\n",
+				base64.StdEncoding.EncodeToString(accept[:]),
+			)
+			require.NoError(t, rw.Flush())
+
+			handler(conn, rw.Reader)
+		},
+	)
+
+	return helpExecutorCreateExecutor(t, srv)
+}
+
+func TestExecutorWebsocket(t *testing.T) {
+	t.Run("ReadWrite", func(t *testing.T) {
+		exc := helpWebsocketCreateServer(
+			t,
+			func(conn net.Conn, r *bufio.Reader) {
+				opcode, payload := helpWebsocketReadFrame(t, r)
+				assert.Equal(t, byte(0x2), opcode)
+				assert.Equal(t, "ping?", string(payload))
+
+				helpWebsocketWriteFrame(t, conn, 0x9, []byte("heartbeat"))
+				helpWebsocketWriteFrame(t, conn, 0x2, []byte("pong!"))
+
+				large := make([]byte, 300)
+				for i := range large {
+					large[i] = 'x'
+				}
+
+				helpWebsocketWriteFrame(t, conn, 0x2, large)
+
+				opcode, payload = helpWebsocketReadFrame(t, r)
+				assert.Equal(t, byte(0xa), opcode)
+				assert.Equal(t, "heartbeat", string(payload))
+
+				helpWebsocketWriteFrame(t, conn, 0x8, []byte{0x03, 0xe8})
+
+				opcode, _ = helpWebsocketReadFrame(t, r)
+				assert.Equal(t, byte(0x8), opcode)
+			},
+		)
+
+		conn, err := exc.Websocket("test", url.Values{"test": {"test"}})
+		require.NoError(t, err)
+
+		_, err = conn.Write([]byte("ping?"))
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(conn)
+		require.NoError(t, err)
+
+		assert.Len(t, data, 305)
+		assert.Equal(t, "pong!", string(data[:5]))
+
+		require.NoError(t, conn.Close())
+	})
+
+	t.Run("HandshakeError", func(t *testing.T) {
+		srv := helpExecutorCreateServer(
+			t,
+			func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusUnauthorized)
+			},
+		)
+
+		exc := helpExecutorCreateExecutor(t, srv)
+
+		_, err := exc.Websocket("test", nil)
+		assert.EqualError(t, err, "401 - Unauthorized")
+	})
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"github.com/xabinapal/gopve/pkg/types/cluster"
 	"github.com/xabinapal/gopve/pkg/types/firewall"
+	"github.com/xabinapal/gopve/pkg/types/node"
 	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
 )
 
 //go:generate mockery --case snake --name Cluster
@@ -36,6 +38,30 @@ type Cluster interface {
 	EditFirewallRule(pos uint, rule firewall.Rule) error
 	MoveFirewallRule(pos uint, newpos uint) error
 	DeleteFirewallRule(pos uint, digest string) error
+
+	ListBackupJobs() ([]cluster.BackupJob, error)
+	GetBackupJob(id string) (cluster.BackupJob, error)
+	CreateBackupJob(
+		id string,
+		props cluster.BackupJobProperties,
+	) (cluster.BackupJob, error)
+	ListNotBackedUp() ([]cluster.NotBackedUpGuest, error)
+
+	ListReplicationJobs() ([]vm.ReplicationJob, error)
+	GetReplicationJob(id string) (vm.ReplicationJob, error)
+	CreateReplicationJob(
+		vmid uint,
+		props vm.ReplicationJobProperties,
+	) (vm.ReplicationJob, error)
+
+	GetTagStyle() (cluster.TagStyle, error)
+	SetTagStyle(style cluster.TagStyle) error
+
+	// StartAll, StopAll and ShutdownAll run the bulk action on every online
+	// node, returning one task per node.
+	StartAll(opts node.StartAllOptions) ([]task.Task, error)
+	StopAll(opts node.StopAllOptions) ([]task.Task, error)
+	ShutdownAll(opts node.StopAllOptions) ([]task.Task, error)
 }
 
 type HighAvailability interface {
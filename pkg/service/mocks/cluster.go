@@ -8,9 +8,13 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	node "github.com/xabinapal/gopve/pkg/types/node"
+
 	service "github.com/xabinapal/gopve/pkg/service"
 
 	task "github.com/xabinapal/gopve/pkg/types/task"
+
+	vm "github.com/xabinapal/gopve/pkg/types/vm"
 )
 
 // Cluster is an autogenerated mock type for the Cluster type
@@ -55,6 +59,50 @@ func (_m *Cluster) Create(name string, props cluster.NodeProperties) (task.Task,
 	return r0, r1
 }
 
+// CreateBackupJob provides a mock function with given fields: id, props
+func (_m *Cluster) CreateBackupJob(id string, props cluster.BackupJobProperties) (cluster.BackupJob, error) {
+	ret := _m.Called(id, props)
+
+	var r0 cluster.BackupJob
+	if rf, ok := ret.Get(0).(func(string, cluster.BackupJobProperties) cluster.BackupJob); ok {
+		r0 = rf(id, props)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cluster.BackupJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, cluster.BackupJobProperties) error); ok {
+		r1 = rf(id, props)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReplicationJob provides a mock function with given fields: vmid, props
+func (_m *Cluster) CreateReplicationJob(vmid uint, props vm.ReplicationJobProperties) (vm.ReplicationJob, error) {
+	ret := _m.Called(vmid, props)
+
+	var r0 vm.ReplicationJob
+	if rf, ok := ret.Get(0).(func(uint, vm.ReplicationJobProperties) vm.ReplicationJob); ok {
+		r0 = rf(vmid, props)
+	} else {
+		r0 = ret.Get(0).(vm.ReplicationJob)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, vm.ReplicationJobProperties) error); ok {
+		r1 = rf(vmid, props)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteFirewallRule provides a mock function with given fields: pos, digest
 func (_m *Cluster) DeleteFirewallRule(pos uint, digest string) error {
 	ret := _m.Called(pos, digest)
@@ -106,6 +154,29 @@ func (_m *Cluster) Get() (cluster.Cluster, error) {
 	return r0, r1
 }
 
+// GetBackupJob provides a mock function with given fields: id
+func (_m *Cluster) GetBackupJob(id string) (cluster.BackupJob, error) {
+	ret := _m.Called(id)
+
+	var r0 cluster.BackupJob
+	if rf, ok := ret.Get(0).(func(string) cluster.BackupJob); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cluster.BackupJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFirewallAlias provides a mock function with given fields: name
 func (_m *Cluster) GetFirewallAlias(name string) (firewall.Alias, error) {
 	ret := _m.Called(name)
@@ -217,6 +288,48 @@ func (_m *Cluster) GetFirewallServiceGroup(name string) (firewall.ServiceGroup,
 	return r0, r1
 }
 
+// GetReplicationJob provides a mock function with given fields: id
+func (_m *Cluster) GetReplicationJob(id string) (vm.ReplicationJob, error) {
+	ret := _m.Called(id)
+
+	var r0 vm.ReplicationJob
+	if rf, ok := ret.Get(0).(func(string) vm.ReplicationJob); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(vm.ReplicationJob)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTagStyle provides a mock function with given fields:
+func (_m *Cluster) GetTagStyle() (cluster.TagStyle, error) {
+	ret := _m.Called()
+
+	var r0 cluster.TagStyle
+	if rf, ok := ret.Get(0).(func() cluster.TagStyle); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(cluster.TagStyle)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HA provides a mock function with given fields:
 func (_m *Cluster) HA() service.HighAvailability {
 	ret := _m.Called()
@@ -256,6 +369,29 @@ func (_m *Cluster) Join(hostname string, password string, fingerprint string, pr
 	return r0, r1
 }
 
+// ListBackupJobs provides a mock function with given fields:
+func (_m *Cluster) ListBackupJobs() ([]cluster.BackupJob, error) {
+	ret := _m.Called()
+
+	var r0 []cluster.BackupJob
+	if rf, ok := ret.Get(0).(func() []cluster.BackupJob); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cluster.BackupJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListFirewallAliases provides a mock function with given fields:
 func (_m *Cluster) ListFirewallAliases() ([]firewall.Alias, error) {
 	ret := _m.Called()
@@ -348,6 +484,52 @@ func (_m *Cluster) ListFirewallServiceGroups() ([]firewall.ServiceGroup, error)
 	return r0, r1
 }
 
+// ListNotBackedUp provides a mock function with given fields:
+func (_m *Cluster) ListNotBackedUp() ([]cluster.NotBackedUpGuest, error) {
+	ret := _m.Called()
+
+	var r0 []cluster.NotBackedUpGuest
+	if rf, ok := ret.Get(0).(func() []cluster.NotBackedUpGuest); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cluster.NotBackedUpGuest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReplicationJobs provides a mock function with given fields:
+func (_m *Cluster) ListReplicationJobs() ([]vm.ReplicationJob, error) {
+	ret := _m.Called()
+
+	var r0 []vm.ReplicationJob
+	if rf, ok := ret.Get(0).(func() []vm.ReplicationJob); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vm.ReplicationJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MoveFirewallRule provides a mock function with given fields: pos, newpos
 func (_m *Cluster) MoveFirewallRule(pos uint, newpos uint) error {
 	ret := _m.Called(pos, newpos)
@@ -375,3 +557,86 @@ func (_m *Cluster) SetFirewallProperties(props firewall.ClusterProperties) error
 
 	return r0
 }
+
+// SetTagStyle provides a mock function with given fields: style
+func (_m *Cluster) SetTagStyle(style cluster.TagStyle) error {
+	ret := _m.Called(style)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(cluster.TagStyle) error); ok {
+		r0 = rf(style)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShutdownAll provides a mock function with given fields: opts
+func (_m *Cluster) ShutdownAll(opts node.StopAllOptions) ([]task.Task, error) {
+	ret := _m.Called(opts)
+
+	var r0 []task.Task
+	if rf, ok := ret.Get(0).(func(node.StopAllOptions) []task.Task); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]task.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(node.StopAllOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartAll provides a mock function with given fields: opts
+func (_m *Cluster) StartAll(opts node.StartAllOptions) ([]task.Task, error) {
+	ret := _m.Called(opts)
+
+	var r0 []task.Task
+	if rf, ok := ret.Get(0).(func(node.StartAllOptions) []task.Task); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]task.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(node.StartAllOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StopAll provides a mock function with given fields: opts
+func (_m *Cluster) StopAll(opts node.StopAllOptions) ([]task.Task, error) {
+	ret := _m.Called(opts)
+
+	var r0 []task.Task
+	if rf, ok := ret.Get(0).(func(node.StopAllOptions) []task.Task); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]task.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(node.StopAllOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -3,11 +3,15 @@
 package mocks
 
 import (
+	export "github.com/xabinapal/gopve/pkg/types/vm/export"
+
 	mock "github.com/stretchr/testify/mock"
 	lxc "github.com/xabinapal/gopve/pkg/types/vm/lxc"
 
 	qemu "github.com/xabinapal/gopve/pkg/types/vm/qemu"
 
+	spec "github.com/xabinapal/gopve/pkg/types/vm/spec"
+
 	task "github.com/xabinapal/gopve/pkg/types/task"
 
 	vm "github.com/xabinapal/gopve/pkg/types/vm"
@@ -18,16 +22,62 @@ type VirtualMachine struct {
 	mock.Mock
 }
 
+// Apply provides a mock function with given fields: plan, opts
+func (_m *VirtualMachine) Apply(plan spec.Plan, opts spec.ApplyOptions) ([]task.Task, error) {
+	ret := _m.Called(plan, opts)
+
+	var r0 []task.Task
+	if rf, ok := ret.Get(0).(func(spec.Plan, spec.ApplyOptions) []task.Task); ok {
+		r0 = rf(plan, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]task.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(spec.Plan, spec.ApplyOptions) error); ok {
+		r1 = rf(plan, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Backup provides a mock function with given fields: node, selection, opts
+func (_m *VirtualMachine) Backup(node string, selection vm.BackupSelection, opts vm.BackupOptions) (task.Task, error) {
+	ret := _m.Called(node, selection, opts)
+
+	var r0 task.Task
+	if rf, ok := ret.Get(0).(func(string, vm.BackupSelection, vm.BackupOptions) task.Task); ok {
+		r0 = rf(node, selection, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(task.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, vm.BackupSelection, vm.BackupOptions) error); ok {
+		r1 = rf(node, selection, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateLXC provides a mock function with given fields: opts
-func (_m *VirtualMachine) CreateLXC(opts lxc.CreateOptions) (task.Task, error) {
+func (_m *VirtualMachine) CreateLXC(opts lxc.CreateOptions) (task.VirtualMachineTask, error) {
 	ret := _m.Called(opts)
 
-	var r0 task.Task
-	if rf, ok := ret.Get(0).(func(lxc.CreateOptions) task.Task); ok {
+	var r0 task.VirtualMachineTask
+	if rf, ok := ret.Get(0).(func(lxc.CreateOptions) task.VirtualMachineTask); ok {
 		r0 = rf(opts)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(task.Task)
+			r0 = ret.Get(0).(task.VirtualMachineTask)
 		}
 	}
 
@@ -64,13 +114,36 @@ func (_m *VirtualMachine) CreateQEMU(opts qemu.CreateOptions) (task.Task, error)
 	return r0, r1
 }
 
-// DeleteLXC provides a mock function with given fields: vmid, purge, force
-func (_m *VirtualMachine) DeleteLXC(vmid uint, purge bool, force bool) (task.Task, error) {
-	ret := _m.Called(vmid, purge, force)
+// CreateQEMUFromCloudImage provides a mock function with given fields: opts
+func (_m *VirtualMachine) CreateQEMUFromCloudImage(opts qemu.CloudImageOptions) ([]task.Task, error) {
+	ret := _m.Called(opts)
+
+	var r0 []task.Task
+	if rf, ok := ret.Get(0).(func(qemu.CloudImageOptions) []task.Task); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]task.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(qemu.CloudImageOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLXC provides a mock function with given fields: vmid, opts
+func (_m *VirtualMachine) DeleteLXC(vmid uint, opts vm.DeleteOptions) (task.Task, error) {
+	ret := _m.Called(vmid, opts)
 
 	var r0 task.Task
-	if rf, ok := ret.Get(0).(func(uint, bool, bool) task.Task); ok {
-		r0 = rf(vmid, purge, force)
+	if rf, ok := ret.Get(0).(func(uint, vm.DeleteOptions) task.Task); ok {
+		r0 = rf(vmid, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(task.Task)
@@ -78,8 +151,8 @@ func (_m *VirtualMachine) DeleteLXC(vmid uint, purge bool, force bool) (task.Tas
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, bool, bool) error); ok {
-		r1 = rf(vmid, purge, force)
+	if rf, ok := ret.Get(1).(func(uint, vm.DeleteOptions) error); ok {
+		r1 = rf(vmid, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -87,13 +160,13 @@ func (_m *VirtualMachine) DeleteLXC(vmid uint, purge bool, force bool) (task.Tas
 	return r0, r1
 }
 
-// DeleteQEMU provides a mock function with given fields: vmid, purge, force
-func (_m *VirtualMachine) DeleteQEMU(vmid uint, purge bool, force bool) (task.Task, error) {
-	ret := _m.Called(vmid, purge, force)
+// DeleteQEMU provides a mock function with given fields: vmid, opts
+func (_m *VirtualMachine) DeleteQEMU(vmid uint, opts vm.DeleteOptions) (task.Task, error) {
+	ret := _m.Called(vmid, opts)
 
 	var r0 task.Task
-	if rf, ok := ret.Get(0).(func(uint, bool, bool) task.Task); ok {
-		r0 = rf(vmid, purge, force)
+	if rf, ok := ret.Get(0).(func(uint, vm.DeleteOptions) task.Task); ok {
+		r0 = rf(vmid, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(task.Task)
@@ -101,8 +174,29 @@ func (_m *VirtualMachine) DeleteQEMU(vmid uint, purge bool, force bool) (task.Ta
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, bool, bool) error); ok {
-		r1 = rf(vmid, purge, force)
+	if rf, ok := ret.Get(1).(func(uint, vm.DeleteOptions) error); ok {
+		r1 = rf(vmid, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Export provides a mock function with given fields: vmid
+func (_m *VirtualMachine) Export(vmid uint) (export.Document, error) {
+	ret := _m.Called(vmid)
+
+	var r0 export.Document
+	if rf, ok := ret.Get(0).(func(uint) export.Document); ok {
+		r0 = rf(vmid)
+	} else {
+		r0 = ret.Get(0).(export.Document)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(vmid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -133,6 +227,79 @@ func (_m *VirtualMachine) Get(vmid uint) (vm.VirtualMachine, error) {
 	return r0, r1
 }
 
+// GetLinkedCloneGraph provides a mock function with given fields:
+func (_m *VirtualMachine) GetLinkedCloneGraph() (vm.LinkedCloneGraph, error) {
+	ret := _m.Called()
+
+	var r0 vm.LinkedCloneGraph
+	if rf, ok := ret.Get(0).(func() vm.LinkedCloneGraph); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(vm.LinkedCloneGraph)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Hydrate provides a mock function with given fields: resources
+func (_m *VirtualMachine) Hydrate(resources ...vm.Resource) ([]vm.VirtualMachine, error) {
+	_va := make([]interface{}, len(resources))
+	for _i := range resources {
+		_va[_i] = resources[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []vm.VirtualMachine
+	if rf, ok := ret.Get(0).(func(...vm.Resource) []vm.VirtualMachine); ok {
+		r0 = rf(resources...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vm.VirtualMachine)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(...vm.Resource) error); ok {
+		r1 = rf(resources...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: doc, opts
+func (_m *VirtualMachine) Import(doc export.Document, opts export.ImportOptions) ([]task.Task, error) {
+	ret := _m.Called(doc, opts)
+
+	var r0 []task.Task
+	if rf, ok := ret.Get(0).(func(export.Document, export.ImportOptions) []task.Task); ok {
+		r0 = rf(doc, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]task.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(export.Document, export.ImportOptions) error); ok {
+		r1 = rf(doc, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields:
 func (_m *VirtualMachine) List() ([]vm.VirtualMachine, error) {
 	ret := _m.Called()
@@ -178,3 +345,162 @@ func (_m *VirtualMachine) ListByKind(kind vm.Kind) ([]vm.VirtualMachine, error)
 
 	return r0, r1
 }
+
+// ListByTags provides a mock function with given fields: filter
+func (_m *VirtualMachine) ListByTags(filter vm.TagFilter) ([]vm.VirtualMachine, error) {
+	ret := _m.Called(filter)
+
+	var r0 []vm.VirtualMachine
+	if rf, ok := ret.Get(0).(func(vm.TagFilter) []vm.VirtualMachine); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vm.VirtualMachine)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vm.TagFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListResources provides a mock function with given fields:
+func (_m *VirtualMachine) ListResources() ([]vm.Resource, error) {
+	ret := _m.Called()
+
+	var r0 []vm.Resource
+	if rf, ok := ret.Get(0).(func() []vm.Resource); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vm.Resource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Plan provides a mock function with given fields: s
+func (_m *VirtualMachine) Plan(s spec.Spec) (spec.Plan, error) {
+	ret := _m.Called(s)
+
+	var r0 spec.Plan
+	if rf, ok := ret.Get(0).(func(spec.Spec) spec.Plan); ok {
+		r0 = rf(s)
+	} else {
+		r0 = ret.Get(0).(spec.Plan)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(spec.Spec) error); ok {
+		r1 = rf(s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryResources provides a mock function with given fields: query
+func (_m *VirtualMachine) QueryResources(query *vm.ResourceQuery) ([]vm.Resource, error) {
+	ret := _m.Called(query)
+
+	var r0 []vm.Resource
+	if rf, ok := ret.Get(0).(func(*vm.ResourceQuery) []vm.Resource); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vm.Resource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*vm.ResourceQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreLXC provides a mock function with given fields: opts
+func (_m *VirtualMachine) RestoreLXC(opts vm.RestoreOptions) (task.Task, error) {
+	ret := _m.Called(opts)
+
+	var r0 task.Task
+	if rf, ok := ret.Get(0).(func(vm.RestoreOptions) task.Task); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(task.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vm.RestoreOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreQEMU provides a mock function with given fields: opts
+func (_m *VirtualMachine) RestoreQEMU(opts vm.RestoreOptions) (task.Task, error) {
+	ret := _m.Called(opts)
+
+	var r0 task.Task
+	if rf, ok := ret.Get(0).(func(vm.RestoreOptions) task.Task); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(task.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(vm.RestoreOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunBulk provides a mock function with given fields: guests, action, opts
+func (_m *VirtualMachine) RunBulk(guests []vm.VirtualMachine, action vm.PowerAction, opts vm.BulkOptions) ([]vm.BulkResult, error) {
+	ret := _m.Called(guests, action, opts)
+
+	var r0 []vm.BulkResult
+	if rf, ok := ret.Get(0).(func([]vm.VirtualMachine, vm.PowerAction, vm.BulkOptions) []vm.BulkResult); ok {
+		r0 = rf(guests, action, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]vm.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]vm.VirtualMachine, vm.PowerAction, vm.BulkOptions) error); ok {
+		r1 = rf(guests, action, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
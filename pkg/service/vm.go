@@ -3,8 +3,10 @@ package service
 import (
 	"github.com/xabinapal/gopve/pkg/types/task"
 	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/export"
 	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
 	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/pkg/types/vm/spec"
 )
 
 //go:generate mockery --case snake --name VirtualMachine --filename vm.go
@@ -12,11 +14,46 @@ import (
 type VirtualMachine interface {
 	List() ([]vm.VirtualMachine, error)
 	ListByKind(kind vm.Kind) ([]vm.VirtualMachine, error)
+	ListByTags(filter vm.TagFilter) ([]vm.VirtualMachine, error)
+
+	ListResources() ([]vm.Resource, error)
+	QueryResources(query *vm.ResourceQuery) ([]vm.Resource, error)
+	Hydrate(resources ...vm.Resource) ([]vm.VirtualMachine, error)
 	Get(vmid uint) (vm.VirtualMachine, error)
 
 	CreateQEMU(opts qemu.CreateOptions) (task.Task, error)
-	CreateLXC(opts lxc.CreateOptions) (task.Task, error)
+	CreateLXC(opts lxc.CreateOptions) (task.VirtualMachineTask, error)
+
+	CreateQEMUFromCloudImage(opts qemu.CloudImageOptions) ([]task.Task, error)
+
+	RestoreQEMU(opts vm.RestoreOptions) (task.Task, error)
+	RestoreLXC(opts vm.RestoreOptions) (task.Task, error)
+
+	// DeleteQEMU and DeleteLXC refuse to delete templates that still have
+	// linked clones, or whose clones can't be checked because some guest
+	// configuration is unreadable, unless IgnoreLinkedClones is set.
+	DeleteQEMU(vmid uint, opts vm.DeleteOptions) (task.Task, error)
+	DeleteLXC(vmid uint, opts vm.DeleteOptions) (task.Task, error)
+
+	GetLinkedCloneGraph() (vm.LinkedCloneGraph, error)
+
+	Backup(
+		node string,
+		selection vm.BackupSelection,
+		opts vm.BackupOptions,
+	) (task.Task, error)
+
+	RunBulk(
+		guests []vm.VirtualMachine,
+		action vm.PowerAction,
+		opts vm.BulkOptions,
+	) ([]vm.BulkResult, error)
+
+	// Plan diffs the spec against the live guest, and Apply executes the
+	// resulting change set.
+	Plan(s spec.Spec) (spec.Plan, error)
+	Apply(plan spec.Plan, opts spec.ApplyOptions) ([]task.Task, error)
 
-	DeleteQEMU(vmid uint, purge bool, force bool) (task.Task, error)
-	DeleteLXC(vmid uint, purge bool, force bool) (task.Task, error)
+	Export(vmid uint) (export.Document, error)
+	Import(doc export.Document, opts export.ImportOptions) ([]task.Task, error)
 }
@@ -0,0 +1,85 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/task"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type BackupJob interface {
+	ID() string
+
+	GetProperties() (BackupJobProperties, error)
+	SetProperties(props BackupJobProperties) error
+
+	// Run starts the job immediately on every node it applies to, and
+	// returns one vzdump task per node.
+	Run() ([]task.Task, error)
+
+	Delete() error
+}
+
+type BackupJobProperties struct {
+	Enabled  bool
+	Schedule string
+	Node     string
+	Comment  string
+
+	Selection vm.BackupSelection
+	Options   vm.BackupOptions
+}
+
+func (obj BackupJobProperties) MapToValues() (request.Values, error) {
+	if obj.Schedule == "" {
+		return nil, fmt.Errorf("backup job schedule is required")
+	}
+
+	values, err := obj.Selection.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := obj.Options.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range options {
+		values[k] = v
+	}
+
+	values.AddBool("enabled", obj.Enabled)
+	values.AddString("schedule", obj.Schedule)
+	values.ConditionalAddString("node", obj.Node, obj.Node != "")
+	values.ConditionalAddString("comment", obj.Comment, obj.Comment != "")
+
+	return values, nil
+}
+
+// MapToUpdateValues serializes the properties like MapToValues, deleting
+// the ones that were set in previous and aren't anymore.
+func (obj BackupJobProperties) MapToUpdateValues(
+	previous BackupJobProperties,
+) (request.Values, error) {
+	values, err := obj.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	previousValues, err := previous.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	values.AddDeleted(previousValues)
+
+	return values, nil
+}
+
+type NotBackedUpGuest struct {
+	VMID uint
+	Name string
+	Kind vm.Kind
+}
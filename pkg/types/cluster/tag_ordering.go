@@ -0,0 +1,43 @@
+package cluster
+
+import (
+	"encoding/json"
+)
+
+type TagOrdering string
+
+const (
+	TagOrderingConfig       TagOrdering = "config"
+	TagOrderingAlphabetical TagOrdering = "alphabetical"
+)
+
+func (obj TagOrdering) IsValid() bool {
+	switch obj {
+	case TagOrderingConfig, TagOrderingAlphabetical:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj TagOrdering) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj TagOrdering) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *TagOrdering) Unmarshal(s string) error {
+	*obj = TagOrdering(s)
+	return nil
+}
+
+func (obj *TagOrdering) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
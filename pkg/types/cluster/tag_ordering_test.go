@@ -0,0 +1,29 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/cluster"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestTagOrdering(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*cluster.TagOrdering)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Config": {
+				Object: cluster.TagOrderingConfig,
+				Value:  "config",
+			},
+			"Alphabetical": {
+				Object: cluster.TagOrderingAlphabetical,
+				Value:  "alphabetical",
+			},
+		},
+	)
+}
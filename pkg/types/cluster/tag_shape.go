@@ -0,0 +1,45 @@
+package cluster
+
+import (
+	"encoding/json"
+)
+
+type TagShape string
+
+const (
+	TagShapeCircle TagShape = "circle"
+	TagShapeDense  TagShape = "dense"
+	TagShapeFull   TagShape = "full"
+	TagShapeNone   TagShape = "none"
+)
+
+func (obj TagShape) IsValid() bool {
+	switch obj {
+	case TagShapeCircle, TagShapeDense, TagShapeFull, TagShapeNone:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj TagShape) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj TagShape) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *TagShape) Unmarshal(s string) error {
+	*obj = TagShape(s)
+	return nil
+}
+
+func (obj *TagShape) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
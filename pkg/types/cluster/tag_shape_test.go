@@ -0,0 +1,37 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/cluster"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestTagShape(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*cluster.TagShape)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Circle": {
+				Object: cluster.TagShapeCircle,
+				Value:  "circle",
+			},
+			"Dense": {
+				Object: cluster.TagShapeDense,
+				Value:  "dense",
+			},
+			"Full": {
+				Object: cluster.TagShapeFull,
+				Value:  "full",
+			},
+			"None": {
+				Object: cluster.TagShapeNone,
+				Value:  "none",
+			},
+		},
+	)
+}
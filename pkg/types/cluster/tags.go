@@ -0,0 +1,145 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+)
+
+// TagColor holds the hexadecimal background and, optionally, text colors of
+// a tag, without the leading #.
+type TagColor struct {
+	Background string
+	Text       string
+}
+
+type TagStyle struct {
+	CaseSensitive bool
+	ColorMap      map[string]TagColor
+
+	Ordering TagOrdering
+	Shape    TagShape
+}
+
+const (
+	mkTagStylePropertyCaseSensitive = "case-sensitive"
+	mkTagStylePropertyColorMap      = "color-map"
+	mkTagStylePropertyOrdering      = "ordering"
+	mkTagStylePropertyShape         = "shape"
+)
+
+func (obj TagStyle) IsZero() bool {
+	return !obj.CaseSensitive && len(obj.ColorMap) == 0 &&
+		obj.Ordering == "" && obj.Shape == ""
+}
+
+func (obj TagStyle) Marshal() (string, error) {
+	content := internal_types.PVEList{Separator: ","}
+
+	if obj.CaseSensitive {
+		content.Append(fmt.Sprintf("%s=1", mkTagStylePropertyCaseSensitive))
+	}
+
+	if len(obj.ColorMap) != 0 {
+		tags := make([]string, 0, len(obj.ColorMap))
+		for tag := range obj.ColorMap {
+			tags = append(tags, tag)
+		}
+
+		sort.Strings(tags)
+
+		colors := make([]string, len(tags))
+
+		for i, tag := range tags {
+			color := obj.ColorMap[tag]
+			if color.Background == "" {
+				return "", fmt.Errorf("tag %s has no background color", tag)
+			}
+
+			colors[i] = fmt.Sprintf("%s:%s", tag, color.Background)
+			if color.Text != "" {
+				colors[i] += ":" + color.Text
+			}
+		}
+
+		content.Append(fmt.Sprintf(
+			"%s=%s",
+			mkTagStylePropertyColorMap,
+			strings.Join(colors, ";"),
+		))
+	}
+
+	if obj.Ordering != "" {
+		ordering, err := obj.Ordering.Marshal()
+		if err != nil {
+			return "", err
+		}
+
+		content.Append(fmt.Sprintf("%s=%s", mkTagStylePropertyOrdering, ordering))
+	}
+
+	if obj.Shape != "" {
+		shape, err := obj.Shape.Marshal()
+		if err != nil {
+			return "", err
+		}
+
+		content.Append(fmt.Sprintf("%s=%s", mkTagStylePropertyShape, shape))
+	}
+
+	return content.Marshal()
+}
+
+func (obj *TagStyle) Unmarshal(s string) error {
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+	}
+
+	if err := (&props).Unmarshal(s); err != nil {
+		return err
+	}
+
+	var style TagStyle
+
+	for _, kv := range props.List() {
+		var err error
+
+		switch kv.Key() {
+		case mkTagStylePropertyCaseSensitive:
+			style.CaseSensitive, err = kv.ValueAsBool()
+		case mkTagStylePropertyColorMap:
+			style.ColorMap = map[string]TagColor{}
+
+			for _, entry := range strings.Split(kv.Value(), ";") {
+				parts := strings.Split(entry, ":")
+				if len(parts) < 2 || len(parts) > 3 {
+					return fmt.Errorf("invalid tag color %s", entry)
+				}
+
+				color := TagColor{Background: parts[1]}
+				if len(parts) == 3 {
+					color.Text = parts[2]
+				}
+
+				style.ColorMap[parts[0]] = color
+			}
+		case mkTagStylePropertyOrdering:
+			err = (&style.Ordering).Unmarshal(kv.Value())
+		case mkTagStylePropertyShape:
+			err = (&style.Shape).Unmarshal(kv.Value())
+		default:
+			err = fmt.Errorf("unknown tag style property %s", kv.Key())
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	*obj = style
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package console
+
+type VNCProxyOptions struct {
+	Websocket        bool
+	GeneratePassword bool
+}
+
+type VNCTicket struct {
+	User   string
+	Ticket string
+	Port   uint
+
+	Certificate string
+	Password    string
+
+	UPID string
+}
+
+type TermProxyOptions struct {
+	Serial  string
+	Command string
+}
+
+type TermTicket struct {
+	User   string
+	Ticket string
+	Port   uint
+
+	UPID string
+}
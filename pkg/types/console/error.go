@@ -0,0 +1,9 @@
+package console
+
+import "github.com/xabinapal/gopve/pkg/types/errors"
+
+const (
+	ErrTerminalAuthentication = errors.ClientError(
+		"401 - terminal authentication failed!",
+	)
+)
@@ -0,0 +1,61 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+)
+
+type SPICEProxyOptions struct {
+	Proxy string
+}
+
+type SPICETicket struct {
+	Type     string
+	Host     string
+	Proxy    string
+	Password string
+	TLSPort  uint
+
+	HostSubject string
+	CA          string
+
+	Title            string
+	ToggleFullscreen string
+	ReleaseCursor    string
+	SecureAttention  string
+
+	DeleteThisFile bool
+}
+
+func (obj SPICETicket) VirtViewerFile() string {
+	var sb strings.Builder
+
+	sb.WriteString("[virt-viewer]\n")
+
+	for _, kv := range []struct {
+		Key   string
+		Value string
+	}{
+		{"type", obj.Type},
+		{"host", obj.Host},
+		{"proxy", obj.Proxy},
+		{"password", obj.Password},
+		{"tls-port", fmt.Sprintf("%d", obj.TLSPort)},
+		{"host-subject", obj.HostSubject},
+		{"ca", obj.CA},
+		{"title", obj.Title},
+		{"toggle-fullscreen", obj.ToggleFullscreen},
+		{"release-cursor", obj.ReleaseCursor},
+		{"secure-attention", obj.SecureAttention},
+	} {
+		if kv.Value != "" {
+			fmt.Fprintf(&sb, "%s=%s\n", kv.Key, kv.Value)
+		}
+	}
+
+	if obj.DeleteThisFile {
+		sb.WriteString("delete-this-file=1\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package console_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xabinapal/gopve/pkg/types/console"
+)
+
+func TestSPICETicketVirtViewerFile(t *testing.T) {
+	ticket := console.SPICETicket{
+		Type:     "spice",
+		Host:     "pvespiceproxy:00000000:100:test_node::0000",
+		Proxy:    "http://test_node:3128",
+		Password: "test_password",
+		TLSPort:  61000,
+
+		HostSubject: "OU=PVE Cluster Node,O=Proxmox Virtual Environment,CN=test_node",
+		CA:          `-----BEGIN CERTIFICATE-----\nTEST\n-----END CERTIFICATE-----\n`,
+
+		Title:            "VM 100 - test_name",
+		ToggleFullscreen: "Shift+F11",
+		ReleaseCursor:    "Ctrl+Alt+R",
+		SecureAttention:  "Ctrl+Alt+Ins",
+
+		DeleteThisFile: true,
+	}
+
+	expectedFile := `[virt-viewer]
+type=spice
+host=pvespiceproxy:00000000:100:test_node::0000
+proxy=http://test_node:3128
+password=test_password
+tls-port=61000
+host-subject=OU=PVE Cluster Node,O=Proxmox Virtual Environment,CN=test_node
+ca=-----BEGIN CERTIFICATE-----\nTEST\n-----END CERTIFICATE-----\n
+title=VM 100 - test_name
+toggle-fullscreen=Shift+F11
+release-cursor=Ctrl+Alt+R
+secure-attention=Ctrl+Alt+Ins
+delete-this-file=1
+`
+
+	assert.Equal(t, expectedFile, ticket.VirtViewerFile())
+}
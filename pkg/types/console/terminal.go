@@ -0,0 +1,59 @@
+package console
+
+import (
+	"fmt"
+	"io"
+)
+
+type Terminal struct {
+	conn io.ReadWriteCloser
+}
+
+func NewTerminal(
+	conn io.ReadWriteCloser,
+	user, ticket string,
+) (*Terminal, error) {
+	if _, err := fmt.Fprintf(conn, "%s:%s\n", user, ticket); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	res := make([]byte, 2)
+	if _, err := io.ReadFull(conn, res); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if string(res) != "OK" {
+		conn.Close()
+		return nil, ErrTerminalAuthentication
+	}
+
+	return &Terminal{conn: conn}, nil
+}
+
+func (obj *Terminal) Read(p []byte) (int, error) {
+	return obj.conn.Read(p)
+}
+
+func (obj *Terminal) Write(p []byte) (int, error) {
+	if _, err := fmt.Fprintf(obj.conn, "0:%d:%s", len(p), p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (obj *Terminal) Resize(columns, rows uint) error {
+	_, err := fmt.Fprintf(obj.conn, "1:%d:%d:", columns, rows)
+	return err
+}
+
+func (obj *Terminal) Ping() error {
+	_, err := io.WriteString(obj.conn, "2")
+	return err
+}
+
+func (obj *Terminal) Close() error {
+	return obj.conn.Close()
+}
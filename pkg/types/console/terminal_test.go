@@ -0,0 +1,83 @@
+package console_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/console"
+)
+
+func TestTerminal(t *testing.T) {
+	t.Run("Session", func(t *testing.T) {
+		client, server := net.Pipe()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer server.Close()
+
+			r := bufio.NewReader(server)
+
+			login, err := r.ReadString('\n')
+			assert.NoError(t, err)
+			assert.Equal(t, "root@pam:PVEVNC:TEST\n", login)
+
+			_, err = server.Write([]byte("OK"))
+			assert.NoError(t, err)
+
+			msg := make([]byte, len("0:6:ls -l\n"))
+			_, err = io.ReadFull(r, msg)
+			assert.NoError(t, err)
+			assert.Equal(t, "0:6:ls -l\n", string(msg))
+
+			_, err = server.Write([]byte("total 0\n"))
+			assert.NoError(t, err)
+
+			msg = make([]byte, len("1:80:24:"))
+			_, err = io.ReadFull(r, msg)
+			assert.NoError(t, err)
+			assert.Equal(t, "1:80:24:", string(msg))
+
+			msg = make([]byte, 1)
+			_, err = io.ReadFull(r, msg)
+			assert.NoError(t, err)
+			assert.Equal(t, "2", string(msg))
+		}()
+
+		terminal, err := console.NewTerminal(client, "root@pam", "PVEVNC:TEST")
+		require.NoError(t, err)
+
+		n, err := terminal.Write([]byte("ls -l\n"))
+		require.NoError(t, err)
+		assert.Equal(t, 6, n)
+
+		output := make([]byte, len("total 0\n"))
+		_, err = io.ReadFull(terminal, output)
+		require.NoError(t, err)
+		assert.Equal(t, "total 0\n", string(output))
+
+		require.NoError(t, terminal.Resize(80, 24))
+		require.NoError(t, terminal.Ping())
+
+		<-done
+		require.NoError(t, terminal.Close())
+	})
+
+	t.Run("AuthenticationFailed", func(t *testing.T) {
+		client, server := net.Pipe()
+
+		go func() {
+			defer server.Close()
+
+			_, _ = bufio.NewReader(server).ReadString('\n')
+			_, _ = server.Write([]byte("NO"))
+		}()
+
+		_, err := console.NewTerminal(client, "root@pam", "PVEVNC:TEST")
+		assert.Equal(t, console.ErrTerminalAuthentication, err)
+	})
+}
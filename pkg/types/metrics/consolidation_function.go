@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"encoding/json"
+)
+
+type ConsolidationFunction string
+
+const (
+	ConsolidationFunctionAverage ConsolidationFunction = "AVERAGE"
+	ConsolidationFunctionMax     ConsolidationFunction = "MAX"
+)
+
+func (obj ConsolidationFunction) IsValid() bool {
+	switch obj {
+	case ConsolidationFunctionAverage, ConsolidationFunctionMax:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj ConsolidationFunction) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj ConsolidationFunction) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *ConsolidationFunction) Unmarshal(s string) error {
+	*obj = ConsolidationFunction(s)
+	return nil
+}
+
+func (obj *ConsolidationFunction) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,29 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/metrics"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestConsolidationFunction(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*metrics.ConsolidationFunction)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Average": {
+				Object: metrics.ConsolidationFunctionAverage,
+				Value:  "AVERAGE",
+			},
+			"Max": {
+				Object: metrics.ConsolidationFunctionMax,
+				Value:  "MAX",
+			},
+		},
+	)
+}
@@ -0,0 +1,200 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xabinapal/gopve/pkg/request"
+)
+
+type Options struct {
+	Timeframe             Timeframe
+	ConsolidationFunction ConsolidationFunction
+}
+
+func (obj Options) MapToValues() (request.Values, error) {
+	if !obj.Timeframe.IsValid() {
+		return nil, fmt.Errorf("invalid metrics timeframe %s", obj.Timeframe)
+	}
+
+	values := request.Values{}
+
+	if err := values.AddObject("timeframe", obj.Timeframe); err != nil {
+		return nil, err
+	}
+
+	if obj.ConsolidationFunction != "" {
+		if !obj.ConsolidationFunction.IsValid() {
+			return nil, fmt.Errorf(
+				"invalid metrics consolidation function %s",
+				obj.ConsolidationFunction,
+			)
+		}
+
+		if err := values.AddObject("cf", obj.ConsolidationFunction); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+type Unit string
+
+const (
+	UnitRatio          Unit = "ratio"
+	UnitCount          Unit = "count"
+	UnitBytes          Unit = "bytes"
+	UnitBytesPerSecond Unit = "bytes/s"
+)
+
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+type Series struct {
+	Unit   Unit
+	Points []Point
+}
+
+func (obj Series) Last() (Point, bool) {
+	if len(obj.Points) == 0 {
+		return Point{}, false
+	}
+
+	return obj.Points[len(obj.Points)-1], true
+}
+
+func (obj Series) Average() float64 {
+	if len(obj.Points) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, point := range obj.Points {
+		sum += point.Value
+	}
+
+	return sum / float64(len(obj.Points))
+}
+
+func (obj Series) Max() float64 {
+	var max float64
+
+	for i, point := range obj.Points {
+		if i == 0 || point.Value > max {
+			max = point.Value
+		}
+	}
+
+	return max
+}
+
+// Sample is a single rrddata row as returned by the API. Values that have
+// no data for the sampled interval are omitted.
+type Sample map[string]float64
+
+func newSeries(samples []Sample, key string, unit Unit) Series {
+	series := Series{Unit: unit}
+
+	for _, sample := range samples {
+		t, ok := sample["time"]
+		if !ok {
+			continue
+		}
+
+		if v, ok := sample[key]; ok {
+			series.Points = append(series.Points, Point{
+				Time:  time.Unix(int64(t), 0).UTC(),
+				Value: v,
+			})
+		}
+	}
+
+	return series
+}
+
+type GuestMetrics struct {
+	CPU  Series
+	CPUs Series
+
+	Memory      Series
+	MemoryTotal Series
+
+	Disk      Series
+	DiskTotal Series
+	DiskRead  Series
+	DiskWrite Series
+
+	NetIn  Series
+	NetOut Series
+}
+
+func NewGuestMetrics(samples []Sample) GuestMetrics {
+	return GuestMetrics{
+		CPU:  newSeries(samples, "cpu", UnitRatio),
+		CPUs: newSeries(samples, "maxcpu", UnitCount),
+
+		Memory:      newSeries(samples, "mem", UnitBytes),
+		MemoryTotal: newSeries(samples, "maxmem", UnitBytes),
+
+		Disk:      newSeries(samples, "disk", UnitBytes),
+		DiskTotal: newSeries(samples, "maxdisk", UnitBytes),
+		DiskRead:  newSeries(samples, "diskread", UnitBytesPerSecond),
+		DiskWrite: newSeries(samples, "diskwrite", UnitBytesPerSecond),
+
+		NetIn:  newSeries(samples, "netin", UnitBytesPerSecond),
+		NetOut: newSeries(samples, "netout", UnitBytesPerSecond),
+	}
+}
+
+type NodeMetrics struct {
+	CPU         Series
+	CPUs        Series
+	IOWait      Series
+	LoadAverage Series
+
+	Memory      Series
+	MemoryTotal Series
+	Swap        Series
+	SwapTotal   Series
+
+	RootDisk      Series
+	RootDiskTotal Series
+
+	NetIn  Series
+	NetOut Series
+}
+
+func NewNodeMetrics(samples []Sample) NodeMetrics {
+	return NodeMetrics{
+		CPU:         newSeries(samples, "cpu", UnitRatio),
+		CPUs:        newSeries(samples, "maxcpu", UnitCount),
+		IOWait:      newSeries(samples, "iowait", UnitRatio),
+		LoadAverage: newSeries(samples, "loadavg", UnitCount),
+
+		Memory:      newSeries(samples, "memused", UnitBytes),
+		MemoryTotal: newSeries(samples, "memtotal", UnitBytes),
+		Swap:        newSeries(samples, "swapused", UnitBytes),
+		SwapTotal:   newSeries(samples, "swaptotal", UnitBytes),
+
+		RootDisk:      newSeries(samples, "rootused", UnitBytes),
+		RootDiskTotal: newSeries(samples, "roottotal", UnitBytes),
+
+		NetIn:  newSeries(samples, "netin", UnitBytesPerSecond),
+		NetOut: newSeries(samples, "netout", UnitBytesPerSecond),
+	}
+}
+
+type StorageMetrics struct {
+	Used  Series
+	Total Series
+}
+
+func NewStorageMetrics(samples []Sample) StorageMetrics {
+	return StorageMetrics{
+		Used:  newSeries(samples, "used", UnitBytes),
+		Total: newSeries(samples, "total", UnitBytes),
+	}
+}
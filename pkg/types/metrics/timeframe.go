@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"encoding/json"
+)
+
+type Timeframe string
+
+const (
+	TimeframeHour  Timeframe = "hour"
+	TimeframeDay   Timeframe = "day"
+	TimeframeWeek  Timeframe = "week"
+	TimeframeMonth Timeframe = "month"
+	TimeframeYear  Timeframe = "year"
+)
+
+func (obj Timeframe) IsValid() bool {
+	switch obj {
+	case TimeframeHour,
+		TimeframeDay,
+		TimeframeWeek,
+		TimeframeMonth,
+		TimeframeYear:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj Timeframe) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj Timeframe) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *Timeframe) Unmarshal(s string) error {
+	*obj = Timeframe(s)
+	return nil
+}
+
+func (obj *Timeframe) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,41 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/metrics"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestTimeframe(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*metrics.Timeframe)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Hour": {
+				Object: metrics.TimeframeHour,
+				Value:  "hour",
+			},
+			"Day": {
+				Object: metrics.TimeframeDay,
+				Value:  "day",
+			},
+			"Week": {
+				Object: metrics.TimeframeWeek,
+				Value:  "week",
+			},
+			"Month": {
+				Object: metrics.TimeframeMonth,
+				Value:  "month",
+			},
+			"Year": {
+				Object: metrics.TimeframeYear,
+				Value:  "year",
+			},
+		},
+	)
+}
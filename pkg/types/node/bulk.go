@@ -0,0 +1,90 @@
+package node
+
+import (
+	"fmt"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+)
+
+type StartAllOptions struct {
+	// VMIDs restricts the action to the given guests. When empty, every
+	// guest with onboot set is started.
+	VMIDs []uint
+
+	// Force also starts guests without onboot set.
+	Force bool
+}
+
+func (obj StartAllOptions) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	values.ConditionalAddString("vms", joinVMIDs(obj.VMIDs), len(obj.VMIDs) != 0)
+	values.ConditionalAddBool("force", obj.Force, obj.Force)
+
+	return values, nil
+}
+
+type StopAllOptions struct {
+	VMIDs []uint
+
+	// Timeout is the number of seconds to wait for each guest to shut down.
+	Timeout uint
+}
+
+func (obj StopAllOptions) mapToValues(force bool) (request.Values, error) {
+	values := request.Values{}
+
+	values.AddBool("force-stop", force)
+	values.ConditionalAddString("vms", joinVMIDs(obj.VMIDs), len(obj.VMIDs) != 0)
+	values.ConditionalAddUint("timeout", obj.Timeout, obj.Timeout != 0)
+
+	return values, nil
+}
+
+// MapToValues returns the values for stopall forcing guests that do not shut
+// down in time to stop.
+func (obj StopAllOptions) MapToValues() (request.Values, error) {
+	return obj.mapToValues(true)
+}
+
+// MapToShutdownValues returns the values for stopall without forcing guests
+// to stop after the timeout.
+func (obj StopAllOptions) MapToShutdownValues() (request.Values, error) {
+	return obj.mapToValues(false)
+}
+
+type MigrateAllOptions struct {
+	VMIDs []uint
+
+	MaxWorkers     uint
+	WithLocalDisks bool
+}
+
+func (obj MigrateAllOptions) MapToValues(
+	target string,
+) (request.Values, error) {
+	if target == "" {
+		return nil, fmt.Errorf("target node is required")
+	}
+
+	values := request.Values{}
+
+	values.AddString("target", target)
+	values.ConditionalAddString("vms", joinVMIDs(obj.VMIDs), len(obj.VMIDs) != 0)
+	values.ConditionalAddUint("maxworkers", obj.MaxWorkers, obj.MaxWorkers != 0)
+	values.ConditionalAddBool("with-local-disks", obj.WithLocalDisks, obj.WithLocalDisks)
+
+	return values, nil
+}
+
+func joinVMIDs(vmids []uint) string {
+	list := internal_types.PVEList{Separator: ","}
+
+	for _, vmid := range vmids {
+		list.Append(fmt.Sprintf("%d", vmid))
+	}
+
+	s, _ := list.Marshal()
+	return s
+}
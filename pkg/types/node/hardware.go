@@ -0,0 +1,94 @@
+package node
+
+import "fmt"
+
+// PCIDevice is a host PCI function. IOMMUGroup is -1 when IOMMU is not
+// enabled on the node.
+type PCIDevice struct {
+	ID    string
+	Class string
+
+	VendorID   string
+	VendorName string
+	DeviceID   string
+	DeviceName string
+
+	SubsystemVendorID   string
+	SubsystemVendorName string
+	SubsystemDeviceID   string
+	SubsystemDeviceName string
+
+	IOMMUGroup int
+
+	SupportsMediatedDevices bool
+}
+
+// IOMMUGroup lists the functions that can only be passed through together.
+type IOMMUGroup struct {
+	ID      int
+	Devices []PCIDevice
+}
+
+type MediatedDeviceType struct {
+	Name        string
+	Description string
+	Available   uint
+}
+
+type USBDevice struct {
+	BusNumber    uint
+	DeviceNumber uint
+	Port         uint
+	Level        uint
+	Path         string
+
+	Class     uint
+	VendorID  string
+	ProductID string
+
+	Manufacturer string
+	Product      string
+	Serial       string
+	Speed        string
+}
+
+// HostID returns the vendor:product id used to pass the device to a guest.
+func (obj USBDevice) HostID() string {
+	return fmt.Sprintf("%s:%s", obj.VendorID, obj.ProductID)
+}
+
+// HostPath returns the bus-port path used to pass whatever device is
+// plugged in the same port to a guest.
+func (obj USBDevice) HostPath() string {
+	if obj.Path != "" {
+		return fmt.Sprintf("%d-%s", obj.BusNumber, obj.Path)
+	}
+
+	return fmt.Sprintf("%d-%d", obj.BusNumber, obj.Port)
+}
+
+// CPUInfo is the processor summary of the node. PVE doesn't expose the host
+// NUMA topology, but on most hosts each socket is a NUMA node, so Sockets
+// gives the range of host nodes to bind guest NUMA nodes to.
+type CPUInfo struct {
+	Model string
+	MHz   float64
+
+	Sockets uint
+	// Cores is the number of physical cores across every socket, and CPUs
+	// the number of logical processors.
+	Cores uint
+	CPUs  uint
+
+	HVM   bool
+	Flags []string
+}
+
+// CoresPerSocket returns the physical cores in a single socket.
+func (obj CPUInfo) CoresPerSocket() uint {
+	if obj.Sockets == 0 {
+		return obj.Cores
+	}
+
+	return obj.Cores / obj.Sockets
+}
@@ -3,7 +3,9 @@ package node
 import (
 	"time"
 
+	"github.com/xabinapal/gopve/pkg/types/console"
 	"github.com/xabinapal/gopve/pkg/types/firewall"
+	"github.com/xabinapal/gopve/pkg/types/metrics"
 	"github.com/xabinapal/gopve/pkg/types/task"
 )
 
@@ -15,13 +17,32 @@ type Node interface {
 	Reboot() error
 	WakeOnLAN() (task.Task, error)
 
+	StartAll(opts StartAllOptions) (task.Task, error)
+	StopAll(opts StopAllOptions) (task.Task, error)
+	ShutdownAll(opts StopAllOptions) (task.Task, error)
+	MigrateAll(target string, opts MigrateAllOptions) (task.Task, error)
+
+	VNCProxy(opts console.VNCProxyOptions) (console.VNCTicket, error)
+	SPICEProxy(opts console.SPICEProxyOptions) (console.SPICETicket, error)
+	TermProxy(opts console.TermProxyOptions) (console.TermTicket, error)
+	OpenTerminal(opts console.TermProxyOptions) (*console.Terminal, error)
+
 	GetSyslog(opts GetSyslogOptions) (SyslogEntries, error)
+	GetMetrics(opts metrics.Options) (metrics.NodeMetrics, error)
 
 	GetDNSSettings() (DNSSettings, error)
 	SetDNSSettings(settings DNSSettings) error
 	GetHostsFile() (HostsFile, error)
 	SetHostsFile(file HostsFile) error
 
+	ListPCIDevices() ([]PCIDevice, error)
+	// ListIOMMUGroups includes bridges and every other device class, as
+	// they are also part of the groups.
+	ListIOMMUGroups() ([]IOMMUGroup, error)
+	ListMediatedDeviceTypes(pciID string) ([]MediatedDeviceType, error)
+	ListUSBDevices() ([]USBDevice, error)
+	GetCPUInfo() (CPUInfo, error)
+
 	GetTime(local bool) (time.Time, error)
 	GetTimezone() (*time.Location, error)
 	SetTimezone(timezone *time.Location) error
@@ -30,6 +30,9 @@ const (
 
 	// ContentSnippet represents snippet files like guest hook scripts. It's treated as the internal "snippets" type, which shows up as "Snippets" in the UI.
 	ContentSnippet
+
+	// ContentImport represents disk images and OVAs meant to be imported into guests. It's treated as the internal "import" type, which shows up as "Import" in the UI.
+	ContentImport
 )
 
 func (obj Content) String() string {
@@ -67,6 +70,10 @@ func (obj Content) Marshal() (string, error) {
 		content.Append("snippets")
 	}
 
+	if obj&ContentImport != 0 {
+		content.Append("import")
+	}
+
 	return content.Marshal()
 }
 
@@ -96,6 +103,9 @@ func (obj *Content) Unmarshal(s string) error {
 		case "snippets":
 			*obj |= ContentSnippet
 
+		case "import":
+			*obj |= ContentImport
+
 		default:
 			return fmt.Errorf("unknown storage kind %s", c)
 		}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/xabinapal/gopve/pkg/request"
+)
+
+type DownloadURLOptions struct {
+	URL      string
+	FileName string
+
+	// Content must be one of ContentISO, ContentContainerTemplate or
+	// ContentImport.
+	Content Content
+
+	Checksum          string
+	ChecksumAlgorithm string
+	// Compression is the algorithm used to decompress the file after
+	// downloading it, like gz, lzo or zst.
+	Compression string
+
+	SkipCertificateVerification bool
+}
+
+func (obj DownloadURLOptions) contentDirectory() (string, error) {
+	switch obj.Content {
+	case ContentISO, ContentContainerTemplate, ContentImport:
+		return obj.Content.Marshal()
+	default:
+		return "", fmt.Errorf("invalid download content %s", obj.Content)
+	}
+}
+
+// VolumeID returns the identifier the downloaded file will have in the
+// given storage.
+func (obj DownloadURLOptions) VolumeID(storage string) (string, error) {
+	dir, err := obj.contentDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s/%s", storage, dir, obj.FileName), nil
+}
+
+func (obj DownloadURLOptions) MapToValues() (request.Values, error) {
+	if obj.URL == "" || obj.FileName == "" {
+		return nil, fmt.Errorf("url and filename are required")
+	}
+
+	content, err := obj.contentDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	if (obj.Checksum == "") != (obj.ChecksumAlgorithm == "") {
+		return nil, fmt.Errorf(
+			"checksum and checksum algorithm must be set together",
+		)
+	}
+
+	values := request.Values{}
+
+	values.AddString("url", obj.URL)
+	values.AddString("filename", obj.FileName)
+	values.AddString("content", content)
+
+	values.ConditionalAddString("checksum", obj.Checksum, obj.Checksum != "")
+	values.ConditionalAddString(
+		"checksum-algorithm",
+		obj.ChecksumAlgorithm,
+		obj.ChecksumAlgorithm != "",
+	)
+	values.ConditionalAddString(
+		"compression",
+		obj.Compression,
+		obj.Compression != "",
+	)
+	values.ConditionalAddBool(
+		"verify-certificates",
+		false,
+		obj.SkipCertificateVerification,
+	)
+
+	return values, nil
+}
@@ -0,0 +1,56 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/storage"
+)
+
+func TestDownloadURLOptions(t *testing.T) {
+	opts := storage.DownloadURLOptions{
+		URL:                         "https://example.com/debian-12-genericcloud-amd64.qcow2",
+		FileName:                    "debian-12.qcow2",
+		Content:                     storage.ContentImport,
+		Checksum:                    "0123456789abcdef",
+		ChecksumAlgorithm:           "sha512",
+		SkipCertificateVerification: true,
+	}
+
+	t.Run("MapToValues", func(t *testing.T) {
+		values, err := opts.MapToValues()
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{
+			"url":                 {"https://example.com/debian-12-genericcloud-amd64.qcow2"},
+			"filename":            {"debian-12.qcow2"},
+			"content":             {"import"},
+			"checksum":            {"0123456789abcdef"},
+			"checksum-algorithm":  {"sha512"},
+			"verify-certificates": {"0"},
+		}, map[string][]string(values))
+	})
+
+	t.Run("VolumeID", func(t *testing.T) {
+		volume, err := opts.VolumeID("local")
+		require.NoError(t, err)
+		assert.Equal(t, "local:import/debian-12.qcow2", volume)
+	})
+
+	t.Run("InvalidContent", func(t *testing.T) {
+		opts := opts
+		opts.Content = storage.ContentBackup
+
+		_, err := opts.MapToValues()
+		assert.Error(t, err)
+	})
+
+	t.Run("ChecksumWithoutAlgorithm", func(t *testing.T) {
+		opts := opts
+		opts.ChecksumAlgorithm = ""
+
+		_, err := opts.MapToValues()
+		assert.Error(t, err)
+	})
+}
@@ -1,5 +1,10 @@
 package storage
 
+import (
+	"github.com/xabinapal/gopve/pkg/types/metrics"
+	"github.com/xabinapal/gopve/pkg/types/task"
+)
+
 type Storage interface {
 	Name() string
 	Kind() Kind
@@ -14,6 +19,14 @@ type Storage interface {
 	Nodes() []string
 
 	Digest() string
+
+	// GetMetrics returns the usage history of the storage as seen from the
+	// given node.
+	GetMetrics(node string, opts metrics.Options) (metrics.StorageMetrics, error)
+
+	// DownloadURL makes the given node fetch a file from the network into
+	// the storage.
+	DownloadURL(node string, opts DownloadURLOptions) (task.Task, error)
 }
 
 type Properties struct {
@@ -73,6 +73,4 @@ func NewStorageDirProperties(
 			)
 		},
 	)
-
-	return obj, nil
 }
@@ -0,0 +1,43 @@
+package task
+
+// CompletedTask stands for an operation PVE finished synchronously without
+// starting a task, so it can be waited on like any other one.
+type CompletedTask struct {
+	node string
+	id   string
+}
+
+func NewCompletedTask(node, id string) *CompletedTask {
+	return &CompletedTask{
+		node: node,
+		id:   id,
+	}
+}
+
+func (t *CompletedTask) UPID() string {
+	return ""
+}
+
+func (t *CompletedTask) Node() string {
+	return t.node
+}
+
+func (t *CompletedTask) Action() Action {
+	return ActionUnknown
+}
+
+func (t *CompletedTask) ID() string {
+	return t.id
+}
+
+func (t *CompletedTask) User() string {
+	return ""
+}
+
+func (t *CompletedTask) GetStatus() (Status, error) {
+	return StatusStopped, nil
+}
+
+func (t *CompletedTask) Wait() error {
+	return nil
+}
@@ -0,0 +1,193 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+)
+
+type BackupOptions struct {
+	Mode        BackupMode
+	Compression BackupCompression
+	Storage     string
+
+	NotesTemplate string
+	Protected     bool
+
+	MailTo           []string
+	MailNotification BackupMailNotification
+
+	Retention BackupRetention
+
+	BandwidthLimit uint
+	// RemoveOld is nil to use the vzdump default, which removes the
+	// backups beyond the retention.
+	RemoveOld *bool
+}
+
+func (obj BackupOptions) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	if obj.Mode != "" {
+		if !obj.Mode.IsValid() {
+			return nil, fmt.Errorf("invalid backup mode %s", obj.Mode)
+		}
+
+		if err := values.AddObject("mode", obj.Mode); err != nil {
+			return nil, err
+		}
+	}
+
+	if obj.Compression != "" {
+		if !obj.Compression.IsValid() {
+			return nil, fmt.Errorf(
+				"invalid backup compression %s",
+				obj.Compression,
+			)
+		}
+
+		if err := values.AddObject("compress", obj.Compression); err != nil {
+			return nil, err
+		}
+	}
+
+	values.ConditionalAddString("storage", obj.Storage, obj.Storage != "")
+
+	if strings.Contains(obj.NotesTemplate, "\n") {
+		return nil, fmt.Errorf("backup notes template must be a single line")
+	}
+
+	values.ConditionalAddString(
+		"notes-template",
+		obj.NotesTemplate,
+		obj.NotesTemplate != "",
+	)
+	values.ConditionalAddBool("protected", true, obj.Protected)
+
+	if len(obj.MailTo) != 0 {
+		values.AddString("mailto", strings.Join(obj.MailTo, ","))
+	}
+
+	if obj.MailNotification != "" {
+		if !obj.MailNotification.IsValid() {
+			return nil, fmt.Errorf(
+				"invalid backup mail notification %s",
+				obj.MailNotification,
+			)
+		}
+
+		if err := values.AddObject("mailnotification", obj.MailNotification); err != nil {
+			return nil, err
+		}
+	}
+
+	if !obj.Retention.IsZero() {
+		if err := values.AddObject("prune-backups", obj.Retention); err != nil {
+			return nil, err
+		}
+	}
+
+	values.ConditionalAddUint(
+		"bwlimit",
+		obj.BandwidthLimit,
+		obj.BandwidthLimit != 0,
+	)
+	if obj.RemoveOld != nil {
+		values.AddBool("remove", *obj.RemoveOld)
+	}
+
+	return values, nil
+}
+
+type BackupSelection struct {
+	VMIDs []uint
+	Pool  string
+
+	All     bool
+	Exclude []uint
+}
+
+func (obj BackupSelection) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	selectors := 0
+
+	if len(obj.VMIDs) != 0 {
+		selectors++
+		values.AddString("vmid", joinVMIDs(obj.VMIDs))
+	}
+
+	if obj.Pool != "" {
+		selectors++
+		values.AddString("pool", obj.Pool)
+	}
+
+	if obj.All {
+		selectors++
+		values.AddBool("all", true)
+	}
+
+	if selectors != 1 {
+		return nil, fmt.Errorf(
+			"exactly one of vmids, pool or all must be selected to backup",
+		)
+	}
+
+	if len(obj.Exclude) != 0 {
+		if !obj.All {
+			return nil, fmt.Errorf(
+				"virtual machines can only be excluded when backing up all",
+			)
+		}
+
+		values.AddString("exclude", joinVMIDs(obj.Exclude))
+	}
+
+	return values, nil
+}
+
+func joinVMIDs(vmids []uint) string {
+	list := internal_types.PVEList{Separator: ","}
+
+	for _, vmid := range vmids {
+		list.Append(fmt.Sprintf("%d", vmid))
+	}
+
+	s, _ := list.Marshal()
+	return s
+}
+
+type RestoreOptions struct {
+	VMID    uint
+	Node    string
+	Archive string
+	Storage string
+
+	Force  bool
+	Unique bool
+	Start  bool
+
+	BandwidthLimit uint
+}
+
+func (obj RestoreOptions) MapToValues() (request.Values, error) {
+	if obj.Archive == "" {
+		return nil, fmt.Errorf("backup archive is required to restore")
+	}
+
+	values := request.Values{}
+
+	values.ConditionalAddString("storage", obj.Storage, obj.Storage != "")
+	values.ConditionalAddBool("force", true, obj.Force)
+	values.ConditionalAddBool("unique", true, obj.Unique)
+	values.ConditionalAddBool("start", true, obj.Start)
+	values.ConditionalAddUint(
+		"bwlimit",
+		obj.BandwidthLimit,
+		obj.BandwidthLimit != 0,
+	)
+
+	return values, nil
+}
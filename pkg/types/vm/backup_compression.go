@@ -0,0 +1,48 @@
+package vm
+
+import (
+	"encoding/json"
+)
+
+type BackupCompression string
+
+const (
+	BackupCompressionNone      BackupCompression = "0"
+	BackupCompressionGZip      BackupCompression = "gzip"
+	BackupCompressionLZO       BackupCompression = "lzo"
+	BackupCompressionZStandard BackupCompression = "zstd"
+)
+
+func (obj BackupCompression) IsValid() bool {
+	switch obj {
+	case BackupCompressionNone,
+		BackupCompressionGZip,
+		BackupCompressionLZO,
+		BackupCompressionZStandard:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj BackupCompression) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj BackupCompression) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *BackupCompression) Unmarshal(s string) error {
+	*obj = BackupCompression(s)
+	return nil
+}
+
+func (obj *BackupCompression) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,37 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestBackupCompression(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*vm.BackupCompression)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"None": {
+				Object: vm.BackupCompressionNone,
+				Value:  "0",
+			},
+			"GZip": {
+				Object: vm.BackupCompressionGZip,
+				Value:  "gzip",
+			},
+			"LZO": {
+				Object: vm.BackupCompressionLZO,
+				Value:  "lzo",
+			},
+			"ZStandard": {
+				Object: vm.BackupCompressionZStandard,
+				Value:  "zstd",
+			},
+		},
+	)
+}
@@ -0,0 +1,43 @@
+package vm
+
+import (
+	"encoding/json"
+)
+
+type BackupMailNotification string
+
+const (
+	BackupMailNotificationAlways  BackupMailNotification = "always"
+	BackupMailNotificationFailure BackupMailNotification = "failure"
+)
+
+func (obj BackupMailNotification) IsValid() bool {
+	switch obj {
+	case BackupMailNotificationAlways, BackupMailNotificationFailure:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj BackupMailNotification) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj BackupMailNotification) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *BackupMailNotification) Unmarshal(s string) error {
+	*obj = BackupMailNotification(s)
+	return nil
+}
+
+func (obj *BackupMailNotification) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,29 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestBackupMailNotification(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*vm.BackupMailNotification)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Always": {
+				Object: vm.BackupMailNotificationAlways,
+				Value:  "always",
+			},
+			"Failure": {
+				Object: vm.BackupMailNotificationFailure,
+				Value:  "failure",
+			},
+		},
+	)
+}
@@ -0,0 +1,44 @@
+package vm
+
+import (
+	"encoding/json"
+)
+
+type BackupMode string
+
+const (
+	BackupModeSnapshot BackupMode = "snapshot"
+	BackupModeSuspend  BackupMode = "suspend"
+	BackupModeStop     BackupMode = "stop"
+)
+
+func (obj BackupMode) IsValid() bool {
+	switch obj {
+	case BackupModeSnapshot, BackupModeSuspend, BackupModeStop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj BackupMode) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj BackupMode) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *BackupMode) Unmarshal(s string) error {
+	*obj = BackupMode(s)
+	return nil
+}
+
+func (obj *BackupMode) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
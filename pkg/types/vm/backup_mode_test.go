@@ -0,0 +1,33 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestBackupMode(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*vm.BackupMode)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Snapshot": {
+				Object: vm.BackupModeSnapshot,
+				Value:  "snapshot",
+			},
+			"Suspend": {
+				Object: vm.BackupModeSuspend,
+				Value:  "suspend",
+			},
+			"Stop": {
+				Object: vm.BackupModeStop,
+				Value:  "stop",
+			},
+		},
+	)
+}
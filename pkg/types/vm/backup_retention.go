@@ -0,0 +1,140 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+)
+
+type BackupRetention struct {
+	KeepAll bool
+
+	KeepLast    uint
+	KeepHourly  uint
+	KeepDaily   uint
+	KeepWeekly  uint
+	KeepMonthly uint
+	KeepYearly  uint
+}
+
+func (obj BackupRetention) IsZero() bool {
+	return obj == BackupRetention{}
+}
+
+func (obj BackupRetention) Marshal() (string, error) {
+	if obj.KeepAll {
+		if obj != (BackupRetention{KeepAll: true}) {
+			return "", fmt.Errorf(
+				"backup retention keep all cannot be combined with other options",
+			)
+		}
+
+		return "keep-all=1", nil
+	}
+
+	list := internal_types.PVEList{Separator: ","}
+
+	for _, opt := range []struct {
+		key   string
+		value uint
+	}{
+		{"keep-last", obj.KeepLast},
+		{"keep-hourly", obj.KeepHourly},
+		{"keep-daily", obj.KeepDaily},
+		{"keep-weekly", obj.KeepWeekly},
+		{"keep-monthly", obj.KeepMonthly},
+		{"keep-yearly", obj.KeepYearly},
+	} {
+		if opt.value != 0 {
+			list.Append(fmt.Sprintf("%s=%d", opt.key, opt.value))
+		}
+	}
+
+	return list.Marshal()
+}
+
+func (obj *BackupRetention) Unmarshal(s string) error {
+	dict := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+	}
+
+	if err := (&dict).Unmarshal(s); err != nil {
+		return err
+	}
+
+	retention := BackupRetention{}
+
+	for _, kv := range dict.List() {
+		if err := retention.set(kv.Key(), kv.Value()); err != nil {
+			return err
+		}
+	}
+
+	*obj = retention
+
+	return nil
+}
+
+func (obj *BackupRetention) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return obj.Unmarshal(s)
+	}
+
+	var m map[string]json.Number
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+
+	retention := BackupRetention{}
+
+	for k, v := range m {
+		if err := retention.set(k, v.String()); err != nil {
+			return err
+		}
+	}
+
+	*obj = retention
+
+	return nil
+}
+
+func (obj *BackupRetention) set(key, value string) error {
+	if key == "keep-all" {
+		keepAll, err := internal_types.NewPVEBoolFromString(value)
+		if err != nil {
+			return err
+		}
+
+		obj.KeepAll = keepAll.Bool()
+
+		return nil
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	switch key {
+	case "keep-last":
+		obj.KeepLast = uint(n)
+	case "keep-hourly":
+		obj.KeepHourly = uint(n)
+	case "keep-daily":
+		obj.KeepDaily = uint(n)
+	case "keep-weekly":
+		obj.KeepWeekly = uint(n)
+	case "keep-monthly":
+		obj.KeepMonthly = uint(n)
+	case "keep-yearly":
+		obj.KeepYearly = uint(n)
+	default:
+		return fmt.Errorf("unknown backup retention option %s", key)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package vm_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestBackupRetention(t *testing.T) {
+	options := map[string]struct {
+		Object vm.BackupRetention
+		Value  string
+	}{
+		"KeepAll": {
+			Object: vm.BackupRetention{KeepAll: true},
+			Value:  "keep-all=1",
+		},
+		"KeepLast": {
+			Object: vm.BackupRetention{KeepLast: 3},
+			Value:  "keep-last=3",
+		},
+		"Mixed": {
+			Object: vm.BackupRetention{
+				KeepDaily:   7,
+				KeepWeekly:  4,
+				KeepMonthly: 6,
+				KeepYearly:  1,
+			},
+			Value: "keep-daily=7,keep-weekly=4,keep-monthly=6,keep-yearly=1",
+		},
+	}
+
+	for n, tt := range options {
+		tt := tt
+
+		t.Run(n, func(t *testing.T) {
+			t.Run("Marshal", func(t *testing.T) {
+				value, err := tt.Object.Marshal()
+				require.NoError(t, err)
+				assert.Equal(t, tt.Value, value)
+			})
+
+			t.Run("Unmarshal", func(t *testing.T) {
+				var obj vm.BackupRetention
+				require.NoError(t, (&obj).Unmarshal(tt.Value))
+				assert.Equal(t, tt.Object, obj)
+			})
+		})
+	}
+
+	t.Run("UnmarshalJSONObject", func(t *testing.T) {
+		var obj vm.BackupRetention
+		err := json.Unmarshal([]byte(`{"keep-last": 2, "keep-daily": "7"}`), &obj)
+		require.NoError(t, err)
+		assert.Equal(t, vm.BackupRetention{KeepLast: 2, KeepDaily: 7}, obj)
+	})
+
+	t.Run("KeepAllCombined", func(t *testing.T) {
+		_, err := vm.BackupRetention{KeepAll: true, KeepLast: 1}.Marshal()
+		assert.Error(t, err)
+	})
+
+	t.Run("UnknownOption", func(t *testing.T) {
+		var obj vm.BackupRetention
+		assert.Error(t, (&obj).Unmarshal("keep-forever=1"))
+	})
+}
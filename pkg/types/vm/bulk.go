@@ -0,0 +1,41 @@
+package vm
+
+import (
+	"time"
+
+	"github.com/xabinapal/gopve/pkg/types/task"
+)
+
+type BulkOptions struct {
+	// Concurrency is the maximum number of guests acted upon at the same
+	// time. When zero, DefaultBulkConcurrency is used.
+	Concurrency uint
+
+	// Wait blocks until every task finishes. Tasks of a startup order group
+	// are always waited for before moving to the next group.
+	Wait bool
+
+	// IgnoreOrder runs every guest in a single group, without loading
+	// their startup order, delay or shutdown timeout.
+	IgnoreOrder bool
+
+	// ShutdownTimeout applies to guests without their own shutdown timeout,
+	// and the shutdown task fails once it's reached. When ForceStop is set,
+	// PVE stops the guest instead, and the shutdown is always waited for.
+	ShutdownTimeout time.Duration
+	ForceStop       bool
+}
+
+const (
+	DefaultBulkConcurrency uint = 4
+)
+
+type BulkResult struct {
+	VMID uint
+	Task task.Task
+	Err  error
+
+	// Forced is set when a shutdown reached its timeout and the guest was
+	// stopped.
+	Forced bool
+}
@@ -0,0 +1,11 @@
+package vm
+
+// DeleteOptions set how a guest is destroyed. Purge also removes it from
+// backup jobs, replication and HA, Force stops a running container before
+// destroying it, and IgnoreLinkedClones skips the check that refuses to
+// delete templates with linked clones.
+type DeleteOptions struct {
+	Purge              bool
+	Force              bool
+	IgnoreLinkedClones bool
+}
@@ -0,0 +1,98 @@
+package vm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/xabinapal/gopve/pkg/types/storage"
+)
+
+type DiskSize struct {
+	Bytes     uint64
+	Increment bool
+}
+
+const (
+	DiskSizeKibibyte uint64 = 1 << (10 * (iota + 1))
+	DiskSizeMebibyte
+	DiskSizeGibibyte
+	DiskSizeTebibyte
+)
+
+var diskSizeRegExp = regexp.MustCompile(`^(\+)?(\d+)([KMGT])?$`)
+
+func NewDiskSize(s string) (DiskSize, error) {
+	var obj DiskSize
+	return obj, (&obj).Unmarshal(s)
+}
+
+func (obj DiskSize) Marshal() (string, error) {
+	if obj.Bytes == 0 {
+		return "", fmt.Errorf("invalid disk size, must be greater than 0")
+	}
+
+	var prefix string
+	if obj.Increment {
+		prefix = "+"
+	}
+
+	for _, unit := range []struct {
+		Suffix string
+		Size   uint64
+	}{
+		{"T", DiskSizeTebibyte},
+		{"G", DiskSizeGibibyte},
+		{"M", DiskSizeMebibyte},
+		{"K", DiskSizeKibibyte},
+	} {
+		if obj.Bytes%unit.Size == 0 {
+			return fmt.Sprintf(
+				"%s%d%s",
+				prefix,
+				obj.Bytes/unit.Size,
+				unit.Suffix,
+			), nil
+		}
+	}
+
+	return fmt.Sprintf("%s%d", prefix, obj.Bytes), nil
+}
+
+func (obj *DiskSize) Unmarshal(s string) error {
+	matches := diskSizeRegExp.FindStringSubmatch(s)
+	if matches == nil {
+		return fmt.Errorf("can't unmarshal disk size %s", s)
+	}
+
+	size, err := strconv.ParseUint(matches[2], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	switch matches[3] {
+	case "K":
+		size *= DiskSizeKibibyte
+	case "M":
+		size *= DiskSizeMebibyte
+	case "G":
+		size *= DiskSizeGibibyte
+	case "T":
+		size *= DiskSizeTebibyte
+	}
+
+	obj.Bytes = size
+	obj.Increment = matches[1] == "+"
+
+	return nil
+}
+
+type MoveDiskOptions struct {
+	TargetStorage string
+	Format        storage.ImageFormat
+
+	DeleteSource   bool
+	BandwidthLimit uint
+
+	Digest string
+}
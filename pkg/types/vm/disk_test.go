@@ -0,0 +1,62 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestDiskSize(t *testing.T) {
+	options := map[string]struct {
+		Object vm.DiskSize
+		Value  string
+	}{
+		"Bytes": {
+			Object: vm.DiskSize{Bytes: 1000},
+			Value:  "1000",
+		},
+		"Gibibytes": {
+			Object: vm.DiskSize{Bytes: 32 * vm.DiskSizeGibibyte},
+			Value:  "32G",
+		},
+		"Increment": {
+			Object: vm.DiskSize{
+				Bytes:     10 * vm.DiskSizeGibibyte,
+				Increment: true,
+			},
+			Value: "+10G",
+		},
+		"Tebibytes": {
+			Object: vm.DiskSize{Bytes: 2 * vm.DiskSizeTebibyte},
+			Value:  "2T",
+		},
+	}
+
+	for n, tt := range options {
+		tt := tt
+
+		t.Run(n, func(t *testing.T) {
+			t.Run("Marshal", func(t *testing.T) {
+				value, err := tt.Object.Marshal()
+				require.NoError(t, err)
+				assert.Equal(t, tt.Value, value)
+			})
+
+			t.Run("Unmarshal", func(t *testing.T) {
+				obj, err := vm.NewDiskSize(tt.Value)
+				require.NoError(t, err)
+				assert.Equal(t, tt.Object, obj)
+			})
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := vm.NewDiskSize("-10G")
+		assert.Error(t, err)
+
+		_, err = vm.DiskSize{}.Marshal()
+		assert.Error(t, err)
+	})
+}
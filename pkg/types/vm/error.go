@@ -7,6 +7,20 @@ const (
 
 	ErrNotFound = errors.ClientError("404 - virtual machine not found!")
 
+	ErrInvalidTag = errors.ClientError("500 - invalid tag!")
+
+	ErrTemplateHasLinkedClones = errors.ClientError(
+		"500 - template has linked clones!",
+	)
+
+	ErrTemplateUnknownDependents = errors.ClientError(
+		"500 - template linked clones can't be checked!",
+	)
+
+	ErrMigrateSameNode = errors.ClientError(
+		"500 - can't migrate virtual machine to its current node!",
+	)
+
 	ErrNoSnapshot         = errors.ClientError("500 - snapshot not found!")
 	ErrRootParentSnapshot = errors.ClientError(
 		"500 - snapshot has no parent!",
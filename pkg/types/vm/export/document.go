@@ -0,0 +1,165 @@
+package export
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentVersion is written to every exported document, and documents
+// with another version are refused on import.
+const DocumentVersion uint = 1
+
+// Document is the portable definition of a guest. Configuration, firewall
+// options and rules are kept in PVE notation, as serialized by the typed
+// properties, instead of the typed properties themselves, so they can be
+// read and edited by hand. The configuration is checked against the typed
+// qemu or lxc properties when the document is parsed.
+type Document struct {
+	Version uint `json:"version" yaml:"version"`
+
+	VMID     uint    `json:"vmid" yaml:"vmid"`
+	Kind     vm.Kind `json:"kind" yaml:"kind"`
+	Node     string  `json:"node" yaml:"node"`
+	Name     string  `json:"name,omitempty" yaml:"name,omitempty"`
+	Template bool    `json:"template,omitempty" yaml:"template,omitempty"`
+
+	Config map[string]string `json:"config" yaml:"config"`
+
+	// Unused lists the volumes of the unused disks, which are kept out of
+	// Config and not recreated on import. Only qemu guests report them.
+	Unused []string `json:"unused,omitempty" yaml:"unused,omitempty"`
+
+	Firewall *Firewall `json:"firewall,omitempty" yaml:"firewall,omitempty"`
+
+	// Snapshots are only kept as metadata, they are not recreated on
+	// import.
+	Snapshots []Snapshot `json:"snapshots,omitempty" yaml:"snapshots,omitempty"`
+
+	Pool string `json:"pool,omitempty" yaml:"pool,omitempty"`
+	HA   *HA    `json:"ha,omitempty" yaml:"ha,omitempty"`
+}
+
+type Firewall struct {
+	Options map[string]string   `json:"options,omitempty" yaml:"options,omitempty"`
+	Rules   []map[string]string `json:"rules,omitempty" yaml:"rules,omitempty"`
+	Aliases []Alias             `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	IPSets  []IPSet             `json:"ipsets,omitempty" yaml:"ipsets,omitempty"`
+}
+
+type Alias struct {
+	Name    string `json:"name" yaml:"name"`
+	CIDR    string `json:"cidr" yaml:"cidr"`
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+type IPSet struct {
+	Name    string       `json:"name" yaml:"name"`
+	Comment string       `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Entries []IPSetEntry `json:"entries,omitempty" yaml:"entries,omitempty"`
+}
+
+type IPSetEntry struct {
+	CIDR    string `json:"cidr" yaml:"cidr"`
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	NoMatch bool   `json:"nomatch,omitempty" yaml:"nomatch,omitempty"`
+}
+
+type Snapshot struct {
+	Name        string    `json:"name" yaml:"name"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Parent      string    `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Time        time.Time `json:"time" yaml:"time"`
+	WithRAM     bool      `json:"vmstate,omitempty" yaml:"vmstate,omitempty"`
+}
+
+type HA struct {
+	State       vm.HAState `json:"state" yaml:"state"`
+	Group       string     `json:"group,omitempty" yaml:"group,omitempty"`
+	MaxRestart  uint       `json:"max_restart" yaml:"max_restart"`
+	MaxRelocate uint       `json:"max_relocate" yaml:"max_relocate"`
+	Comment     string     `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+func NewHA(props vm.HAProperties) *HA {
+	return &HA{
+		State:       props.State,
+		Group:       props.Group,
+		MaxRestart:  props.MaxRestart,
+		MaxRelocate: props.MaxRelocate,
+		Comment:     props.Comment,
+	}
+}
+
+func (obj HA) Properties() vm.HAProperties {
+	return vm.HAProperties{
+		State:       obj.State,
+		Group:       obj.Group,
+		MaxRestart:  obj.MaxRestart,
+		MaxRelocate: obj.MaxRelocate,
+		Comment:     obj.Comment,
+	}
+}
+
+// Parse reads a document from its YAML or JSON representation.
+func Parse(b []byte) (Document, error) {
+	var obj Document
+
+	if err := yaml.Unmarshal(b, &obj); err != nil {
+		return obj, err
+	}
+
+	if obj.Version != DocumentVersion {
+		return obj, ErrUnsupportedVersion
+	}
+
+	if err := obj.Kind.IsValid(); err != nil {
+		return obj, err
+	}
+
+	return obj, obj.validateConfig()
+}
+
+func (obj Document) validateConfig() error {
+	props := make(types.Properties, len(obj.Config))
+
+	// PVE sends numbers and booleans as JSON numbers, which the document
+	// keeps as strings like any other value.
+	for k, v := range obj.Config {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			props[k] = f
+		} else {
+			props[k] = v
+		}
+	}
+
+	// The digest belongs to the source guest, so it's never exported.
+	props["digest"] = ""
+
+	var err error
+
+	switch obj.Kind {
+	case vm.KindQEMU:
+		_, err = qemu.NewProperties(props)
+	case vm.KindLXC:
+		_, err = lxc.NewProperties(props)
+	default:
+		err = vm.ErrInvalidKind
+	}
+
+	return err
+}
+
+func (obj Document) YAML() ([]byte, error) {
+	return yaml.Marshal(obj)
+}
+
+func (obj Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(obj, "", "  ")
+}
@@ -0,0 +1,85 @@
+package export_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/export"
+)
+
+func TestDocument(t *testing.T) {
+	doc := export.Document{
+		Version: export.DocumentVersion,
+		VMID:    100,
+		Kind:    vm.KindQEMU,
+		Node:    "test_node",
+		Name:    "web",
+		Config: map[string]string{
+			"name":    "web",
+			"ostype":  "l26",
+			"sockets": "1",
+			"cores":   "2",
+			"memory":  "2048",
+			"scsi0":   "local-lvm:vm-100-disk-0,size=32G",
+		},
+		Unused: []string{"local-lvm:vm-100-disk-1"},
+		Firewall: &export.Firewall{
+			Options: map[string]string{"enable": "1"},
+			Rules: []map[string]string{
+				{"type": "in", "action": "ACCEPT", "enable": "1"},
+			},
+			IPSets: []export.IPSet{{
+				Name:    "trusted",
+				Entries: []export.IPSetEntry{{CIDR: "10.0.0.0/8"}},
+			}},
+		},
+		Snapshots: []export.Snapshot{{
+			Name: "before-upgrade",
+			Time: time.Unix(1600000000, 0).UTC(),
+		}},
+		Pool: "prod",
+		HA:   export.NewHA(vm.NewHAProperties()),
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		b, err := doc.YAML()
+		require.NoError(t, err)
+
+		parsed, err := export.Parse(b)
+		require.NoError(t, err)
+		assert.Equal(t, doc, parsed)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		b, err := doc.JSON()
+		require.NoError(t, err)
+
+		parsed, err := export.Parse(b)
+		require.NoError(t, err)
+		assert.Equal(t, doc, parsed)
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		doc := doc
+		doc.Config = map[string]string{
+			"ostype":  "l26",
+			"sockets": "1",
+			"cores":   "two",
+			"memory":  "2048",
+		}
+
+		b, err := doc.YAML()
+		require.NoError(t, err)
+
+		_, err = export.Parse(b)
+		assert.Error(t, err)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		_, err := export.Parse([]byte("version: 2\nvmid: 100\nkind: qemu\n"))
+		assert.Equal(t, export.ErrUnsupportedVersion, err)
+	})
+}
@@ -0,0 +1,12 @@
+package export
+
+import "github.com/xabinapal/gopve/pkg/types/errors"
+
+const (
+	ErrUnsupportedVersion = errors.ClientError(
+		"500 - unsupported document version!",
+	)
+	ErrMissingTemplate = errors.ClientError(
+		"500 - container template is required!",
+	)
+)
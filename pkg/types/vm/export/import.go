@@ -0,0 +1,274 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+// ImportOptions control how a document is recreated. Zero VMID and empty
+// Node keep the ones in the document, and storages or bridges missing in
+// the maps keep their names.
+type ImportOptions struct {
+	VMID uint
+	Node string
+
+	StorageMap map[string]string
+	BridgeMap  map[string]string
+
+	RegenerateMACAddresses bool
+
+	// OSTemplate is the volume used to create a container, as its root
+	// filesystem can't be recreated from the configuration.
+	OSTemplate string
+
+	SkipFirewall bool
+	SkipPool     bool
+	SkipHA       bool
+}
+
+func (obj ImportOptions) storage(name string) string {
+	if mapped, ok := obj.StorageMap[name]; ok {
+		return mapped
+	}
+
+	return name
+}
+
+func (obj ImportOptions) bridge(name string) string {
+	if mapped, ok := obj.BridgeMap[name]; ok {
+		return mapped
+	}
+
+	return name
+}
+
+var (
+	qemuDriveRegExp  = regexp.MustCompile(`^(ide|sata|scsi|virtio)(\d+)$`)
+	networkRegExp    = regexp.MustCompile(`^net(\d+)$`)
+	mountPointRegExp = regexp.MustCompile(`^mp(\d+)$`)
+	unusedRegExp     = regexp.MustCompile(`^unused\d+$`)
+)
+
+// CreateValues maps the document configuration to the values used to create
+// the guest. Disks are allocated as new empty volumes of the same size in the
+// mapped storage, and network interfaces are attached to the mapped bridges.
+func (obj Document) CreateValues(opts ImportOptions) (request.Values, error) {
+	if obj.Version != DocumentVersion {
+		return nil, ErrUnsupportedVersion
+	}
+
+	keys := make([]string, 0, len(obj.Config))
+	for key := range obj.Config {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	values := request.Values{}
+
+	for _, key := range keys {
+		if key == "digest" || unusedRegExp.MatchString(key) {
+			continue
+		}
+
+		var (
+			value string
+			err   error
+		)
+
+		switch obj.Kind {
+		case vm.KindQEMU:
+			value, err = mapQEMUValue(key, obj.Config[key], opts)
+		case vm.KindLXC:
+			value, err = mapLXCValue(key, obj.Config[key], opts)
+		default:
+			return nil, vm.ErrInvalidKind
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+
+		values.AddString(key, value)
+	}
+
+	if obj.Kind == vm.KindLXC {
+		if opts.OSTemplate == "" {
+			return nil, ErrMissingTemplate
+		}
+
+		values.AddString("ostemplate", opts.OSTemplate)
+	}
+
+	return values, nil
+}
+
+func mapQEMUValue(key, value string, opts ImportOptions) (string, error) {
+	if key == "efidisk0" {
+		disk, err := qemu.NewEFIDiskProperties(value)
+		if err != nil {
+			return "", err
+		}
+
+		disk.StorageName = opts.storage(disk.StorageName)
+		disk.StorageFile = "1"
+		disk.Size = ""
+
+		return disk.Marshal()
+	}
+
+	if key == "tpmstate0" {
+		state, err := qemu.NewTPMStateProperties(value)
+		if err != nil {
+			return "", err
+		}
+
+		state.StorageName = opts.storage(state.StorageName)
+		state.StorageFile = "1"
+		state.Size = ""
+
+		return state.Marshal()
+	}
+
+	if matches := networkRegExp.FindStringSubmatch(key); matches != nil {
+		n, _ := strconv.Atoi(matches[1])
+
+		nic, err := qemu.NewNetworkInterfaceProperties(n, value)
+		if err != nil {
+			return "", err
+		}
+
+		nic.Bridge = opts.bridge(nic.Bridge)
+
+		if opts.RegenerateMACAddresses {
+			nic.MACAddress = ""
+		}
+
+		return nic.Marshal()
+	}
+
+	matches := qemuDriveRegExp.FindStringSubmatch(key)
+	if matches == nil {
+		return value, nil
+	}
+
+	n, _ := strconv.Atoi(matches[2])
+
+	drive, err := qemu.NewStorageDrive(qemu.Bus(matches[1]), n, value)
+	if err != nil {
+		return "", err
+	}
+
+	switch drive := drive.(type) {
+	case qemu.HardDriveProperties:
+		size, err := vm.NewDiskSize(drive.Size)
+		if err != nil {
+			return "", err
+		}
+
+		storage := opts.storage(drive.StorageName)
+
+		// The image format may not be supported by another storage.
+		if storage != drive.StorageName {
+			drive.Format = 0
+		}
+
+		drive.StorageName = storage
+		drive.StorageFile = strconv.FormatUint(gibibytes(size), 10)
+		drive.Size = ""
+		drive.ImportFrom = ""
+
+		return drive.Marshal()
+	case qemu.CDROMProperties:
+		switch drive.Source {
+		case qemu.CDROMSourceCloudInit:
+			drive.StorageFile = qemu.CloudInitVolume
+			fallthrough
+		case qemu.CDROMSourceISOFile:
+			drive.StorageName = opts.storage(drive.StorageName)
+		}
+
+		drive.Size = ""
+
+		return drive.Marshal()
+	default:
+		return value, nil
+	}
+}
+
+func mapLXCValue(key, value string, opts ImportOptions) (string, error) {
+	if matches := networkRegExp.FindStringSubmatch(key); matches != nil {
+		n, _ := strconv.Atoi(matches[1])
+
+		nic, err := lxc.NewNetworkInterfaceProperties(n, value)
+		if err != nil {
+			return "", err
+		}
+
+		nic.Bridge = opts.bridge(nic.Bridge)
+
+		if opts.RegenerateMACAddresses {
+			nic.MACAddress = ""
+		}
+
+		return nic.Marshal()
+	}
+
+	if key == "rootfs" {
+		rootfs, err := lxc.NewRootFSProperties(value)
+		if err != nil {
+			return "", err
+		}
+
+		mapVolume(&rootfs.VolumeProperties, opts)
+
+		return rootfs.Marshal()
+	}
+
+	if matches := mountPointRegExp.FindStringSubmatch(key); matches != nil {
+		n, _ := strconv.Atoi(matches[1])
+
+		mp, err := lxc.NewMountPointProperties(n, value)
+		if err != nil {
+			return "", err
+		}
+
+		if !mp.IsBindMount() {
+			mapVolume(&mp.VolumeProperties, opts)
+		}
+
+		return mp.Marshal()
+	}
+
+	return value, nil
+}
+
+func mapVolume(volume *lxc.VolumeProperties, opts ImportOptions) {
+	storage := opts.storage(storageName(volume.Volume))
+
+	volume.Volume = fmt.Sprintf("%s:%d", storage, gibibytes(volume.Size))
+	volume.Size = vm.DiskSize{}
+}
+
+// gibibytes rounds the size up to whole GiB, the unit used to allocate new
+// volumes.
+func gibibytes(size vm.DiskSize) uint64 {
+	gib := (size.Bytes + vm.DiskSizeGibibyte - 1) / vm.DiskSizeGibibyte
+	if gib == 0 {
+		gib = 1
+	}
+
+	return gib
+}
+
+func storageName(volume string) string {
+	return strings.SplitN(volume, ":", 2)[0]
+}
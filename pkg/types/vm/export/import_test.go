@@ -0,0 +1,86 @@
+package export_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/export"
+)
+
+func TestDocumentCreateValues(t *testing.T) {
+	opts := export.ImportOptions{
+		StorageMap:             map[string]string{"local-lvm": "ceph"},
+		BridgeMap:              map[string]string{"vmbr0": "vmbr1"},
+		RegenerateMACAddresses: true,
+	}
+
+	t.Run("QEMU", func(t *testing.T) {
+		doc := export.Document{
+			Version: export.DocumentVersion,
+			Kind:    vm.KindQEMU,
+			Config: map[string]string{
+				"name":      "web",
+				"memory":    "2048",
+				"scsi0":     "local-lvm:vm-100-disk-0,size=32G,format=raw,ssd=1",
+				"virtio1":   "local:100/vm-100-disk-1.qcow2,size=1536M,format=qcow2",
+				"ide0":      "local:iso/debian.iso,media=cdrom",
+				"ide2":      "local-lvm:vm-100-cloudinit,media=cdrom",
+				"efidisk0":  "local-lvm:vm-100-disk-2,size=4M,efitype=4m,pre-enrolled-keys=1",
+				"tpmstate0": "local-lvm:vm-100-disk-4,size=4M,version=v2.0",
+				"net0":      "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,firewall=1",
+				"unused0":   "local-lvm:vm-100-disk-3",
+				"digest":    "0000000000000000000000000000000000000000",
+			},
+		}
+
+		values, err := doc.CreateValues(opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, request.Values{
+			"name":      {"web"},
+			"memory":    {"2048"},
+			"scsi0":     {"ceph:32,ssd=1"},
+			"virtio1":   {"local:2,format=qcow2"},
+			"ide0":      {"local:iso/debian.iso,media=cdrom"},
+			"ide2":      {"ceph:cloudinit,media=cdrom"},
+			"efidisk0":  {"ceph:1,efitype=4m,pre-enrolled-keys=1"},
+			"tpmstate0": {"ceph:1,version=v2.0"},
+			"net0":      {"virtio,bridge=vmbr1,firewall=1"},
+		}, values)
+	})
+
+	t.Run("LXC", func(t *testing.T) {
+		doc := export.Document{
+			Version: export.DocumentVersion,
+			Kind:    vm.KindLXC,
+			Config: map[string]string{
+				"hostname": "db",
+				"rootfs":   "local-lvm:vm-101-disk-0,size=8G",
+				"mp0":      "local-lvm:vm-101-disk-1,mp=/var/lib/db,size=100G,backup=1",
+				"mp1":      "/srv/shared,mp=/mnt/shared",
+				"net0":     "name=eth0,bridge=vmbr0,hwaddr=AA:BB:CC:DD:EE:FF,ip=dhcp",
+			},
+		}
+
+		_, err := doc.CreateValues(opts)
+		assert.Equal(t, export.ErrMissingTemplate, err)
+
+		opts := opts
+		opts.OSTemplate = "local:vztmpl/debian.tar.zst"
+
+		values, err := doc.CreateValues(opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, request.Values{
+			"hostname":   {"db"},
+			"rootfs":     {"ceph:8"},
+			"mp0":        {"ceph:100,mp=/var/lib/db,backup=1"},
+			"mp1":        {"/srv/shared,mp=/mnt/shared"},
+			"net0":       {"name=eth0,bridge=vmbr1,ip=dhcp,type=veth"},
+			"ostemplate": {"local:vztmpl/debian.tar.zst"},
+		}, values)
+	})
+}
@@ -0,0 +1,49 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/xabinapal/gopve/pkg/request"
+)
+
+// HAProperties describe the high availability resource of a guest.
+type HAProperties struct {
+	State HAState
+	Group string
+
+	MaxRestart  uint
+	MaxRelocate uint
+
+	Comment string
+}
+
+const (
+	DefaultHAPropertyState       HAState = HAStateStarted
+	DefaultHAPropertyMaxRestart  uint    = 1
+	DefaultHAPropertyMaxRelocate uint    = 1
+)
+
+func NewHAProperties() HAProperties {
+	return HAProperties{
+		State:       DefaultHAPropertyState,
+		MaxRestart:  DefaultHAPropertyMaxRestart,
+		MaxRelocate: DefaultHAPropertyMaxRelocate,
+	}
+}
+
+func (obj HAProperties) MapToValues() (request.Values, error) {
+	if !obj.State.IsValid() {
+		return nil, fmt.Errorf("invalid high availability state %s", obj.State)
+	}
+
+	values := request.Values{}
+
+	values.AddString("state", string(obj.State))
+
+	values.ConditionalAddString("group", obj.Group, obj.Group != "")
+	values.AddUint("max_restart", obj.MaxRestart)
+	values.AddUint("max_relocate", obj.MaxRelocate)
+	values.ConditionalAddString("comment", obj.Comment, obj.Comment != "")
+
+	return values, nil
+}
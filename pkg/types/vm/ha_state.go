@@ -0,0 +1,50 @@
+package vm
+
+import (
+	"encoding/json"
+)
+
+type HAState string
+
+const (
+	HAStateStarted  HAState = "started"
+	HAStateStopped  HAState = "stopped"
+	HAStateEnabled  HAState = "enabled"
+	HAStateDisabled HAState = "disabled"
+	HAStateIgnored  HAState = "ignored"
+)
+
+func (obj HAState) IsValid() bool {
+	switch obj {
+	case HAStateStarted,
+		HAStateStopped,
+		HAStateEnabled,
+		HAStateDisabled,
+		HAStateIgnored:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj HAState) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj HAState) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *HAState) Unmarshal(s string) error {
+	*obj = HAState(s)
+	return nil
+}
+
+func (obj *HAState) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,41 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestHAState(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*vm.HAState)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Started": {
+				Object: vm.HAStateStarted,
+				Value:  "started",
+			},
+			"Stopped": {
+				Object: vm.HAStateStopped,
+				Value:  "stopped",
+			},
+			"Enabled": {
+				Object: vm.HAStateEnabled,
+				Value:  "enabled",
+			},
+			"Disabled": {
+				Object: vm.HAStateDisabled,
+				Value:  "disabled",
+			},
+			"Ignored": {
+				Object: vm.HAStateIgnored,
+				Value:  "ignored",
+			},
+		},
+	)
+}
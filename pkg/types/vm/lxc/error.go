@@ -0,0 +1,15 @@
+package lxc
+
+import "github.com/xabinapal/gopve/pkg/types/errors"
+
+const (
+	ErrInvalidTemplateStorage = errors.ClientError(
+		"500 - storage can't hold container templates!",
+	)
+	ErrTemplateNotFound = errors.ClientError(
+		"404 - container template not found!",
+	)
+	ErrCreateTimeout = errors.ClientError(
+		"500 - container didn't become ready in time!",
+	)
+)
@@ -0,0 +1,34 @@
+package lxc
+
+import (
+	"github.com/xabinapal/gopve/pkg/types/errors"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type PendingProperties struct {
+	Changes vm.PendingChanges
+
+	Current Properties
+	Pending Properties
+}
+
+func NewPendingProperties(
+	changes vm.PendingChanges,
+) (obj PendingProperties, err error) {
+	obj.Changes = changes
+
+	return obj, errors.ChainUntilFail(
+		func() (err error) {
+			obj.Current, err = NewProperties(changes.Current())
+			return err
+		},
+		func() (err error) {
+			obj.Pending, err = NewProperties(changes.Pending())
+			return err
+		},
+	)
+}
+
+func (obj PendingProperties) RebootRequired() bool {
+	return obj.Changes.RebootRequired()
+}
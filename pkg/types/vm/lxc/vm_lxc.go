@@ -2,10 +2,13 @@ package lxc
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/xabinapal/gopve/pkg/request"
 	"github.com/xabinapal/gopve/pkg/types"
 	"github.com/xabinapal/gopve/pkg/types/errors"
+	"github.com/xabinapal/gopve/pkg/types/task"
 	"github.com/xabinapal/gopve/pkg/types/vm"
 )
 
@@ -17,6 +20,12 @@ type VirtualMachine interface {
 
 	GetLXCProperties() (Properties, error)
 	SetLXCProperties(props Properties) error
+	GetPendingLXCProperties() (PendingProperties, error)
+
+	ResizeVolume(volume string, size vm.DiskSize) (task.Task, error)
+	MoveVolume(volume string, opts vm.MoveDiskOptions) (task.Task, error)
+	DetachVolume(volume string) error
+	DeleteUnusedVolume(volume string) error
 }
 
 type CreateOptions struct {
@@ -26,18 +35,65 @@ type CreateOptions struct {
 	OSTemplateStorage string
 	OSTemplate        string
 
+	Password      string
+	SSHPublicKeys []string
+
+	Pool               string
+	Start              bool
+	Unique             bool
+	IgnoreUnpackErrors bool
+
+	// WaitUntilReady makes the creation block until the container is
+	// running and has an address on any network interface. It implies
+	// Start. Timeout defaults to DefaultCreateTimeout.
+	WaitUntilReady  bool
+	PollingInterval time.Duration
+	Timeout         time.Duration
+
 	Properties Properties
 }
 
+const (
+	DefaultCreatePollingInterval = time.Duration(1) * time.Second
+	DefaultCreateTimeout         = time.Duration(5) * time.Minute
+)
+
+func (obj CreateOptions) OSTemplateVolume() string {
+	return fmt.Sprintf("%s:vztmpl/%s", obj.OSTemplateStorage, obj.OSTemplate)
+}
+
 func (obj CreateOptions) MapToValues() (request.Values, error) {
+	if obj.OSTemplateStorage == "" || obj.OSTemplate == "" {
+		return nil, fmt.Errorf("container template is required")
+	}
+
 	values, err := obj.Properties.MapToValues()
 	if err != nil {
 		return nil, err
 	}
 
-	values.AddString(
-		"ostemplate",
-		fmt.Sprintf("%s:vztmpl/%s", obj.OSTemplateStorage, obj.OSTemplate),
+	delete(values, "digest")
+
+	values.AddString("ostemplate", obj.OSTemplateVolume())
+
+	values.ConditionalAddString("password", obj.Password, obj.Password != "")
+	values.ConditionalAddString(
+		"ssh-public-keys",
+		strings.Join(obj.SSHPublicKeys, "\n"),
+		len(obj.SSHPublicKeys) != 0,
+	)
+
+	values.ConditionalAddString("pool", obj.Pool, obj.Pool != "")
+	values.ConditionalAddBool(
+		"start",
+		true,
+		obj.Start || obj.WaitUntilReady,
+	)
+	values.ConditionalAddBool("unique", true, obj.Unique)
+	values.ConditionalAddBool(
+		"ignore-unpack-errors",
+		true,
+		obj.IgnoreUnpackErrors,
 	)
 
 	return values, nil
@@ -49,10 +105,23 @@ type Properties struct {
 
 	CPU    CPUProperties
 	Memory MemoryProperties
+
+	RootFS      RootFSProperties
+	MountPoints []MountPointProperties
+	Network     []NetworkInterfaceProperties
 }
 
+const (
+	maxMountPointPropertiesArrayCapacity       = 256
+	maxNetworkInterfacePropertiesArrayCapacity = 32
+)
+
 func NewProperties(props types.Properties) (obj Properties, err error) {
 	return obj, errors.ChainUntilFail(
+		func() (err error) {
+			obj.Properties, err = vm.NewProperties(props)
+			return err
+		},
 		func() (err error) {
 			obj.GlobalProperties, err = NewGlobalProperties(props)
 			return err
@@ -65,53 +134,254 @@ func NewProperties(props types.Properties) (obj Properties, err error) {
 			obj.Memory, err = NewMemoryProperties(props)
 			return err
 		},
+		func() (err error) {
+			var rootfs string
+			if err := props.SetRequiredString(mkRootFSProperty, &rootfs, nil); err != nil {
+				return err
+			}
+
+			obj.RootFS, err = NewRootFSProperties(rootfs)
+			return err
+		},
+		func() (err error) {
+			return forEachIndexedProperty(
+				props,
+				"mp",
+				maxMountPointPropertiesArrayCapacity,
+				func(i int, media string) error {
+					mp, err := NewMountPointProperties(i, media)
+					if err != nil {
+						return err
+					}
+
+					obj.MountPoints = append(obj.MountPoints, mp)
+					return nil
+				},
+			)
+		},
+		func() (err error) {
+			return forEachIndexedProperty(
+				props,
+				"net",
+				maxNetworkInterfacePropertiesArrayCapacity,
+				func(i int, media string) error {
+					network, err := NewNetworkInterfaceProperties(i, media)
+					if err != nil {
+						return err
+					}
+
+					obj.Network = append(obj.Network, network)
+					return nil
+				},
+			)
+		},
 	)
+}
+
+func forEachIndexedProperty(
+	props types.Properties,
+	prefix string,
+	capacity int,
+	fn func(i int, media string) error,
+) error {
+	for i := 0; i < capacity; i++ {
+		propName := fmt.Sprintf("%s%d", prefix, i)
+		prop, ok := props[propName]
+		if !ok {
+			continue
+		}
+
+		x, ok := prop.(string)
+		if !ok {
+			err := errors.ErrInvalidProperty
+			err.AddKey("name", propName)
+			err.AddKey("value", prop)
+			return err
+		}
 
-	return obj, nil
+		if err := fn(i, x); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (obj Properties) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	for _, f := range []func() (request.Values, error){
+		obj.Properties.MapToValues,
+		obj.GlobalProperties.MapToValues,
+		obj.CPU.MapToValues,
+		obj.Memory.MapToValues,
+	} {
+		v, err := f()
+		if err != nil {
+			return nil, err
+		}
+
+		for k, vv := range v {
+			values[k] = vv
+		}
+	}
+
+	if err := values.AddObject(mkRootFSProperty, obj.RootFS); err != nil {
+		return nil, err
+	}
+
+	for _, mp := range obj.MountPoints {
+		if err := values.AddObject(mp.Name(), mp); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, network := range obj.Network {
+		if err := values.AddObject(network.Name(), network); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// MapToUpdateValues serializes the properties like MapToValues, and also
+// appends a delete key with every property present in the previous
+// configuration that is missing in the new one, like detached mount points.
+// Unprivileged can only be set on creation, so it is never sent.
+func (obj Properties) MapToUpdateValues(
+	previous Properties,
+) (request.Values, error) {
+	values, err := obj.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	delete(values, mkGlobalPropertyUnprivileged)
+
+	previousValues, err := previous.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	values.AddDeleted(previousValues, "digest", mkGlobalPropertyUnprivileged)
+
+	return values, nil
 }
 
 type GlobalProperties struct {
 	OSType OSType
 
-	RootFSStorage string
-	RootFSSize    uint
+	Hostname     string
+	Unprivileged bool
+
+	Nameservers  []string
+	SearchDomain string
+
+	Features FeaturesProperties
 }
 
 const (
-	mkGlobalPropertyOSType = "ostype"
+	mkGlobalPropertyOSType       = "ostype"
+	mkGlobalPropertyHostname     = "hostname"
+	mkGlobalPropertyUnprivileged = "unprivileged"
+	mkGlobalPropertyNameservers  = "nameserver"
+	mkGlobalPropertySearchDomain = "searchdomain"
+	mkGlobalPropertyFeatures     = "features"
+
+	DefaultGlobalPropertyHostname     string = ""
+	DefaultGlobalPropertyUnprivileged bool   = false
+	DefaultGlobalPropertySearchDomain string = ""
 )
 
 func NewGlobalProperties(
 	props types.Properties,
 ) (obj GlobalProperties, err error) {
-	if err := props.SetRequiredFixedValue(mkGlobalPropertyOSType, &obj.OSType, nil); err != nil {
-		return obj, err
-	}
+	return obj, errors.ChainUntilFail(
+		func() error {
+			return props.SetRequiredFixedValue(
+				mkGlobalPropertyOSType,
+				&obj.OSType,
+				nil,
+			)
+		},
+		func() error {
+			return props.SetString(
+				mkGlobalPropertyHostname,
+				&obj.Hostname,
+				DefaultGlobalPropertyHostname,
+				nil,
+			)
+		},
+		func() error {
+			return props.SetBool(
+				mkGlobalPropertyUnprivileged,
+				&obj.Unprivileged,
+				DefaultGlobalPropertyUnprivileged,
+				nil,
+			)
+		},
+		func() error {
+			var nameservers string
+			if err := props.SetString(mkGlobalPropertyNameservers, &nameservers, "", nil); err != nil {
+				return err
+			}
 
-	return obj, nil
+			obj.Nameservers = strings.Fields(nameservers)
+			return nil
+		},
+		func() error {
+			return props.SetString(
+				mkGlobalPropertySearchDomain,
+				&obj.SearchDomain,
+				DefaultGlobalPropertySearchDomain,
+				nil,
+			)
+		},
+		func() (err error) {
+			var features string
+			if err := props.SetString(mkGlobalPropertyFeatures, &features, "", nil); err != nil || features == "" {
+				return err
+			}
+
+			obj.Features, err = NewFeaturesProperties(features)
+			return err
+		},
+	)
 }
 
-func (obj Properties) MapToValues() (request.Values, error) {
+func (obj GlobalProperties) MapToValues() (request.Values, error) {
 	values := request.Values{}
 
-	values.AddString(
-		"rootfs",
-		fmt.Sprintf("%s:%d", obj.RootFSStorage, obj.RootFSSize),
+	values.ConditionalAddObject(
+		mkGlobalPropertyOSType,
+		obj.OSType,
+		obj.OSType != "",
 	)
 
-	if cpuValues, err := obj.CPU.MapToValues(); err != nil {
-		return nil, err
-	} else {
-		for k, v := range cpuValues {
-			values[k] = v
-		}
-	}
+	values.ConditionalAddString(
+		mkGlobalPropertyHostname,
+		obj.Hostname,
+		obj.Hostname != DefaultGlobalPropertyHostname,
+	)
 
-	if memoryValues, err := obj.Memory.MapToValues(); err != nil {
-		return nil, err
-	} else {
-		for k, v := range memoryValues {
-			values[k] = v
+	values.AddBool(mkGlobalPropertyUnprivileged, obj.Unprivileged)
+
+	values.ConditionalAddString(
+		mkGlobalPropertyNameservers,
+		strings.Join(obj.Nameservers, " "),
+		len(obj.Nameservers) != 0,
+	)
+
+	values.ConditionalAddString(
+		mkGlobalPropertySearchDomain,
+		obj.SearchDomain,
+		obj.SearchDomain != DefaultGlobalPropertySearchDomain,
+	)
+
+	if !obj.Features.IsZero() {
+		if err := values.AddObject(mkGlobalPropertyFeatures, obj.Features); err != nil {
+			return nil, err
 		}
 	}
 
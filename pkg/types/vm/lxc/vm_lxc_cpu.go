@@ -79,6 +79,12 @@ func NewCPUProperties(props types.Properties) (obj CPUProperties, err error) {
 func (obj CPUProperties) MapToValues() (request.Values, error) {
 	values := request.Values{}
 
+	values.ConditionalAddObject(
+		mkCPUPropertyArchitecture,
+		obj.Architecture,
+		obj.Architecture != "",
+	)
+
 	cores := obj.Cores
 	if cores == 0 {
 		cores = 1
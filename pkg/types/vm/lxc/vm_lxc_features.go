@@ -0,0 +1,99 @@
+package lxc
+
+import (
+	"fmt"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+)
+
+type FeaturesProperties struct {
+	Nesting    bool
+	KeyCtl     bool
+	FUSE       bool
+	MKNod      bool
+	ForceRWSys bool
+
+	// MountTypes lists the filesystem types the container is allowed to
+	// mount, like nfs or cifs.
+	MountTypes []string
+}
+
+const (
+	mkFeaturesPropertyNesting    = "nesting"
+	mkFeaturesPropertyKeyCtl     = "keyctl"
+	mkFeaturesPropertyFUSE       = "fuse"
+	mkFeaturesPropertyMKNod      = "mknod"
+	mkFeaturesPropertyForceRWSys = "force_rw_sys"
+	mkFeaturesPropertyMountTypes = "mount"
+)
+
+func NewFeaturesProperties(media string) (obj FeaturesProperties, err error) {
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+	}
+
+	if err := (&props).Unmarshal(media); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		switch kv.Key() {
+		case mkFeaturesPropertyNesting:
+			obj.Nesting, err = kv.ValueAsBool()
+		case mkFeaturesPropertyKeyCtl:
+			obj.KeyCtl, err = kv.ValueAsBool()
+		case mkFeaturesPropertyFUSE:
+			obj.FUSE, err = kv.ValueAsBool()
+		case mkFeaturesPropertyMKNod:
+			obj.MKNod, err = kv.ValueAsBool()
+		case mkFeaturesPropertyForceRWSys:
+			obj.ForceRWSys, err = kv.ValueAsBool()
+		case mkFeaturesPropertyMountTypes:
+			obj.MountTypes = strings.Split(kv.Value(), ";")
+		default:
+			err = fmt.Errorf("unknown feature %s", kv.Key())
+		}
+
+		if err != nil {
+			return obj, err
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj FeaturesProperties) IsZero() bool {
+	return !obj.Nesting && !obj.KeyCtl && !obj.FUSE && !obj.MKNod &&
+		!obj.ForceRWSys && len(obj.MountTypes) == 0
+}
+
+func (obj FeaturesProperties) Marshal() (string, error) {
+	content := internal_types.PVEList{Separator: ","}
+
+	for _, kv := range []struct {
+		key   string
+		value bool
+	}{
+		{mkFeaturesPropertyNesting, obj.Nesting},
+		{mkFeaturesPropertyKeyCtl, obj.KeyCtl},
+		{mkFeaturesPropertyFUSE, obj.FUSE},
+		{mkFeaturesPropertyMKNod, obj.MKNod},
+		{mkFeaturesPropertyForceRWSys, obj.ForceRWSys},
+	} {
+		if kv.value {
+			content.Append(fmt.Sprintf("%s=1", kv.key))
+		}
+	}
+
+	if len(obj.MountTypes) != 0 {
+		content.Append(fmt.Sprintf(
+			"%s=%s",
+			mkFeaturesPropertyMountTypes,
+			strings.Join(obj.MountTypes, ";"),
+		))
+	}
+
+	return content.Marshal()
+}
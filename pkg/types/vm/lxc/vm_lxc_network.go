@@ -0,0 +1,200 @@
+package lxc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+)
+
+type NetworkInterfaceProperties struct {
+	DeviceNumber int
+
+	InterfaceName string
+	Bridge        string
+	MACAddress    string
+
+	// IPv4 is either an address in CIDR notation, "dhcp" or "manual".
+	IPv4        string
+	GatewayIPv4 string
+	// IPv6 is either an address in CIDR notation, "dhcp", "auto" or
+	// "manual".
+	IPv6        string
+	GatewayIPv6 string
+
+	VLAN   int
+	Trunks []uint
+	MTU    int
+
+	Enabled        bool
+	EnableFirewall bool
+
+	RateLimitMBps float64
+}
+
+const (
+	mkNetworkInterfacePropertyName        = "name"
+	mkNetworkInterfacePropertyBridge      = "bridge"
+	mkNetworkInterfacePropertyMACAddress  = "hwaddr"
+	mkNetworkInterfacePropertyIPv4        = "ip"
+	mkNetworkInterfacePropertyGatewayIPv4 = "gw"
+	mkNetworkInterfacePropertyIPv6        = "ip6"
+	mkNetworkInterfacePropertyGatewayIPv6 = "gw6"
+	mkNetworkInterfacePropertyVLAN        = "tag"
+	mkNetworkInterfacePropertyMTU         = "mtu"
+	mkNetworkInterfacePropertyLinkDown    = "link_down"
+	mkNetworkInterfacePropertyFirewall    = "firewall"
+	mkNetworkInterfacePropertyRate        = "rate"
+	mkNetworkInterfacePropertyType        = "type"
+	mkNetworkInterfacePropertyTrunks      = "trunks"
+)
+
+func (obj NetworkInterfaceProperties) Name() string {
+	return fmt.Sprintf("net%d", obj.DeviceNumber)
+}
+
+func NewNetworkInterfaceProperties(
+	deviceNumber int,
+	media string,
+) (obj NetworkInterfaceProperties, err error) {
+	obj.DeviceNumber = deviceNumber
+	obj.Enabled = true
+
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+	}
+
+	if err := (&props).Unmarshal(media); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		switch kv.Key() {
+		case mkNetworkInterfacePropertyName:
+			obj.InterfaceName = kv.Value()
+		case mkNetworkInterfacePropertyBridge:
+			obj.Bridge = kv.Value()
+		case mkNetworkInterfacePropertyMACAddress:
+			obj.MACAddress = kv.Value()
+		case mkNetworkInterfacePropertyIPv4:
+			obj.IPv4 = kv.Value()
+		case mkNetworkInterfacePropertyGatewayIPv4:
+			obj.GatewayIPv4 = kv.Value()
+		case mkNetworkInterfacePropertyIPv6:
+			obj.IPv6 = kv.Value()
+		case mkNetworkInterfacePropertyGatewayIPv6:
+			obj.GatewayIPv6 = kv.Value()
+		case mkNetworkInterfacePropertyVLAN:
+			if obj.VLAN, err = kv.ValueAsInt(); err != nil {
+				return obj, err
+			}
+		case mkNetworkInterfacePropertyMTU:
+			if obj.MTU, err = kv.ValueAsInt(); err != nil {
+				return obj, err
+			}
+		case mkNetworkInterfacePropertyLinkDown:
+			linkDown, err := kv.ValueAsBool()
+			if err != nil {
+				return obj, err
+			}
+
+			obj.Enabled = !linkDown
+		case mkNetworkInterfacePropertyFirewall:
+			if obj.EnableFirewall, err = kv.ValueAsBool(); err != nil {
+				return obj, err
+			}
+		case mkNetworkInterfacePropertyRate:
+			if obj.RateLimitMBps, err = strconv.ParseFloat(kv.Value(), 64); err != nil {
+				return obj, err
+			}
+		case mkNetworkInterfacePropertyType:
+			if kv.Value() != "veth" {
+				return obj, fmt.Errorf("unknown network type %s", kv.Value())
+			}
+		case mkNetworkInterfacePropertyTrunks:
+			trunks := internal_types.PVEList{Separator: ";"}
+			if err := (&trunks).Unmarshal(kv.Value()); err != nil {
+				return obj, err
+			}
+
+			for _, trunk := range trunks.List() {
+				vlan, err := strconv.ParseUint(trunk, 10, 64)
+				if err != nil {
+					return obj, err
+				}
+
+				obj.Trunks = append(obj.Trunks, uint(vlan))
+			}
+		default:
+			return obj, fmt.Errorf("unknown property %s", kv.Key())
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj NetworkInterfaceProperties) Marshal() (string, error) {
+	if obj.InterfaceName == "" {
+		return "", fmt.Errorf(
+			"network interface %s has no name",
+			obj.Name(),
+		)
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+
+	for _, kv := range []struct {
+		key   string
+		value string
+	}{
+		{mkNetworkInterfacePropertyName, obj.InterfaceName},
+		{mkNetworkInterfacePropertyBridge, obj.Bridge},
+		{mkNetworkInterfacePropertyMACAddress, obj.MACAddress},
+		{mkNetworkInterfacePropertyIPv4, obj.IPv4},
+		{mkNetworkInterfacePropertyGatewayIPv4, obj.GatewayIPv4},
+		{mkNetworkInterfacePropertyIPv6, obj.IPv6},
+		{mkNetworkInterfacePropertyGatewayIPv6, obj.GatewayIPv6},
+	} {
+		if kv.value != "" {
+			content.Append(fmt.Sprintf("%s=%s", kv.key, kv.value))
+		}
+	}
+
+	if obj.VLAN != 0 {
+		content.Append(fmt.Sprintf("tag=%d", obj.VLAN))
+	}
+
+	if len(obj.Trunks) != 0 {
+		trunks := make([]string, len(obj.Trunks))
+		for i, vlan := range obj.Trunks {
+			trunks[i] = strconv.FormatUint(uint64(vlan), 10)
+		}
+
+		content.Append(fmt.Sprintf("trunks=%s", strings.Join(trunks, ";")))
+	}
+
+	if obj.MTU != 0 {
+		content.Append(fmt.Sprintf("mtu=%d", obj.MTU))
+	}
+
+	if obj.EnableFirewall {
+		content.Append("firewall=1")
+	}
+
+	if !obj.Enabled {
+		content.Append("link_down=1")
+	}
+
+	if obj.RateLimitMBps != 0 {
+		content.Append(fmt.Sprintf(
+			"rate=%s",
+			strconv.FormatFloat(obj.RateLimitMBps, 'f', -1, 64),
+		))
+	}
+
+	content.Append("type=veth")
+
+	return content.Marshal()
+}
@@ -0,0 +1,75 @@
+package lxc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+)
+
+func TestNetworkInterfaceProperties(t *testing.T) {
+	options := map[string]struct {
+		Object lxc.NetworkInterfaceProperties
+		Value  string
+	}{
+		"DHCP": {
+			Object: lxc.NetworkInterfaceProperties{
+				DeviceNumber:  0,
+				InterfaceName: "eth0",
+				Bridge:        "vmbr0",
+				IPv4:          "dhcp",
+				IPv6:          "auto",
+				Enabled:       true,
+			},
+			Value: "name=eth0,bridge=vmbr0,ip=dhcp,ip6=auto,type=veth",
+		},
+		"Static": {
+			Object: lxc.NetworkInterfaceProperties{
+				DeviceNumber:   1,
+				InterfaceName:  "eth1",
+				Bridge:         "vmbr1",
+				MACAddress:     "AA:BB:CC:DD:EE:FF",
+				IPv4:           "10.0.0.2/24",
+				GatewayIPv4:    "10.0.0.1",
+				VLAN:           10,
+				Trunks:         []uint{20, 30},
+				MTU:            9000,
+				EnableFirewall: true,
+				RateLimitMBps:  12.5,
+			},
+			Value: "name=eth1,bridge=vmbr1,hwaddr=AA:BB:CC:DD:EE:FF,ip=10.0.0.2/24,gw=10.0.0.1,tag=10,trunks=20;30,mtu=9000,firewall=1,link_down=1,rate=12.5,type=veth",
+		},
+	}
+
+	for n, tt := range options {
+		tt := tt
+
+		t.Run(n, func(t *testing.T) {
+			t.Run("Marshal", func(t *testing.T) {
+				value, err := tt.Object.Marshal()
+				require.NoError(t, err)
+				assert.Equal(t, tt.Value, value)
+			})
+
+			t.Run("Unmarshal", func(t *testing.T) {
+				obj, err := lxc.NewNetworkInterfaceProperties(
+					tt.Object.DeviceNumber,
+					tt.Value,
+				)
+				require.NoError(t, err)
+				assert.Equal(t, tt.Object, obj)
+			})
+		})
+	}
+
+	t.Run("MissingName", func(t *testing.T) {
+		_, err := lxc.NetworkInterfaceProperties{Bridge: "vmbr0"}.Marshal()
+		assert.Error(t, err)
+	})
+
+	t.Run("UnknownType", func(t *testing.T) {
+		_, err := lxc.NewNetworkInterfaceProperties(0, "name=eth0,type=phys")
+		assert.Error(t, err)
+	})
+}
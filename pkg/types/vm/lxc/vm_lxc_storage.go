@@ -0,0 +1,245 @@
+package lxc
+
+import (
+	"fmt"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type VolumeProperties struct {
+	// Volume is either a storage volume, a host path for bind mounts, or
+	// STORAGE:SIZE_IN_GiB to allocate a new volume.
+	Volume string
+	Size   vm.DiskSize
+
+	// ACL overrides the filesystem default when not nil.
+	ACL       *bool
+	Quota     bool
+	ReadOnly  bool
+	Shared    bool
+	Replicate bool
+
+	MountOptions []string
+}
+
+const (
+	mkRootFSProperty = "rootfs"
+
+	mkVolumePropertyVolume       = "volume"
+	mkVolumePropertySize         = "size"
+	mkVolumePropertyACL          = "acl"
+	mkVolumePropertyQuota        = "quota"
+	mkVolumePropertyReadOnly     = "ro"
+	mkVolumePropertyShared       = "shared"
+	mkVolumePropertyReplicate    = "replicate"
+	mkVolumePropertyMountOptions = "mountoptions"
+	mkVolumePropertyMountPoint   = "mp"
+	mkVolumePropertyBackup       = "backup"
+
+	DefaultVolumePropertyReplicate bool = true
+)
+
+func (obj VolumeProperties) IsBindMount() bool {
+	return strings.HasPrefix(obj.Volume, "/")
+}
+
+func (obj *VolumeProperties) setProperty(kv internal_types.PVEKeyValue) (bool, error) {
+	var err error
+
+	switch {
+	case !kv.HasValue():
+		obj.Volume = kv.Key()
+	case kv.Key() == mkVolumePropertyVolume:
+		obj.Volume = kv.Value()
+	case kv.Key() == mkVolumePropertySize:
+		err = (&obj.Size).Unmarshal(kv.Value())
+	case kv.Key() == mkVolumePropertyACL:
+		var acl bool
+		if acl, err = kv.ValueAsBool(); err == nil {
+			obj.ACL = &acl
+		}
+	case kv.Key() == mkVolumePropertyQuota:
+		obj.Quota, err = kv.ValueAsBool()
+	case kv.Key() == mkVolumePropertyReadOnly:
+		obj.ReadOnly, err = kv.ValueAsBool()
+	case kv.Key() == mkVolumePropertyShared:
+		obj.Shared, err = kv.ValueAsBool()
+	case kv.Key() == mkVolumePropertyReplicate:
+		obj.Replicate, err = kv.ValueAsBool()
+	case kv.Key() == mkVolumePropertyMountOptions:
+		obj.MountOptions = strings.Split(kv.Value(), ";")
+	default:
+		return false, nil
+	}
+
+	return true, err
+}
+
+func (obj VolumeProperties) marshal(extra ...string) (string, error) {
+	if obj.Volume == "" {
+		return "", fmt.Errorf("volume is required")
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(obj.Volume)
+
+	for _, e := range extra {
+		content.Append(e)
+	}
+
+	if obj.Size.Bytes != 0 {
+		size, err := obj.Size.Marshal()
+		if err != nil {
+			return "", err
+		}
+
+		content.Append(fmt.Sprintf("%s=%s", mkVolumePropertySize, size))
+	}
+
+	if obj.ACL != nil {
+		content.Append(fmt.Sprintf(
+			"%s=%s",
+			mkVolumePropertyACL,
+			internal_types.PVEBool(*obj.ACL).String(),
+		))
+	}
+
+	if obj.Quota {
+		content.Append(fmt.Sprintf("%s=1", mkVolumePropertyQuota))
+	}
+
+	if obj.ReadOnly {
+		content.Append(fmt.Sprintf("%s=1", mkVolumePropertyReadOnly))
+	}
+
+	if obj.Shared {
+		content.Append(fmt.Sprintf("%s=1", mkVolumePropertyShared))
+	}
+
+	if obj.Replicate != DefaultVolumePropertyReplicate {
+		content.Append(fmt.Sprintf("%s=0", mkVolumePropertyReplicate))
+	}
+
+	if len(obj.MountOptions) != 0 {
+		content.Append(fmt.Sprintf(
+			"%s=%s",
+			mkVolumePropertyMountOptions,
+			strings.Join(obj.MountOptions, ";"),
+		))
+	}
+
+	return content.Marshal()
+}
+
+func newVolumeDictionary(media string) (internal_types.PVEDictionary, error) {
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      true,
+	}
+
+	return props, (&props).Unmarshal(media)
+}
+
+type RootFSProperties struct {
+	VolumeProperties
+}
+
+func NewRootFSProperties(media string) (RootFSProperties, error) {
+	obj := RootFSProperties{
+		VolumeProperties: VolumeProperties{
+			Replicate: DefaultVolumePropertyReplicate,
+		},
+	}
+
+	props, err := newVolumeDictionary(media)
+	if err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		if ok, err := obj.setProperty(kv); err != nil {
+			return obj, err
+		} else if !ok {
+			return obj, fmt.Errorf("unknown property %s", kv.Key())
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj RootFSProperties) Marshal() (string, error) {
+	return obj.VolumeProperties.marshal()
+}
+
+type MountPointProperties struct {
+	DeviceNumber int
+
+	VolumeProperties
+
+	MountPoint string
+	Backup     bool
+}
+
+func (obj MountPointProperties) Name() string {
+	return fmt.Sprintf("mp%d", obj.DeviceNumber)
+}
+
+func NewMountPointProperties(
+	deviceNumber int,
+	media string,
+) (MountPointProperties, error) {
+	obj := MountPointProperties{
+		DeviceNumber: deviceNumber,
+		VolumeProperties: VolumeProperties{
+			Replicate: DefaultVolumePropertyReplicate,
+		},
+	}
+
+	props, err := newVolumeDictionary(media)
+	if err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		if ok, err := obj.setProperty(kv); err != nil {
+			return obj, err
+		} else if ok {
+			continue
+		}
+
+		switch kv.Key() {
+		case mkVolumePropertyMountPoint:
+			obj.MountPoint = kv.Value()
+		case mkVolumePropertyBackup:
+			if obj.Backup, err = kv.ValueAsBool(); err != nil {
+				return obj, err
+			}
+		default:
+			return obj, fmt.Errorf("unknown property %s", kv.Key())
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj MountPointProperties) Marshal() (string, error) {
+	if obj.MountPoint == "" {
+		return "", fmt.Errorf(
+			"mount point %s has no path",
+			obj.Name(),
+		)
+	}
+
+	extra := []string{
+		fmt.Sprintf("%s=%s", mkVolumePropertyMountPoint, obj.MountPoint),
+	}
+
+	if obj.Backup {
+		extra = append(extra, fmt.Sprintf("%s=1", mkVolumePropertyBackup))
+	}
+
+	return obj.VolumeProperties.marshal(extra...)
+}
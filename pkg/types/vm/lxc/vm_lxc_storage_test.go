@@ -0,0 +1,109 @@
+package lxc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+)
+
+func TestRootFSProperties(t *testing.T) {
+	acl := true
+
+	obj := lxc.RootFSProperties{
+		VolumeProperties: lxc.VolumeProperties{
+			Volume:       "local-lvm:vm-100-disk-0",
+			ACL:          &acl,
+			Quota:        true,
+			MountOptions: []string{"noatime", "nodev"},
+		},
+	}
+
+	value := "local-lvm:vm-100-disk-0,acl=1,quota=1,replicate=0,mountoptions=noatime;nodev"
+
+	t.Run("Marshal", func(t *testing.T) {
+		v, err := obj.Marshal()
+		require.NoError(t, err)
+		assert.Equal(t, value, v)
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		v, err := lxc.NewRootFSProperties(value)
+		require.NoError(t, err)
+		assert.Equal(t, obj, v)
+	})
+
+	t.Run("MountPointKey", func(t *testing.T) {
+		_, err := lxc.NewRootFSProperties("local-lvm:vm-100-disk-0,mp=/data")
+		assert.Error(t, err)
+	})
+}
+
+func TestMountPointProperties(t *testing.T) {
+	options := map[string]struct {
+		Object lxc.MountPointProperties
+		Value  string
+	}{
+		"Volume": {
+			Object: lxc.MountPointProperties{
+				DeviceNumber: 0,
+				VolumeProperties: lxc.VolumeProperties{
+					Volume:    "local-lvm:vm-100-disk-1",
+					Replicate: true,
+				},
+				MountPoint: "/data",
+				Backup:     true,
+			},
+			Value: "local-lvm:vm-100-disk-1,mp=/data,backup=1",
+		},
+		"BindMount": {
+			Object: lxc.MountPointProperties{
+				DeviceNumber: 1,
+				VolumeProperties: lxc.VolumeProperties{
+					Volume:    "/srv/share",
+					ReadOnly:  true,
+					Shared:    true,
+					Replicate: true,
+				},
+				MountPoint: "/share",
+			},
+			Value: "/srv/share,mp=/share,ro=1,shared=1",
+		},
+	}
+
+	for n, tt := range options {
+		tt := tt
+
+		t.Run(n, func(t *testing.T) {
+			t.Run("Marshal", func(t *testing.T) {
+				value, err := tt.Object.Marshal()
+				require.NoError(t, err)
+				assert.Equal(t, tt.Value, value)
+			})
+
+			t.Run("Unmarshal", func(t *testing.T) {
+				obj, err := lxc.NewMountPointProperties(
+					tt.Object.DeviceNumber,
+					tt.Value,
+				)
+				require.NoError(t, err)
+				assert.Equal(t, tt.Object, obj)
+			})
+		})
+	}
+
+	t.Run("ExplicitVolumeKey", func(t *testing.T) {
+		obj, err := lxc.NewMountPointProperties(0, "volume=local:10,mp=/data")
+		require.NoError(t, err)
+		assert.Equal(t, "local:10", obj.Volume)
+		assert.False(t, obj.IsBindMount())
+	})
+
+	t.Run("MissingMountPoint", func(t *testing.T) {
+		_, err := lxc.MountPointProperties{
+			VolumeProperties: lxc.VolumeProperties{Volume: "local:10"},
+		}.Marshal()
+		assert.Error(t, err)
+	})
+}
@@ -54,3 +54,116 @@ func TestGlobalProperties(t *testing.T) {
 	// 	),
 	// )
 }
+
+func TestProperties(t *testing.T) {
+	props := test.HelperCreatePropertiesMap(types.Properties{
+		"description":  "test_description",
+		"onboot":       1,
+		"ostype":       "debian",
+		"hostname":     "test_hostname",
+		"unprivileged": 1,
+		"nameserver":   "1.1.1.1 8.8.8.8",
+		"searchdomain": "example.com",
+		"features":     "nesting=1,keyctl=1,mount=nfs;cifs",
+		"arch":         "amd64",
+		"cores":        2,
+		"memory":       1024,
+		"swap":         512,
+		"rootfs":       "local-lvm:vm-100-disk-0,size=8G",
+		"mp0":          "local-lvm:vm-100-disk-1,mp=/data,backup=1,size=32G",
+		"mp1":          "/srv/share,mp=/share,ro=1",
+		"net0":         "name=eth0,bridge=vmbr0,hwaddr=AA:BB:CC:DD:EE:FF,ip=dhcp,type=veth",
+		"digest":       "0000000000000000000000000000000000000000",
+	})
+
+	obj, err := lxc.NewProperties(props)
+	require.NoError(t, err)
+
+	t.Run("Create", func(t *testing.T) {
+		assert.Equal(t, "test_hostname", obj.Hostname)
+		assert.True(t, obj.Unprivileged)
+		assert.Equal(t, []string{"1.1.1.1", "8.8.8.8"}, obj.Nameservers)
+		assert.True(t, obj.Features.Nesting)
+		assert.Equal(t, []string{"nfs", "cifs"}, obj.Features.MountTypes)
+		assert.Equal(t, "local-lvm:vm-100-disk-0", obj.RootFS.Volume)
+
+		require.Len(t, obj.MountPoints, 2)
+		require.Len(t, obj.Network, 1)
+
+		assert.Equal(t, "mp0", obj.MountPoints[0].Name())
+		assert.True(t, obj.MountPoints[0].Backup)
+		assert.True(t, obj.MountPoints[1].IsBindMount())
+		assert.True(t, obj.MountPoints[1].ReadOnly)
+		assert.Equal(t, "eth0", obj.Network[0].InterfaceName)
+	})
+
+	t.Run("MapToValues", func(t *testing.T) {
+		values, err := obj.MapToValues()
+		require.NoError(t, err)
+
+		expectedValues := map[string]string{
+			"description":  "test_description",
+			"protection":   "0",
+			"onboot":       "1",
+			"ostype":       "debian",
+			"hostname":     "test_hostname",
+			"unprivileged": "1",
+			"nameserver":   "1.1.1.1 8.8.8.8",
+			"searchdomain": "example.com",
+			"features":     "nesting=1,keyctl=1,mount=nfs;cifs",
+			"arch":         "amd64",
+			"cores":        "2",
+			"cpuunits":     "1024",
+			"memory":       "1024",
+			"swap":         "512",
+			"rootfs":       "local-lvm:vm-100-disk-0,size=8G",
+			"mp0":          "local-lvm:vm-100-disk-1,mp=/data,backup=1,size=32G",
+			"mp1":          "/srv/share,mp=/share,ro=1",
+			"net0":         "name=eth0,bridge=vmbr0,hwaddr=AA:BB:CC:DD:EE:FF,ip=dhcp,type=veth",
+			"digest":       "0000000000000000000000000000000000000000",
+		}
+
+		for k, v := range expectedValues {
+			assert.Equal(t, []string{v}, values[k], k)
+		}
+
+		reparsedProps := make(types.Properties, len(values))
+		for k, v := range props {
+			if _, ok := values[k]; ok {
+				reparsedProps[k] = v
+			}
+		}
+
+		reparsed, err := lxc.NewProperties(reparsedProps)
+		require.NoError(t, err)
+		assert.Equal(t, obj, reparsed)
+	})
+
+	t.Run("MapToUpdateValues", func(t *testing.T) {
+		updated := obj
+		updated.SearchDomain = ""
+		updated.MountPoints = updated.MountPoints[:1]
+
+		values, err := updated.MapToUpdateValues(obj)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"mp1,searchdomain"}, values["delete"])
+		assert.NotContains(t, values, "unprivileged")
+	})
+
+	t.Run("CreateOptions", func(t *testing.T) {
+		values, err := lxc.CreateOptions{
+			OSTemplateStorage: "local",
+			OSTemplate:        "debian-12-standard_12.2-1_amd64.tar.zst",
+			Properties:        obj,
+		}.MapToValues()
+		require.NoError(t, err)
+
+		assert.NotContains(t, values, "digest")
+		assert.Equal(
+			t,
+			[]string{"local:vztmpl/debian-12-standard_12.2-1_amd64.tar.zst"},
+			values["ostemplate"],
+		)
+	})
+}
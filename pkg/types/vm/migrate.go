@@ -0,0 +1,65 @@
+package vm
+
+import (
+	"time"
+)
+
+type MigrateOptions struct {
+	Online         bool
+	WithLocalDisks bool
+
+	TargetStorage  string
+	StorageMapping map[string]string
+
+	BandwidthLimit   uint
+	MigrationNetwork string
+
+	Restart        bool
+	RestartTimeout time.Duration
+}
+
+type MigrationPreconditions struct {
+	Running bool
+
+	AllowedNodes    []string
+	NotAllowedNodes map[string][]string
+
+	LocalDisks     []MigrationLocalDisk
+	LocalResources []string
+}
+
+type MigrationLocalDisk struct {
+	VolumeID  string
+	DriveName string
+	Size      uint64
+
+	CDROM  bool
+	Unused bool
+}
+
+func (obj MigrationPreconditions) IsAllowedNode(node string) bool {
+	for _, n := range obj.AllowedNodes {
+		if n == node {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (obj MigrationPreconditions) BlockingDisks(withLocalDisks bool) []MigrationLocalDisk {
+	var disks []MigrationLocalDisk
+
+	for _, disk := range obj.LocalDisks {
+		if disk.CDROM || !withLocalDisks {
+			disks = append(disks, disk)
+		}
+	}
+
+	return disks
+}
+
+func (obj MigrationPreconditions) IsBlocked(withLocalDisks bool) bool {
+	return len(obj.LocalResources) != 0 ||
+		len(obj.BlockingDisks(withLocalDisks)) != 0
+}
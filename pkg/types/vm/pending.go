@@ -0,0 +1,73 @@
+package vm
+
+import (
+	"sort"
+
+	"github.com/xabinapal/gopve/pkg/types"
+)
+
+type PendingChange struct {
+	Key string
+
+	Value        interface{}
+	PendingValue interface{}
+
+	HasValue        bool
+	HasPendingValue bool
+
+	Delete      bool
+	ForceDelete bool
+}
+
+func (obj PendingChange) IsPending() bool {
+	return obj.HasPendingValue || obj.Delete
+}
+
+type PendingChanges []PendingChange
+
+func (obj PendingChanges) Keys() []string {
+	keys := []string{}
+
+	for _, change := range obj {
+		if change.IsPending() {
+			keys = append(keys, change.Key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (obj PendingChanges) RebootRequired() bool {
+	return len(obj.Keys()) != 0
+}
+
+func (obj PendingChanges) Current() types.Properties {
+	props := make(types.Properties, len(obj))
+
+	for _, change := range obj {
+		if change.HasValue {
+			props[change.Key] = change.Value
+		}
+	}
+
+	return props
+}
+
+func (obj PendingChanges) Pending() types.Properties {
+	props := make(types.Properties, len(obj))
+
+	for _, change := range obj {
+		switch {
+		case change.Delete:
+			continue
+		case change.HasPendingValue:
+			props[change.Key] = change.PendingValue
+		case change.HasValue:
+			props[change.Key] = change.Value
+		}
+	}
+
+	return props
+}
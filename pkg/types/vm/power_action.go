@@ -0,0 +1,63 @@
+package vm
+
+import (
+	"encoding/json"
+)
+
+type PowerAction string
+
+const (
+	PowerActionStart    PowerAction = "start"
+	PowerActionStop     PowerAction = "stop"
+	PowerActionShutdown PowerAction = "shutdown"
+	PowerActionReboot   PowerAction = "reboot"
+	PowerActionSuspend  PowerAction = "suspend"
+	PowerActionResume   PowerAction = "resume"
+)
+
+func (obj PowerAction) IsValid() bool {
+	switch obj {
+	case PowerActionStart,
+		PowerActionStop,
+		PowerActionShutdown,
+		PowerActionReboot,
+		PowerActionSuspend,
+		PowerActionResume:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsStopping reports whether the action takes guests down, which reverses
+// their startup order.
+func (obj PowerAction) IsStopping() bool {
+	switch obj {
+	case PowerActionStop, PowerActionShutdown, PowerActionSuspend:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj PowerAction) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj PowerAction) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *PowerAction) Unmarshal(s string) error {
+	*obj = PowerAction(s)
+	return nil
+}
+
+func (obj *PowerAction) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,45 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestPowerAction(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*vm.PowerAction)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Start": {
+				Object: vm.PowerActionStart,
+				Value:  "start",
+			},
+			"Stop": {
+				Object: vm.PowerActionStop,
+				Value:  "stop",
+			},
+			"Shutdown": {
+				Object: vm.PowerActionShutdown,
+				Value:  "shutdown",
+			},
+			"Reboot": {
+				Object: vm.PowerActionReboot,
+				Value:  "reboot",
+			},
+			"Suspend": {
+				Object: vm.PowerActionSuspend,
+				Value:  "suspend",
+			},
+			"Resume": {
+				Object: vm.PowerActionResume,
+				Value:  "resume",
+			},
+		},
+	)
+}
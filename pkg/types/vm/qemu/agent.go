@@ -0,0 +1,133 @@
+package qemu
+
+import (
+	"net"
+	"time"
+)
+
+type GuestAgent interface {
+	Ping() error
+
+	GetOSInfo() (AgentOSInfo, error)
+	GetNetworkInterfaces() ([]AgentNetworkInterface, error)
+	GetFilesystems() ([]AgentFilesystem, error)
+
+	FreezeFilesystems() (uint, error)
+	ThawFilesystems() (uint, error)
+
+	SetUserPassword(username, password string, crypted bool) error
+	Shutdown() error
+
+	Exec(opts AgentExecOptions) (uint, error)
+	GetExecStatus(pid uint) (AgentExecStatus, error)
+	Run(opts AgentExecOptions) (AgentExecStatus, error)
+
+	ReadFile(path string) (AgentFile, error)
+	WriteFile(path string, content []byte) error
+}
+
+type AgentOSInfo struct {
+	ID         string
+	Name       string
+	PrettyName string
+
+	Version   string
+	VersionID string
+
+	KernelRelease string
+	KernelVersion string
+	Machine       string
+}
+
+type AgentNetworkInterface struct {
+	Name       string
+	MACAddress net.HardwareAddr
+
+	IPAddresses []AgentIPAddress
+}
+
+func (obj AgentNetworkInterface) IPv4Addresses() []net.IP {
+	var ips []net.IP
+
+	for _, addr := range obj.IPAddresses {
+		if ip := addr.IP.To4(); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+
+	return ips
+}
+
+func (obj AgentNetworkInterface) IPv6Addresses() []net.IP {
+	var ips []net.IP
+
+	for _, addr := range obj.IPAddresses {
+		if addr.IP.To4() == nil {
+			ips = append(ips, addr.IP)
+		}
+	}
+
+	return ips
+}
+
+type AgentIPAddress struct {
+	IP     net.IP
+	Prefix uint
+}
+
+func (obj AgentIPAddress) IPNet() net.IPNet {
+	bits := 8 * net.IPv6len
+	if obj.IP.To4() != nil {
+		bits = 8 * net.IPv4len
+	}
+
+	return net.IPNet{
+		IP:   obj.IP,
+		Mask: net.CIDRMask(int(obj.Prefix), bits),
+	}
+}
+
+type AgentFilesystem struct {
+	Name       string
+	Mountpoint string
+	Type       string
+
+	TotalBytes uint64
+	UsedBytes  uint64
+
+	Disks []AgentFilesystemDisk
+}
+
+type AgentFilesystemDisk struct {
+	Device  string
+	Serial  string
+	BusType string
+}
+
+type AgentExecOptions struct {
+	Command []string
+	Input   []byte
+
+	PollingInterval time.Duration
+	Timeout         time.Duration
+}
+
+const (
+	DefaultAgentExecPollingInterval = time.Duration(1) * time.Second
+)
+
+type AgentExecStatus struct {
+	Exited   bool
+	ExitCode int
+	Signal   int
+
+	Stdout          []byte
+	StdoutTruncated bool
+	Stderr          []byte
+	StderrTruncated bool
+}
+
+type AgentFile struct {
+	Content   []byte
+	Truncated bool
+}
@@ -0,0 +1,43 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type AgentType string
+
+const (
+	AgentTypeVirtIO AgentType = "virtio"
+	AgentTypeISA    AgentType = "isa"
+)
+
+func (obj AgentType) IsValid() bool {
+	switch obj {
+	case AgentTypeVirtIO, AgentTypeISA:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj AgentType) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj AgentType) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *AgentType) Unmarshal(s string) error {
+	*obj = AgentType(s)
+	return nil
+}
+
+func (obj *AgentType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
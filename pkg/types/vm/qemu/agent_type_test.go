@@ -0,0 +1,29 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestAgentType(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.AgentType)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"VirtIO": {
+				Object: qemu.AgentTypeVirtIO,
+				Value:  "virtio",
+			},
+			"ISA": {
+				Object: qemu.AgentTypeISA,
+				Value:  "isa",
+			},
+		},
+	)
+}
@@ -0,0 +1,44 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type AudioDevice string
+
+const (
+	AudioDeviceICH9     AudioDevice = "ich9-intel-hda"
+	AudioDeviceIntelHDA AudioDevice = "intel-hda"
+	AudioDeviceAC97     AudioDevice = "AC97"
+)
+
+func (obj AudioDevice) IsValid() bool {
+	switch obj {
+	case AudioDeviceICH9, AudioDeviceIntelHDA, AudioDeviceAC97:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj AudioDevice) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj AudioDevice) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *AudioDevice) Unmarshal(s string) error {
+	*obj = AudioDevice(s)
+	return nil
+}
+
+func (obj *AudioDevice) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
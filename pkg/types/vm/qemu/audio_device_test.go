@@ -0,0 +1,33 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestAudioDevice(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.AudioDevice)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"ICH9": {
+				Object: qemu.AudioDeviceICH9,
+				Value:  "ich9-intel-hda",
+			},
+			"IntelHDA": {
+				Object: qemu.AudioDeviceIntelHDA,
+				Value:  "intel-hda",
+			},
+			"AC97": {
+				Object: qemu.AudioDeviceAC97,
+				Value:  "AC97",
+			},
+		},
+	)
+}
@@ -0,0 +1,43 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type AudioDriver string
+
+const (
+	AudioDriverSPICE AudioDriver = "spice"
+	AudioDriverNone  AudioDriver = "none"
+)
+
+func (obj AudioDriver) IsValid() bool {
+	switch obj {
+	case AudioDriverSPICE, AudioDriverNone:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj AudioDriver) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj AudioDriver) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *AudioDriver) Unmarshal(s string) error {
+	*obj = AudioDriver(s)
+	return nil
+}
+
+func (obj *AudioDriver) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
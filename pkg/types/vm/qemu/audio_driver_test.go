@@ -0,0 +1,29 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestAudioDriver(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.AudioDriver)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"SPICE": {
+				Object: qemu.AudioDriverSPICE,
+				Value:  "spice",
+			},
+			"None": {
+				Object: qemu.AudioDriverNone,
+				Value:  "none",
+			},
+		},
+	)
+}
@@ -0,0 +1,81 @@
+package qemu
+
+import (
+	"fmt"
+
+	"github.com/xabinapal/gopve/pkg/types/storage"
+)
+
+type CloudImageOptions struct {
+	VMID uint
+	Node string
+
+	// Download fetches the image into DownloadStorage before importing it.
+	// When nil, Disk.ImportFrom must point to an existing volume or to an
+	// absolute path on the node.
+	Download        *storage.DownloadURLOptions
+	DownloadStorage string
+
+	// Disk is where the image is imported to. Only its bus, number and
+	// storage name are required.
+	Disk HardDriveProperties
+
+	// CloudInitStorage allocates a cloud-init drive on ide2 when set.
+	CloudInitStorage string
+
+	Template bool
+
+	Properties Properties
+}
+
+const (
+	cloudImageCloudInitBus          = BusIDE
+	cloudImageCloudInitDeviceNumber = 2
+)
+
+// CreateOptions returns the options that create the guest with the image
+// imported from the given volume, the cloud-init drive attached and the
+// imported disk first in the boot order.
+func (obj CloudImageOptions) CreateOptions(
+	importFrom string,
+) (CreateOptions, error) {
+	if importFrom == "" {
+		return CreateOptions{}, fmt.Errorf("cloud image source is required")
+	}
+
+	disk := obj.Disk
+	disk.StorageFile = "0"
+	disk.ImportFrom = importFrom
+
+	props := obj.Properties
+
+	props.Storage.HardDrives = append(
+		append([]HardDriveProperties{}, props.Storage.HardDrives...),
+		disk,
+	)
+
+	if obj.CloudInitStorage != "" {
+		props.Storage.CDROMs = append(
+			append([]CDROMProperties{}, props.Storage.CDROMs...),
+			CDROMProperties{
+				DeviceBus:    cloudImageCloudInitBus,
+				DeviceNumber: cloudImageCloudInitDeviceNumber,
+				DriveStorageProperties: DriveStorageProperties{
+					StorageName: obj.CloudInitStorage,
+					StorageFile: CloudInitVolume,
+				},
+				Source: CDROMSourceCloudInit,
+			},
+		)
+	}
+
+	if len(props.BootOrder) == 0 {
+		props.BootOrder = []string{disk.Name()}
+	}
+
+	return CreateOptions{
+		VMID:       obj.VMID,
+		Node:       obj.Node,
+		Properties: props,
+	}, nil
+}
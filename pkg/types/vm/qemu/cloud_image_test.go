@@ -0,0 +1,58 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+func TestCloudImageOptions(t *testing.T) {
+	opts := qemu.CloudImageOptions{
+		VMID: 100,
+		Node: "test_node",
+		Disk: qemu.HardDriveProperties{
+			DeviceBus:    qemu.BusSCSI,
+			DeviceNumber: 0,
+			DriveStorageProperties: qemu.DriveStorageProperties{
+				StorageName: "local-lvm",
+			},
+			Backup:    true,
+			Replicate: true,
+		},
+		CloudInitStorage: "local-lvm",
+	}
+
+	t.Run("CreateOptions", func(t *testing.T) {
+		createOpts, err := opts.CreateOptions("local:import/debian-12.qcow2")
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(100), createOpts.VMID)
+		assert.Equal(t, "test_node", createOpts.Node)
+		assert.Equal(t, []string{"scsi0"}, createOpts.Properties.BootOrder)
+
+		require.Len(t, createOpts.Properties.Storage.HardDrives, 1)
+		require.Len(t, createOpts.Properties.Storage.CDROMs, 1)
+
+		disk, err := createOpts.Properties.Storage.HardDrives[0].Marshal()
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			"local-lvm:0,import-from=local:import/debian-12.qcow2",
+			disk,
+		)
+
+		cloudInit := createOpts.Properties.Storage.CDROMs[0]
+		assert.Equal(t, "ide2", cloudInit.Name())
+
+		cdrom, err := cloudInit.Marshal()
+		require.NoError(t, err)
+		assert.Equal(t, "local-lvm:cloudinit,media=cdrom", cdrom)
+	})
+
+	t.Run("MissingSource", func(t *testing.T) {
+		_, err := opts.CreateOptions("")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,46 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type CloudInitDumpType string
+
+const (
+	CloudInitDumpTypeUser    CloudInitDumpType = "user"
+	CloudInitDumpTypeNetwork CloudInitDumpType = "network"
+	CloudInitDumpTypeMeta    CloudInitDumpType = "meta"
+)
+
+func (obj CloudInitDumpType) IsValid() bool {
+	switch obj {
+	case CloudInitDumpTypeUser,
+		CloudInitDumpTypeNetwork,
+		CloudInitDumpTypeMeta:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj CloudInitDumpType) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj CloudInitDumpType) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *CloudInitDumpType) Unmarshal(s string) error {
+	*obj = CloudInitDumpType(s)
+	return nil
+}
+
+func (obj *CloudInitDumpType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
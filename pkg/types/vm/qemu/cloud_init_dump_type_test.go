@@ -0,0 +1,33 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestCloudInitDumpType(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.CloudInitDumpType)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"User": {
+				Object: qemu.CloudInitDumpTypeUser,
+				Value:  "user",
+			},
+			"Network": {
+				Object: qemu.CloudInitDumpTypeNetwork,
+				Value:  "network",
+			},
+			"Meta": {
+				Object: qemu.CloudInitDumpTypeMeta,
+				Value:  "meta",
+			},
+		},
+	)
+}
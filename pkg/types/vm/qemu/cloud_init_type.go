@@ -0,0 +1,46 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type CloudInitType string
+
+const (
+	CloudInitTypeNoCloud      CloudInitType = "nocloud"
+	CloudInitTypeConfigDrive2 CloudInitType = "configdrive2"
+	CloudInitTypeOpenNebula   CloudInitType = "opennebula"
+)
+
+func (obj CloudInitType) IsValid() bool {
+	switch obj {
+	case CloudInitTypeNoCloud,
+		CloudInitTypeConfigDrive2,
+		CloudInitTypeOpenNebula:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj CloudInitType) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj CloudInitType) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *CloudInitType) Unmarshal(s string) error {
+	*obj = CloudInitType(s)
+	return nil
+}
+
+func (obj *CloudInitType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
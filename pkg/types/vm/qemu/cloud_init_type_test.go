@@ -0,0 +1,33 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestCloudInitType(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.CloudInitType)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"NoCloud": {
+				Object: qemu.CloudInitTypeNoCloud,
+				Value:  "nocloud",
+			},
+			"ConfigDrive2": {
+				Object: qemu.CloudInitTypeConfigDrive2,
+				Value:  "configdrive2",
+			},
+			"OpenNebula": {
+				Object: qemu.CloudInitTypeOpenNebula,
+				Value:  "opennebula",
+			},
+		},
+	)
+}
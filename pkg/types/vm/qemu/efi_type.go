@@ -0,0 +1,43 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type EFIType string
+
+const (
+	EFIType2M EFIType = "2m"
+	EFIType4M EFIType = "4m"
+)
+
+func (obj EFIType) IsValid() bool {
+	switch obj {
+	case EFIType2M, EFIType4M:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj EFIType) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj EFIType) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *EFIType) Unmarshal(s string) error {
+	*obj = EFIType(s)
+	return nil
+}
+
+func (obj *EFIType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,29 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestEFIType(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.EFIType)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"2M": {
+				Object: qemu.EFIType2M,
+				Value:  "2m",
+			},
+			"4M": {
+				Object: qemu.EFIType4M,
+				Value:  "4m",
+			},
+		},
+	)
+}
@@ -0,0 +1,12 @@
+package qemu
+
+import "github.com/xabinapal/gopve/pkg/types/errors"
+
+const (
+	ErrAgentNotEnabled = errors.ClientError(
+		"500 - qemu guest agent is not enabled!",
+	)
+	ErrAgentExecTimeout = errors.ClientError(
+		"500 - qemu guest agent command timed out!",
+	)
+)
@@ -0,0 +1,52 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type HotplugDevice string
+
+const (
+	HotplugDeviceNetwork   HotplugDevice = "network"
+	HotplugDeviceDisk      HotplugDevice = "disk"
+	HotplugDeviceCPU       HotplugDevice = "cpu"
+	HotplugDeviceMemory    HotplugDevice = "memory"
+	HotplugDeviceUSB       HotplugDevice = "usb"
+	HotplugDeviceCloudInit HotplugDevice = "cloudinit"
+)
+
+func (obj HotplugDevice) IsValid() bool {
+	switch obj {
+	case HotplugDeviceNetwork,
+		HotplugDeviceDisk,
+		HotplugDeviceCPU,
+		HotplugDeviceMemory,
+		HotplugDeviceUSB,
+		HotplugDeviceCloudInit:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj HotplugDevice) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj HotplugDevice) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *HotplugDevice) Unmarshal(s string) error {
+	*obj = HotplugDevice(s)
+	return nil
+}
+
+func (obj *HotplugDevice) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
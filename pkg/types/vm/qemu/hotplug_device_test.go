@@ -0,0 +1,45 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestHotplugDevice(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.HotplugDevice)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Network": {
+				Object: qemu.HotplugDeviceNetwork,
+				Value:  "network",
+			},
+			"Disk": {
+				Object: qemu.HotplugDeviceDisk,
+				Value:  "disk",
+			},
+			"CPU": {
+				Object: qemu.HotplugDeviceCPU,
+				Value:  "cpu",
+			},
+			"Memory": {
+				Object: qemu.HotplugDeviceMemory,
+				Value:  "memory",
+			},
+			"USB": {
+				Object: qemu.HotplugDeviceUSB,
+				Value:  "usb",
+			},
+			"CloudInit": {
+				Object: qemu.HotplugDeviceCloudInit,
+				Value:  "cloudinit",
+			},
+		},
+	)
+}
@@ -0,0 +1,44 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type HugePageSize string
+
+const (
+	HugePageSizeAny HugePageSize = "any"
+	HugePageSize2M  HugePageSize = "2"
+	HugePageSize1G  HugePageSize = "1024"
+)
+
+func (obj HugePageSize) IsValid() bool {
+	switch obj {
+	case HugePageSizeAny, HugePageSize2M, HugePageSize1G:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj HugePageSize) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj HugePageSize) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *HugePageSize) Unmarshal(s string) error {
+	*obj = HugePageSize(s)
+	return nil
+}
+
+func (obj *HugePageSize) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
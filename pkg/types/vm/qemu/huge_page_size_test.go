@@ -0,0 +1,33 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestHugePageSize(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.HugePageSize)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Any": {
+				Object: qemu.HugePageSizeAny,
+				Value:  "any",
+			},
+			"2M": {
+				Object: qemu.HugePageSize2M,
+				Value:  "2",
+			},
+			"1G": {
+				Object: qemu.HugePageSize1G,
+				Value:  "1024",
+			},
+		},
+	)
+}
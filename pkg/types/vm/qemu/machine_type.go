@@ -0,0 +1,44 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type MachineType string
+
+const (
+	MachineTypeI440FX MachineType = "i440fx"
+	MachineTypeQ35    MachineType = "q35"
+	MachineTypeVirt   MachineType = "virt"
+)
+
+func (obj MachineType) IsValid() bool {
+	switch obj {
+	case MachineTypeI440FX, MachineTypeQ35, MachineTypeVirt:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj MachineType) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj MachineType) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *MachineType) Unmarshal(s string) error {
+	*obj = MachineType(s)
+	return nil
+}
+
+func (obj *MachineType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,33 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestMachineType(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.MachineType)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"I440FX": {
+				Object: qemu.MachineTypeI440FX,
+				Value:  "i440fx",
+			},
+			"Q35": {
+				Object: qemu.MachineTypeQ35,
+				Value:  "q35",
+			},
+			"Virt": {
+				Object: qemu.MachineTypeVirt,
+				Value:  "virt",
+			},
+		},
+	)
+}
@@ -4,7 +4,7 @@ type NetworkModel string
 
 const (
 	NetworkModelIntelE1000     NetworkModel = "e1000"
-	NetworkModelVirtIO         NetworkModel = "VirtIO"
+	NetworkModelVirtIO         NetworkModel = "virtio"
 	NetworkModelRealtekRTL8139 NetworkModel = "rtl8139"
 	NetworkModelVMwareVMXNET3  NetworkModel = "vmxnet3"
 )
@@ -0,0 +1,44 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type NUMAPolicy string
+
+const (
+	NUMAPolicyPreferred  NUMAPolicy = "preferred"
+	NUMAPolicyBind       NUMAPolicy = "bind"
+	NUMAPolicyInterleave NUMAPolicy = "interleave"
+)
+
+func (obj NUMAPolicy) IsValid() bool {
+	switch obj {
+	case NUMAPolicyPreferred, NUMAPolicyBind, NUMAPolicyInterleave:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj NUMAPolicy) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj NUMAPolicy) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *NUMAPolicy) Unmarshal(s string) error {
+	*obj = NUMAPolicy(s)
+	return nil
+}
+
+func (obj *NUMAPolicy) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,33 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestNUMAPolicy(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.NUMAPolicy)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Preferred": {
+				Object: qemu.NUMAPolicyPreferred,
+				Value:  "preferred",
+			},
+			"Bind": {
+				Object: qemu.NUMAPolicyBind,
+				Value:  "bind",
+			},
+			"Interleave": {
+				Object: qemu.NUMAPolicyInterleave,
+				Value:  "interleave",
+			},
+		},
+	)
+}
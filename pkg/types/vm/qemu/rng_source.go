@@ -0,0 +1,44 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type RNGSource string
+
+const (
+	RNGSourceURandom RNGSource = "/dev/urandom"
+	RNGSourceRandom  RNGSource = "/dev/random"
+	RNGSourceHWRNG   RNGSource = "/dev/hwrng"
+)
+
+func (obj RNGSource) IsValid() bool {
+	switch obj {
+	case RNGSourceURandom, RNGSourceRandom, RNGSourceHWRNG:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj RNGSource) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj RNGSource) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *RNGSource) Unmarshal(s string) error {
+	*obj = RNGSource(s)
+	return nil
+}
+
+func (obj *RNGSource) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,33 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestRNGSource(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.RNGSource)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"URandom": {
+				Object: qemu.RNGSourceURandom,
+				Value:  "/dev/urandom",
+			},
+			"Random": {
+				Object: qemu.RNGSourceRandom,
+				Value:  "/dev/random",
+			},
+			"HWRNG": {
+				Object: qemu.RNGSourceHWRNG,
+				Value:  "/dev/hwrng",
+			},
+		},
+	)
+}
@@ -0,0 +1,52 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type SCSIController string
+
+const (
+	SCSIControllerLSI          SCSIController = "lsi"
+	SCSIControllerLSI53C810    SCSIController = "lsi53c810"
+	SCSIControllerVirtIO       SCSIController = "virtio-scsi-pci"
+	SCSIControllerVirtIOSingle SCSIController = "virtio-scsi-single"
+	SCSIControllerMegaSAS      SCSIController = "megasas"
+	SCSIControllerPVSCSI       SCSIController = "pvscsi"
+)
+
+func (obj SCSIController) IsValid() bool {
+	switch obj {
+	case SCSIControllerLSI,
+		SCSIControllerLSI53C810,
+		SCSIControllerVirtIO,
+		SCSIControllerVirtIOSingle,
+		SCSIControllerMegaSAS,
+		SCSIControllerPVSCSI:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj SCSIController) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj SCSIController) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *SCSIController) Unmarshal(s string) error {
+	*obj = SCSIController(s)
+	return nil
+}
+
+func (obj *SCSIController) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,45 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestSCSIController(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.SCSIController)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"LSI": {
+				Object: qemu.SCSIControllerLSI,
+				Value:  "lsi",
+			},
+			"LSI53C810": {
+				Object: qemu.SCSIControllerLSI53C810,
+				Value:  "lsi53c810",
+			},
+			"VirtIO": {
+				Object: qemu.SCSIControllerVirtIO,
+				Value:  "virtio-scsi-pci",
+			},
+			"VirtIOSingle": {
+				Object: qemu.SCSIControllerVirtIOSingle,
+				Value:  "virtio-scsi-single",
+			},
+			"MegaSAS": {
+				Object: qemu.SCSIControllerMegaSAS,
+				Value:  "megasas",
+			},
+			"PVSCSI": {
+				Object: qemu.SCSIControllerPVSCSI,
+				Value:  "pvscsi",
+			},
+		},
+	)
+}
@@ -0,0 +1,43 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type TPMVersion string
+
+const (
+	TPMVersionV12 TPMVersion = "v1.2"
+	TPMVersionV20 TPMVersion = "v2.0"
+)
+
+func (obj TPMVersion) IsValid() bool {
+	switch obj {
+	case TPMVersionV12, TPMVersionV20:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj TPMVersion) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj TPMVersion) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *TPMVersion) Unmarshal(s string) error {
+	*obj = TPMVersion(s)
+	return nil
+}
+
+func (obj *TPMVersion) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
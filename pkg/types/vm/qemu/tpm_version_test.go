@@ -0,0 +1,29 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestTPMVersion(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.TPMVersion)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"V12": {
+				Object: qemu.TPMVersionV12,
+				Value:  "v1.2",
+			},
+			"V20": {
+				Object: qemu.TPMVersionV20,
+				Value:  "v2.0",
+			},
+		},
+	)
+}
@@ -0,0 +1,68 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type VGAType string
+
+const (
+	VGATypeStandard VGAType = "std"
+	VGATypeCirrus   VGAType = "cirrus"
+	VGATypeVMware   VGAType = "vmware"
+	VGATypeQXL      VGAType = "qxl"
+	VGATypeQXL2     VGAType = "qxl2"
+	VGATypeQXL3     VGAType = "qxl3"
+	VGATypeQXL4     VGAType = "qxl4"
+	VGATypeVirtIO   VGAType = "virtio"
+	VGATypeVirtIOGL VGAType = "virtio-gl"
+	VGATypeSerial0  VGAType = "serial0"
+	VGATypeSerial1  VGAType = "serial1"
+	VGATypeSerial2  VGAType = "serial2"
+	VGATypeSerial3  VGAType = "serial3"
+	VGATypeNone     VGAType = "none"
+)
+
+func (obj VGAType) IsValid() bool {
+	switch obj {
+	case VGATypeStandard,
+		VGATypeCirrus,
+		VGATypeVMware,
+		VGATypeQXL,
+		VGATypeQXL2,
+		VGATypeQXL3,
+		VGATypeQXL4,
+		VGATypeVirtIO,
+		VGATypeVirtIOGL,
+		VGATypeSerial0,
+		VGATypeSerial1,
+		VGATypeSerial2,
+		VGATypeSerial3,
+		VGATypeNone:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj VGAType) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj VGAType) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *VGAType) Unmarshal(s string) error {
+	*obj = VGAType(s)
+	return nil
+}
+
+func (obj *VGAType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
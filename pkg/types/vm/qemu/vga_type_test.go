@@ -0,0 +1,77 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestVGAType(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.VGAType)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Standard": {
+				Object: qemu.VGATypeStandard,
+				Value:  "std",
+			},
+			"Cirrus": {
+				Object: qemu.VGATypeCirrus,
+				Value:  "cirrus",
+			},
+			"VMware": {
+				Object: qemu.VGATypeVMware,
+				Value:  "vmware",
+			},
+			"QXL": {
+				Object: qemu.VGATypeQXL,
+				Value:  "qxl",
+			},
+			"QXL2": {
+				Object: qemu.VGATypeQXL2,
+				Value:  "qxl2",
+			},
+			"QXL3": {
+				Object: qemu.VGATypeQXL3,
+				Value:  "qxl3",
+			},
+			"QXL4": {
+				Object: qemu.VGATypeQXL4,
+				Value:  "qxl4",
+			},
+			"VirtIO": {
+				Object: qemu.VGATypeVirtIO,
+				Value:  "virtio",
+			},
+			"VirtIOGL": {
+				Object: qemu.VGATypeVirtIOGL,
+				Value:  "virtio-gl",
+			},
+			"Serial0": {
+				Object: qemu.VGATypeSerial0,
+				Value:  "serial0",
+			},
+			"Serial1": {
+				Object: qemu.VGATypeSerial1,
+				Value:  "serial1",
+			},
+			"Serial2": {
+				Object: qemu.VGATypeSerial2,
+				Value:  "serial2",
+			},
+			"Serial3": {
+				Object: qemu.VGATypeSerial3,
+				Value:  "serial3",
+			},
+			"None": {
+				Object: qemu.VGATypeNone,
+				Value:  "none",
+			},
+		},
+	)
+}
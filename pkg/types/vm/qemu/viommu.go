@@ -0,0 +1,43 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type VIOMMU string
+
+const (
+	VIOMMUIntel  VIOMMU = "intel"
+	VIOMMUVirtIO VIOMMU = "virtio"
+)
+
+func (obj VIOMMU) IsValid() bool {
+	switch obj {
+	case VIOMMUIntel, VIOMMUVirtIO:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj VIOMMU) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj VIOMMU) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *VIOMMU) Unmarshal(s string) error {
+	*obj = VIOMMU(s)
+	return nil
+}
+
+func (obj *VIOMMU) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,29 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestVIOMMU(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.VIOMMU)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Intel": {
+				Object: qemu.VIOMMUIntel,
+				Value:  "intel",
+			},
+			"VirtIO": {
+				Object: qemu.VIOMMUVirtIO,
+				Value:  "virtio",
+			},
+		},
+	)
+}
@@ -2,10 +2,12 @@ package qemu
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/xabinapal/gopve/pkg/request"
 	"github.com/xabinapal/gopve/pkg/types"
 	"github.com/xabinapal/gopve/pkg/types/errors"
+	"github.com/xabinapal/gopve/pkg/types/task"
 	"github.com/xabinapal/gopve/pkg/types/vm"
 )
 
@@ -17,6 +19,21 @@ type VirtualMachine interface {
 
 	GetQEMUProperties() (Properties, error)
 	SetQEMUProperties(props Properties) error
+	GetPendingQEMUProperties() (PendingProperties, error)
+
+	GuestAgent() (GuestAgent, error)
+
+	RegenerateCloudInit() error
+	DumpCloudInit(kind CloudInitDumpType) (string, error)
+
+	// ImportDisk attaches a new disk with the content of disk.ImportFrom.
+	ImportDisk(disk HardDriveProperties) (task.Task, error)
+	// ResizeDisk returns an already completed task before PVE 8, which
+	// resizes synchronously.
+	ResizeDisk(disk string, size vm.DiskSize) (task.Task, error)
+	MoveDisk(disk string, opts vm.MoveDiskOptions) (task.Task, error)
+	DetachDisk(disk string) error
+	DeleteUnusedDisk(disk string) error
 }
 
 type CreateOptions struct {
@@ -32,16 +49,26 @@ func (obj CreateOptions) MapToValues() (request.Values, error) {
 		return nil, err
 	}
 
+	delete(values, "digest")
+
 	return values, nil
 }
 
 type Properties struct {
+	vm.Properties
 	GlobalProperties
 
 	CPU     CPUProperties
 	Memory  MemoryProperties
 	Storage StorageProperties
 	Network []NetworkInterfaceProperties
+
+	Hardware   HardwareProperties
+	PCIDevices []PCIDeviceProperties
+	USBDevices []USBDeviceProperties
+
+	Agent     AgentProperties
+	CloudInit CloudInitProperties
 }
 
 const (
@@ -50,6 +77,10 @@ const (
 
 func NewProperties(props types.Properties) (obj Properties, err error) {
 	return obj, errors.ChainUntilFail(
+		func() (err error) {
+			obj.Properties, err = vm.NewProperties(props)
+			return err
+		},
 		func() (err error) {
 			obj.GlobalProperties, err = NewGlobalProperties(props)
 			return err
@@ -91,6 +122,22 @@ func NewProperties(props types.Properties) (obj Properties, err error) {
 
 			return nil
 		},
+		func() (err error) {
+			obj.Hardware, err = NewHardwareProperties(props)
+			return err
+		},
+		func() (err error) {
+			obj.PCIDevices, obj.USBDevices, err = newPassthroughDevices(props)
+			return err
+		},
+		func() (err error) {
+			obj.Agent, err = NewAgentProperties(props)
+			return err
+		},
+		func() (err error) {
+			obj.CloudInit, err = NewCloudInitProperties(props)
+			return err
+		},
 	)
 }
 
@@ -100,6 +147,11 @@ type GlobalProperties struct {
 	ACPI              bool
 	KVMVirtualization bool
 	USBTabletDevice   bool
+
+	Machine MachineProperties
+
+	// BootOrder lists the device names tried on boot, like scsi0 or net0.
+	BootOrder []string
 }
 
 const (
@@ -108,6 +160,9 @@ const (
 	mkGlobalPropertyACPI              = "acpi"
 	mkGlobalPropertyKVMVirtualization = "kvm"
 	mkGlobalPropertyUSBTabletDevice   = "tablet"
+	mkGlobalPropertyMachine           = "machine"
+	mkGlobalPropertyBoot              = "boot"
+	mkKeyPropertyBootOrder            = "order"
 
 	DefaultGlobalPropertiesACPI              bool = true
 	DefaultGlobalPropertiesKVMVirtualization bool = true
@@ -151,31 +206,174 @@ func NewGlobalProperties(
 				nil,
 			)
 		},
+		func() error {
+			return parseHardwareProperty(props, mkGlobalPropertyMachine, func(media string) (err error) {
+				obj.Machine, err = NewMachineProperties(media)
+				return err
+			})
+		},
+		func() error {
+			boot, err := props.GetAsDict(mkGlobalPropertyBoot, ",", "=", true)
+			if err != nil {
+				if errors.ErrMissingProperty.IsBase(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			// Legacy boot device letters are not mapped.
+			if order, ok := boot.Elem(mkKeyPropertyBootOrder); ok && order != "" {
+				obj.BootOrder = strings.Split(order, ";")
+			}
+
+			return nil
+		},
 	)
 
 	return obj, err
 }
 
+func (obj GlobalProperties) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	values.ConditionalAddObject(
+		mkGlobalPropertyOSType,
+		obj.OSType,
+		obj.OSType != "",
+	)
+
+	values.AddBool(mkGlobalPropertyACPI, obj.ACPI)
+	values.AddBool(mkGlobalPropertyKVMVirtualization, obj.KVMVirtualization)
+	values.AddBool(mkGlobalPropertyUSBTabletDevice, obj.USBTabletDevice)
+
+	if !obj.Machine.IsEmpty() {
+		if err := values.AddObject(mkGlobalPropertyMachine, obj.Machine); err != nil {
+			return nil, err
+		}
+	}
+
+	values.ConditionalAddString(
+		mkGlobalPropertyBoot,
+		fmt.Sprintf(
+			"%s=%s",
+			mkKeyPropertyBootOrder,
+			strings.Join(obj.BootOrder, ";"),
+		),
+		len(obj.BootOrder) != 0,
+	)
+
+	return values, nil
+}
+
 func (obj Properties) MapToValues() (request.Values, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
 	values := request.Values{}
 
-	cpuValues, err := obj.CPU.MapToValues()
+	for _, f := range []func() (request.Values, error){
+		obj.Properties.MapToValues,
+		obj.GlobalProperties.MapToValues,
+		obj.CPU.MapToValues,
+		obj.Memory.MapToValues,
+		obj.Storage.MapToValues,
+		obj.Hardware.MapToValues,
+		obj.Agent.MapToValues,
+		obj.CloudInit.MapToValues,
+	} {
+		v, err := f()
+		if err != nil {
+			return nil, err
+		}
+
+		for k, vv := range v {
+			values[k] = vv
+		}
+	}
+
+	for _, network := range obj.Network {
+		if err := values.AddObject(network.Name(), network); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, device := range obj.PCIDevices {
+		if err := values.AddObject(device.Name(), device); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, device := range obj.USBDevices {
+		if err := values.AddObject(device.Name(), device); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// IsQ35 returns true when the machine type emulates the q35 chipset, which
+// is required to pass devices as PCI Express.
+func (obj GlobalProperties) IsQ35() bool {
+	return obj.Machine.Type == MachineTypeQ35
+}
+
+// Validate checks the combinations PVE would refuse between devices and the
+// rest of the configuration.
+func (obj Properties) Validate() error {
+	if n := obj.Hardware.VGA.serialPort(); n != -1 {
+		found := false
+
+		for _, port := range obj.Hardware.SerialPorts {
+			found = found || port.DeviceNumber == n
+		}
+
+		if !found {
+			return fmt.Errorf("vga %s requires the serial port", obj.Hardware.VGA.Type)
+		}
+	}
+
+	for _, device := range obj.PCIDevices {
+		if err := device.Validate(); err != nil {
+			return err
+		}
+
+		if device.PCIExpress && !obj.IsQ35() {
+			return fmt.Errorf(
+				"pci express on %s requires the q35 machine type",
+				device.Name(),
+			)
+		}
+	}
+
+	for _, device := range obj.USBDevices {
+		if err := device.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return obj.validateNUMA()
+}
+
+// MapToUpdateValues serializes the properties like MapToValues, and also
+// appends a delete key with every property present in the previous
+// configuration that is missing in the new one, like detached devices.
+func (obj Properties) MapToUpdateValues(
+	previous Properties,
+) (request.Values, error) {
+	values, err := obj.MapToValues()
 	if err != nil {
 		return nil, err
-	} else {
-		for k, v := range cpuValues {
-			values[k] = v
-		}
 	}
 
-	memoryValues, err := obj.Memory.MapToValues()
+	previousValues, err := previous.MapToValues()
 	if err != nil {
 		return nil, err
-	} else {
-		for k, v := range memoryValues {
-			values[k] = v
-		}
 	}
 
+	values.AddDeleted(previousValues, "digest")
+
 	return values, nil
 }
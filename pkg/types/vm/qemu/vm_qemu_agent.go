@@ -0,0 +1,135 @@
+package qemu
+
+import (
+	"fmt"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/errors"
+)
+
+type AgentProperties struct {
+	Enabled bool
+
+	FreezeFilesystemsOnBackup bool
+	TrimClonedDisks           bool
+
+	Type AgentType
+}
+
+const (
+	mkAgentProperty = "agent"
+
+	DefaultAgentPropertyEnabled                   bool      = false
+	DefaultAgentPropertyFreezeFilesystemsOnBackup bool      = true
+	DefaultAgentPropertyTrimClonedDisks           bool      = false
+	DefaultAgentPropertyType                      AgentType = AgentTypeVirtIO
+)
+
+func NewAgentProperties(props types.Properties) (AgentProperties, error) {
+	obj := AgentProperties{
+		Enabled:                   DefaultAgentPropertyEnabled,
+		FreezeFilesystemsOnBackup: DefaultAgentPropertyFreezeFilesystemsOnBackup,
+		TrimClonedDisks:           DefaultAgentPropertyTrimClonedDisks,
+		Type:                      DefaultAgentPropertyType,
+	}
+
+	var agent string
+
+	switch x := props[mkAgentProperty].(type) {
+	case nil:
+		return obj, nil
+	case float64:
+		agent = fmt.Sprintf("%d", int(x))
+	case string:
+		agent = x
+	default:
+		err := errors.ErrInvalidProperty
+		err.AddKey("name", mkAgentProperty)
+		err.AddKey("value", x)
+		return obj, err
+	}
+
+	dict := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      true,
+	}
+
+	if err := (&dict).Unmarshal(agent); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range dict.List() {
+		var err error
+
+		switch {
+		case !kv.HasValue():
+			var enabled internal_types.PVEBool
+			enabled, err = internal_types.NewPVEBoolFromString(kv.Key())
+			obj.Enabled = enabled.Bool()
+		case kv.Key() == "enabled":
+			obj.Enabled, err = kv.ValueAsBool()
+		case kv.Key() == "freeze-fs-on-backup":
+			obj.FreezeFilesystemsOnBackup, err = kv.ValueAsBool()
+		case kv.Key() == "fstrim_cloned_disks":
+			obj.TrimClonedDisks, err = kv.ValueAsBool()
+		case kv.Key() == "type":
+			err = (&obj.Type).Unmarshal(kv.Value())
+		default:
+			err = fmt.Errorf("unknown property %s", kv.Key())
+		}
+
+		if err != nil {
+			return obj, err
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj AgentProperties) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	content := internal_types.PVEList{Separator: ","}
+
+	if obj.FreezeFilesystemsOnBackup != DefaultAgentPropertyFreezeFilesystemsOnBackup {
+		content.Append(fmt.Sprintf(
+			"freeze-fs-on-backup=%s",
+			internal_types.PVEBool(obj.FreezeFilesystemsOnBackup).String(),
+		))
+	}
+
+	if obj.TrimClonedDisks != DefaultAgentPropertyTrimClonedDisks {
+		content.Append(fmt.Sprintf(
+			"fstrim_cloned_disks=%s",
+			internal_types.PVEBool(obj.TrimClonedDisks).String(),
+		))
+	}
+
+	if obj.Type != "" && obj.Type != DefaultAgentPropertyType {
+		if !obj.Type.IsValid() {
+			return nil, fmt.Errorf("invalid agent type %s", obj.Type)
+		}
+
+		content.Append(fmt.Sprintf("type=%s", obj.Type))
+	}
+
+	if obj.Enabled || content.Len() != 0 {
+		agent, err := internal_types.NewPVEList(
+			",",
+			append(
+				[]string{internal_types.PVEBool(obj.Enabled).String()},
+				content.List()...,
+			),
+		).Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		values.AddString(mkAgentProperty, agent)
+	}
+
+	return values, nil
+}
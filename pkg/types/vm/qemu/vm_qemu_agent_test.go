@@ -0,0 +1,76 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+func TestAgentProperties(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Value    interface{}
+		Expected qemu.AgentProperties
+		Marshal  string
+	}{
+		"Missing": {
+			Value: nil,
+			Expected: qemu.AgentProperties{
+				FreezeFilesystemsOnBackup: true,
+				Type:                      qemu.AgentTypeVirtIO,
+			},
+			Marshal: "",
+		},
+		"Enabled": {
+			Value: "1",
+			Expected: qemu.AgentProperties{
+				Enabled:                   true,
+				FreezeFilesystemsOnBackup: true,
+				Type:                      qemu.AgentTypeVirtIO,
+			},
+			Marshal: "1",
+		},
+		"Numeric": {
+			Value: float64(1),
+			Expected: qemu.AgentProperties{
+				Enabled:                   true,
+				FreezeFilesystemsOnBackup: true,
+				Type:                      qemu.AgentTypeVirtIO,
+			},
+			Marshal: "1",
+		},
+		"Options": {
+			Value: "enabled=1,freeze-fs-on-backup=0,fstrim_cloned_disks=1,type=isa",
+			Expected: qemu.AgentProperties{
+				Enabled:         true,
+				TrimClonedDisks: true,
+				Type:            qemu.AgentTypeISA,
+			},
+			Marshal: "1,freeze-fs-on-backup=0,fstrim_cloned_disks=1,type=isa",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			props := types.Properties{}
+			if tc.Value != nil {
+				props["agent"] = tc.Value
+			}
+
+			agentProps, err := qemu.NewAgentProperties(props)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, agentProps)
+
+			values, err := agentProps.MapToValues()
+			require.NoError(t, err)
+
+			if tc.Marshal == "" {
+				assert.NotContains(t, values, "agent")
+			} else {
+				assert.Equal(t, []string{tc.Marshal}, values["agent"])
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package qemu
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/errors"
+)
+
+type CloudInitProperties struct {
+	Type CloudInitType
+
+	User     string
+	Password string
+	SSHKeys  []string
+
+	IPConfig     []CloudInitIPConfigProperties
+	Nameservers  []string
+	SearchDomain string
+
+	Custom CloudInitCustomProperties
+}
+
+// PVE never returns the cloud-init password, only this mask when it's set.
+const CloudInitPasswordMask = "**********"
+
+const (
+	mkCloudInitPropertyType         = "citype"
+	mkCloudInitPropertyUser         = "ciuser"
+	mkCloudInitPropertyPassword     = "cipassword"
+	mkCloudInitPropertySSHKeys      = "sshkeys"
+	mkCloudInitPropertyNameserver   = "nameserver"
+	mkCloudInitPropertySearchDomain = "searchdomain"
+	mkCloudInitPropertyCustom       = "cicustom"
+
+	maxCloudInitIPConfigPropertiesArrayCapacity = 32
+)
+
+func NewCloudInitProperties(
+	props types.Properties,
+) (obj CloudInitProperties, err error) {
+	return obj, errors.ChainUntilFail(
+		func() error {
+			return props.SetFixedValue(
+				mkCloudInitPropertyType,
+				&obj.Type,
+				CloudInitType(""),
+				nil,
+			)
+		},
+		func() error {
+			return props.SetString(mkCloudInitPropertyUser, &obj.User, "", nil)
+		},
+		func() error {
+			return props.SetString(
+				mkCloudInitPropertyPassword,
+				&obj.Password,
+				"",
+				nil,
+			)
+		},
+		func() error {
+			var sshKeys string
+			if err := props.SetString(mkCloudInitPropertySSHKeys, &sshKeys, "", nil); err != nil {
+				return err
+			}
+
+			sshKeys, err := url.PathUnescape(sshKeys)
+			if err != nil {
+				return err
+			}
+
+			for _, key := range strings.Split(sshKeys, "\n") {
+				if key = strings.TrimSpace(key); key != "" {
+					obj.SSHKeys = append(obj.SSHKeys, key)
+				}
+			}
+
+			return nil
+		},
+		func() error {
+			for i := 0; i < maxCloudInitIPConfigPropertiesArrayCapacity; i++ {
+				propName := fmt.Sprintf("ipconfig%d", i)
+				prop, ok := props[propName]
+				if !ok {
+					continue
+				}
+
+				x, ok := prop.(string)
+				if !ok {
+					err := errors.ErrInvalidProperty
+					err.AddKey("name", propName)
+					err.AddKey("value", prop)
+					return err
+				}
+
+				if ipConfig, err := NewCloudInitIPConfigProperties(i, x); err == nil {
+					obj.IPConfig = append(obj.IPConfig, ipConfig)
+				} else {
+					return err
+				}
+			}
+
+			return nil
+		},
+		func() error {
+			var nameservers string
+			if err := props.SetString(mkCloudInitPropertyNameserver, &nameservers, "", nil); err != nil {
+				return err
+			}
+
+			if nameservers != "" {
+				obj.Nameservers = strings.Fields(nameservers)
+			}
+
+			return nil
+		},
+		func() error {
+			return props.SetString(
+				mkCloudInitPropertySearchDomain,
+				&obj.SearchDomain,
+				"",
+				nil,
+			)
+		},
+		func() (err error) {
+			var custom string
+			if err := props.SetString(mkCloudInitPropertyCustom, &custom, "", nil); err != nil {
+				return err
+			}
+
+			obj.Custom, err = NewCloudInitCustomProperties(custom)
+			return err
+		},
+	)
+}
+
+func (obj CloudInitProperties) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	if obj.Type != "" {
+		if !obj.Type.IsValid() {
+			return nil, fmt.Errorf("invalid cloud-init type %s", obj.Type)
+		}
+
+		if err := values.AddObject(mkCloudInitPropertyType, obj.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	values.ConditionalAddString(
+		mkCloudInitPropertyUser,
+		obj.User,
+		obj.User != "",
+	)
+	values.ConditionalAddString(
+		mkCloudInitPropertyPassword,
+		obj.Password,
+		obj.Password != "" && obj.Password != CloudInitPasswordMask,
+	)
+
+	if len(obj.SSHKeys) != 0 {
+		values.AddString(
+			mkCloudInitPropertySSHKeys,
+			strings.ReplaceAll(
+				url.QueryEscape(strings.Join(obj.SSHKeys, "\n")),
+				"+",
+				"%20",
+			),
+		)
+	}
+
+	for _, ipConfig := range obj.IPConfig {
+		if err := values.AddObject(ipConfig.Name(), ipConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	values.ConditionalAddString(
+		mkCloudInitPropertyNameserver,
+		strings.Join(obj.Nameservers, " "),
+		len(obj.Nameservers) != 0,
+	)
+	values.ConditionalAddString(
+		mkCloudInitPropertySearchDomain,
+		obj.SearchDomain,
+		obj.SearchDomain != "",
+	)
+
+	if custom, err := obj.Custom.Marshal(); err != nil {
+		return nil, err
+	} else if custom != "" {
+		values.AddString(mkCloudInitPropertyCustom, custom)
+	}
+
+	return values, nil
+}
+
+type CloudInitIPConfigProperties struct {
+	DeviceNumber int
+
+	IPv4        string
+	GatewayIPv4 string
+
+	IPv6        string
+	GatewayIPv6 string
+}
+
+const (
+	CloudInitIPConfigDHCP = "dhcp"
+	CloudInitIPConfigAuto = "auto"
+)
+
+func NewCloudInitIPConfigProperties(
+	deviceNumber int,
+	ipConfig string,
+) (obj CloudInitIPConfigProperties, err error) {
+	obj.DeviceNumber = deviceNumber
+
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      false,
+	}
+
+	if err := (&props).Unmarshal(ipConfig); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		switch kv.Key() {
+		case "ip":
+			obj.IPv4 = kv.Value()
+		case "gw":
+			obj.GatewayIPv4 = kv.Value()
+		case "ip6":
+			obj.IPv6 = kv.Value()
+		case "gw6":
+			obj.GatewayIPv6 = kv.Value()
+		default:
+			return obj, fmt.Errorf("unknown property %s", kv.Key())
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj CloudInitIPConfigProperties) Name() string {
+	return fmt.Sprintf("ipconfig%d", obj.DeviceNumber)
+}
+
+func (obj CloudInitIPConfigProperties) Marshal() (string, error) {
+	content := internal_types.PVEList{Separator: ","}
+
+	for _, kv := range []struct {
+		Key   string
+		Value string
+	}{
+		{"ip", obj.IPv4},
+		{"gw", obj.GatewayIPv4},
+		{"ip6", obj.IPv6},
+		{"gw6", obj.GatewayIPv6},
+	} {
+		if kv.Value != "" {
+			content.Append(fmt.Sprintf("%s=%s", kv.Key, kv.Value))
+		}
+	}
+
+	if content.Len() == 0 {
+		return "", fmt.Errorf("ip configuration %s is empty", obj.Name())
+	}
+
+	return content.Marshal()
+}
+
+type CloudInitCustomProperties struct {
+	User    string
+	Network string
+	Meta    string
+	Vendor  string
+}
+
+func NewCloudInitCustomProperties(
+	custom string,
+) (obj CloudInitCustomProperties, err error) {
+	if custom == "" {
+		return obj, nil
+	}
+
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      false,
+	}
+
+	if err := (&props).Unmarshal(custom); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		switch kv.Key() {
+		case "user":
+			obj.User = kv.Value()
+		case "network":
+			obj.Network = kv.Value()
+		case "meta":
+			obj.Meta = kv.Value()
+		case "vendor":
+			obj.Vendor = kv.Value()
+		default:
+			return obj, fmt.Errorf("unknown property %s", kv.Key())
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj CloudInitCustomProperties) Marshal() (string, error) {
+	content := internal_types.PVEList{Separator: ","}
+
+	for _, kv := range []struct {
+		Key   string
+		Value string
+	}{
+		{"user", obj.User},
+		{"network", obj.Network},
+		{"meta", obj.Meta},
+		{"vendor", obj.Vendor},
+	} {
+		if kv.Value != "" {
+			content.Append(fmt.Sprintf("%s=%s", kv.Key, kv.Value))
+		}
+	}
+
+	return content.Marshal()
+}
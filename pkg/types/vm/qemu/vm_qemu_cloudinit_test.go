@@ -0,0 +1,101 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestCloudInitProperties(t *testing.T) {
+	props := test.HelperCreatePropertiesMap(types.Properties{
+		"citype":       "nocloud",
+		"ciuser":       "test_user",
+		"cipassword":   qemu.CloudInitPasswordMask,
+		"sshkeys":      "ssh-ed25519%20AAAA%2Bkey1%20user%40host%0Assh-rsa%20AAAA%2Fkey2%3D%3D%0A",
+		"ipconfig0":    "ip=10.0.0.10/24,gw=10.0.0.1",
+		"ipconfig1":    "ip=dhcp,ip6=auto",
+		"nameserver":   "10.0.0.2 10.0.0.3",
+		"searchdomain": "example.com",
+		"cicustom":     "user=local:snippets/user.yaml,vendor=local:snippets/vendor.yaml",
+	})
+
+	t.Run(
+		"Create", func(t *testing.T) {
+			cloudInitProps, err := qemu.NewCloudInitProperties(props)
+			require.NoError(t, err)
+
+			assert.Equal(t, qemu.CloudInitProperties{
+				Type:     qemu.CloudInitTypeNoCloud,
+				User:     "test_user",
+				Password: qemu.CloudInitPasswordMask,
+				SSHKeys: []string{
+					"ssh-ed25519 AAAA+key1 user@host",
+					"ssh-rsa AAAA/key2==",
+				},
+				IPConfig: []qemu.CloudInitIPConfigProperties{
+					{
+						DeviceNumber: 0,
+						IPv4:         "10.0.0.10/24",
+						GatewayIPv4:  "10.0.0.1",
+					},
+					{
+						DeviceNumber: 1,
+						IPv4:         qemu.CloudInitIPConfigDHCP,
+						IPv6:         qemu.CloudInitIPConfigAuto,
+					},
+				},
+				Nameservers:  []string{"10.0.0.2", "10.0.0.3"},
+				SearchDomain: "example.com",
+				Custom: qemu.CloudInitCustomProperties{
+					User:   "local:snippets/user.yaml",
+					Vendor: "local:snippets/vendor.yaml",
+				},
+			}, cloudInitProps)
+		})
+
+	t.Run(
+		"MapToValues", func(t *testing.T) {
+			cloudInitProps, err := qemu.NewCloudInitProperties(props)
+			require.NoError(t, err)
+
+			values, err := cloudInitProps.MapToValues()
+			require.NoError(t, err)
+
+			expectedValues := map[string]string{
+				"citype":       "nocloud",
+				"ciuser":       "test_user",
+				"sshkeys":      "ssh-ed25519%20AAAA%2Bkey1%20user%40host%0Assh-rsa%20AAAA%2Fkey2%3D%3D",
+				"ipconfig0":    "ip=10.0.0.10/24,gw=10.0.0.1",
+				"ipconfig1":    "ip=dhcp,ip6=auto",
+				"nameserver":   "10.0.0.2 10.0.0.3",
+				"searchdomain": "example.com",
+				"cicustom":     "user=local:snippets/user.yaml,vendor=local:snippets/vendor.yaml",
+			}
+
+			require.Len(t, values, len(expectedValues))
+			for k, v := range expectedValues {
+				assert.Equal(t, []string{v}, values[k], k)
+			}
+
+			cloudInitProps.Password = "test_password"
+
+			values, err = cloudInitProps.MapToValues()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"test_password"}, values["cipassword"])
+		})
+
+	t.Run(
+		"Empty", func(t *testing.T) {
+			cloudInitProps, err := qemu.NewCloudInitProperties(types.Properties{})
+			require.NoError(t, err)
+			assert.Equal(t, qemu.CloudInitProperties{}, cloudInitProps)
+
+			values, err := cloudInitProps.MapToValues()
+			require.NoError(t, err)
+			assert.Empty(t, values)
+		})
+}
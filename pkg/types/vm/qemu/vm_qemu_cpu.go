@@ -2,6 +2,7 @@ package qemu
 
 import (
 	"fmt"
+	"strings"
 
 	internal_types "github.com/xabinapal/gopve/internal/types"
 	"github.com/xabinapal/gopve/pkg/request"
@@ -23,7 +24,8 @@ type CPUProperties struct {
 	Limit uint
 	Units uint
 
-	NUMA bool
+	NUMA      bool
+	NUMANodes []NUMANodeProperties
 
 	FreezeAtStartup bool
 }
@@ -181,6 +183,11 @@ func NewCPUProperties(props types.Properties) (CPUProperties, error) {
 				nil,
 			)
 		},
+		func() error {
+			nodes, err := newNUMANodes(props)
+			obj.NUMANodes = nodes
+			return err
+		},
 		func() error {
 			return props.SetBool(
 				mkCPUPropertyFreezeAtStartup,
@@ -192,9 +199,52 @@ func NewCPUProperties(props types.Properties) (CPUProperties, error) {
 	)
 }
 
+// Count returns the number of vCPUs of the guest, sockets times cores, with
+// both defaulting to one when unset.
+func (obj CPUProperties) Count() uint {
+	sockets, cores := obj.Sockets, obj.Cores
+
+	if sockets == 0 {
+		sockets = 1
+	}
+
+	if cores == 0 {
+		cores = 1
+	}
+
+	return sockets * cores
+}
+
 func (obj CPUProperties) MapToValues() (request.Values, error) {
 	values := request.Values{}
 
+	kind := obj.Kind
+	if kind == "" {
+		kind = DefaultCPUPropertyKind
+	}
+
+	cpu := internal_types.PVEList{Separator: ","}
+	cpu.Append(string(kind))
+
+	if len(obj.Flags) != 0 {
+		flags := make([]string, len(obj.Flags))
+		for i, flag := range obj.Flags {
+			flags[i] = string(flag)
+		}
+
+		cpu.Append(
+			fmt.Sprintf("%s=%s", mkCPUKeyPropertyFlags, strings.Join(flags, ";")),
+		)
+	}
+
+	values.AddObject(mkCPUDictPropertyCPU, cpu)
+
+	values.ConditionalAddObject(
+		mkCPUPropertyArchitecture,
+		obj.Architecture,
+		obj.Architecture != CPUArchitectureHost,
+	)
+
 	sockets := obj.Sockets
 	if sockets == 0 {
 		sockets = 1
@@ -215,7 +265,7 @@ func (obj CPUProperties) MapToValues() (request.Values, error) {
 		return nil, fmt.Errorf(
 			"Invalid CPU hotplugged cores, can't be greater than sockets * cores",
 		)
-	} else if obj.VCPUs != 0 {
+	} else if obj.VCPUs != 0 && obj.VCPUs != sockets*cores {
 		values.AddUint("vcpus", obj.VCPUs)
 	}
 
@@ -235,6 +285,12 @@ func (obj CPUProperties) MapToValues() (request.Values, error) {
 
 	values.AddBool("numa", obj.NUMA)
 
+	for _, node := range obj.NUMANodes {
+		if err := values.AddObject(node.Name(), node); err != nil {
+			return nil, err
+		}
+	}
+
 	values.AddBool("freeze", obj.FreezeAtStartup)
 
 	return values, nil
@@ -0,0 +1,712 @@
+package qemu
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/errors"
+)
+
+// HardwareProperties is the emulated hardware other than CPU, memory,
+// storage, network and passthrough devices.
+type HardwareProperties struct {
+	BIOS           BIOSType
+	SCSIController SCSIController
+
+	VGA           VGAProperties
+	SerialPorts   []SerialPortProperties
+	ParallelPorts []ParallelPortProperties
+	Audio         AudioProperties
+	RNG           RNGProperties
+	Watchdog      WatchdogProperties
+
+	// Hotplug is nil when the default devices are hotpluggable, and empty
+	// when hotplug is disabled.
+	Hotplug []HotplugDevice
+
+	SMBIOS SMBIOSProperties
+
+	// Args are extra arguments passed verbatim to the KVM command line.
+	Args string
+}
+
+const (
+	mkHardwarePropertyBIOS           = "bios"
+	mkHardwarePropertySCSIController = "scsihw"
+	mkHardwarePropertyVGA            = "vga"
+	mkHardwarePropertyAudio          = "audio0"
+	mkHardwarePropertyRNG            = "rng0"
+	mkHardwarePropertyWatchdog       = "watchdog"
+	mkHardwarePropertyHotplug        = "hotplug"
+	mkHardwarePropertySMBIOS         = "smbios1"
+	mkHardwarePropertyArgs           = "args"
+
+	maxSerialPortPropertiesArrayCapacity   = 4
+	maxParallelPortPropertiesArrayCapacity = 3
+
+	DefaultHardwarePropertyBIOS           BIOSType       = BIOSTypeSeaBIOS
+	DefaultHardwarePropertySCSIController SCSIController = SCSIControllerLSI
+)
+
+// DefaultHotplugDevices are hotpluggable when hotplug is not set.
+var DefaultHotplugDevices = []HotplugDevice{
+	HotplugDeviceNetwork,
+	HotplugDeviceDisk,
+	HotplugDeviceUSB,
+}
+
+func NewHardwareProperties(props types.Properties) (HardwareProperties, error) {
+	obj := HardwareProperties{}
+
+	err := errors.ChainUntilFail(
+		func() error {
+			return props.SetFixedValue(
+				mkHardwarePropertyBIOS,
+				&obj.BIOS,
+				DefaultHardwarePropertyBIOS,
+				nil,
+			)
+		},
+		func() error {
+			return props.SetFixedValue(
+				mkHardwarePropertySCSIController,
+				&obj.SCSIController,
+				DefaultHardwarePropertySCSIController,
+				nil,
+			)
+		},
+		func() error {
+			return props.SetString(mkHardwarePropertyArgs, &obj.Args, "", nil)
+		},
+		func() error {
+			return parseHardwareProperty(props, mkHardwarePropertyVGA, func(media string) (err error) {
+				obj.VGA, err = NewVGAProperties(media)
+				return err
+			})
+		},
+		func() error {
+			return parseHardwareProperty(props, mkHardwarePropertyAudio, func(media string) (err error) {
+				obj.Audio, err = NewAudioProperties(media)
+				return err
+			})
+		},
+		func() error {
+			return parseHardwareProperty(props, mkHardwarePropertyRNG, func(media string) (err error) {
+				obj.RNG, err = NewRNGProperties(media)
+				return err
+			})
+		},
+		func() error {
+			return parseHardwareProperty(props, mkHardwarePropertyWatchdog, func(media string) (err error) {
+				obj.Watchdog, err = NewWatchdogProperties(media)
+				return err
+			})
+		},
+		func() error {
+			return parseHardwareProperty(props, mkHardwarePropertySMBIOS, func(media string) (err error) {
+				obj.SMBIOS, err = NewSMBIOSProperties(media)
+				return err
+			})
+		},
+		func() error {
+			return parseHardwareProperty(props, mkHardwarePropertyHotplug, func(media string) (err error) {
+				obj.Hotplug, err = newHotplugDevices(media)
+				return err
+			})
+		},
+		func() error {
+			for i := 0; i < maxSerialPortPropertiesArrayCapacity; i++ {
+				if err := parseHardwareProperty(props, fmt.Sprintf("serial%d", i), func(media string) error {
+					obj.SerialPorts = append(obj.SerialPorts, SerialPortProperties{
+						DeviceNumber: i,
+						Device:       media,
+					})
+					return nil
+				}); err != nil {
+					return err
+				}
+			}
+
+			for i := 0; i < maxParallelPortPropertiesArrayCapacity; i++ {
+				if err := parseHardwareProperty(props, fmt.Sprintf("parallel%d", i), func(media string) error {
+					obj.ParallelPorts = append(obj.ParallelPorts, ParallelPortProperties{
+						DeviceNumber: i,
+						Device:       media,
+					})
+					return nil
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+
+	return obj, err
+}
+
+// parseHardwareProperty calls parse with the property value if it is set.
+// Numbers are accepted, as PVE returns some boolean-like values as such.
+func parseHardwareProperty(
+	props types.Properties,
+	key string,
+	parse func(media string) error,
+) error {
+	switch x := props[key].(type) {
+	case nil:
+		return nil
+	case string:
+		return parse(x)
+	case float64:
+		return parse(fmt.Sprintf("%d", int(x)))
+	default:
+		err := errors.ErrInvalidProperty
+		err.AddKey("name", key)
+		err.AddKey("value", x)
+		return err
+	}
+}
+
+func (obj HardwareProperties) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	if obj.BIOS != "" && obj.BIOS != DefaultHardwarePropertyBIOS {
+		if !obj.BIOS.IsValid() {
+			return nil, fmt.Errorf("invalid bios %s", obj.BIOS)
+		}
+
+		if err := values.AddObject(mkHardwarePropertyBIOS, obj.BIOS); err != nil {
+			return nil, err
+		}
+	}
+
+	if obj.SCSIController != "" && obj.SCSIController != DefaultHardwarePropertySCSIController {
+		if !obj.SCSIController.IsValid() {
+			return nil, fmt.Errorf("invalid scsi controller %s", obj.SCSIController)
+		}
+
+		if err := values.AddObject(mkHardwarePropertySCSIController, obj.SCSIController); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, x := range []struct {
+		Key     string
+		Value   types.Marshaler
+		IsEmpty bool
+	}{
+		{mkHardwarePropertyVGA, obj.VGA, obj.VGA.IsEmpty()},
+		{mkHardwarePropertyAudio, obj.Audio, obj.Audio.Device == ""},
+		{mkHardwarePropertyRNG, obj.RNG, obj.RNG.Source == ""},
+		{mkHardwarePropertyWatchdog, obj.Watchdog, obj.Watchdog.Model == "" && obj.Watchdog.Action == ""},
+		{mkHardwarePropertySMBIOS, obj.SMBIOS, obj.SMBIOS == SMBIOSProperties{}},
+	} {
+		if x.IsEmpty {
+			continue
+		}
+
+		if err := values.AddObject(x.Key, x.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, port := range obj.SerialPorts {
+		if err := values.AddObject(port.Name(), port); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, port := range obj.ParallelPorts {
+		if err := values.AddObject(port.Name(), port); err != nil {
+			return nil, err
+		}
+	}
+
+	if obj.Hotplug != nil {
+		hotplug, err := marshalHotplugDevices(obj.Hotplug)
+		if err != nil {
+			return nil, err
+		}
+
+		values.ConditionalAddString(mkHardwarePropertyHotplug, hotplug, hotplug != "")
+	}
+
+	values.ConditionalAddString(mkHardwarePropertyArgs, obj.Args, obj.Args != "")
+
+	return values, nil
+}
+
+type VGAProperties struct {
+	Type VGAType
+
+	// Memory is the video memory in MiB.
+	Memory uint
+}
+
+func (obj VGAProperties) IsEmpty() bool {
+	return obj.Type == "" && obj.Memory == 0
+}
+
+func NewVGAProperties(media string) (obj VGAProperties, err error) {
+	props, err := newHardwareDictionary(media)
+	if err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		switch {
+		case !kv.HasValue():
+			err = (&obj.Type).Unmarshal(kv.Key())
+		case kv.Key() == "type":
+			err = (&obj.Type).Unmarshal(kv.Value())
+		case kv.Key() == "memory":
+			var memory int
+			memory, err = kv.ValueAsInt()
+			obj.Memory = uint(memory)
+		default:
+			err = fmt.Errorf("unknown property %s", kv.Key())
+		}
+
+		if err != nil {
+			return obj, err
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj VGAProperties) Marshal() (string, error) {
+	vgaType := obj.Type
+	if vgaType == "" {
+		vgaType = VGATypeStandard
+	} else if !vgaType.IsValid() {
+		return "", fmt.Errorf("invalid vga type %s", obj.Type)
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(string(vgaType))
+
+	if obj.Memory != 0 {
+		content.Append(fmt.Sprintf("memory=%d", obj.Memory))
+	}
+
+	return content.Marshal()
+}
+
+// serialPort returns the serial port used as display, or -1.
+func (obj VGAProperties) serialPort() int {
+	if !strings.HasPrefix(string(obj.Type), "serial") {
+		return -1
+	}
+
+	return int(obj.Type[len(obj.Type)-1] - '0')
+}
+
+// SerialPortSocket creates a unix socket for the serial port, that can be
+// attached to with qm terminal.
+const SerialPortSocket = "socket"
+
+// SerialPortProperties is a serialN port, either a SerialPortSocket or a
+// host device path like /dev/ttyS0.
+type SerialPortProperties struct {
+	DeviceNumber int
+	Device       string
+}
+
+func (obj SerialPortProperties) Name() string {
+	return fmt.Sprintf("serial%d", obj.DeviceNumber)
+}
+
+func (obj SerialPortProperties) Marshal() (string, error) {
+	if obj.Device != SerialPortSocket && !strings.HasPrefix(obj.Device, "/dev/") {
+		return "", fmt.Errorf("invalid serial port device %s", obj.Device)
+	}
+
+	return obj.Device, nil
+}
+
+// ParallelPortProperties is a parallelN port bound to a host device path
+// like /dev/parport0.
+type ParallelPortProperties struct {
+	DeviceNumber int
+	Device       string
+}
+
+func (obj ParallelPortProperties) Name() string {
+	return fmt.Sprintf("parallel%d", obj.DeviceNumber)
+}
+
+func (obj ParallelPortProperties) Marshal() (string, error) {
+	if !strings.HasPrefix(obj.Device, "/dev/") {
+		return "", fmt.Errorf("invalid parallel port device %s", obj.Device)
+	}
+
+	return obj.Device, nil
+}
+
+type AudioProperties struct {
+	Device AudioDevice
+	Driver AudioDriver
+}
+
+const DefaultAudioPropertyDriver AudioDriver = AudioDriverSPICE
+
+func NewAudioProperties(media string) (obj AudioProperties, err error) {
+	obj.Driver = DefaultAudioPropertyDriver
+
+	props, err := newHardwareDictionary(media)
+	if err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		switch kv.Key() {
+		case "device":
+			err = (&obj.Device).Unmarshal(kv.Value())
+		case "driver":
+			err = (&obj.Driver).Unmarshal(kv.Value())
+		default:
+			err = fmt.Errorf("unknown property %s", kv.Key())
+		}
+
+		if err != nil {
+			return obj, err
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj AudioProperties) Marshal() (string, error) {
+	if !obj.Device.IsValid() {
+		return "", fmt.Errorf("invalid audio device %s", obj.Device)
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(fmt.Sprintf("device=%s", obj.Device))
+
+	if obj.Driver != "" && obj.Driver != DefaultAudioPropertyDriver {
+		if !obj.Driver.IsValid() {
+			return "", fmt.Errorf("invalid audio driver %s", obj.Driver)
+		}
+
+		content.Append(fmt.Sprintf("driver=%s", obj.Driver))
+	}
+
+	return content.Marshal()
+}
+
+// RNGProperties is a VirtIO random number generator fed from the host
+// Source, limited to MaxBytes every Period milliseconds.
+type RNGProperties struct {
+	Source   RNGSource
+	MaxBytes uint
+	Period   uint
+}
+
+const (
+	DefaultRNGPropertyMaxBytes uint = 1024
+	DefaultRNGPropertyPeriod   uint = 1000
+)
+
+func NewRNGProperties(media string) (obj RNGProperties, err error) {
+	obj.MaxBytes = DefaultRNGPropertyMaxBytes
+	obj.Period = DefaultRNGPropertyPeriod
+
+	props, err := newHardwareDictionary(media)
+	if err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		var n int
+
+		switch kv.Key() {
+		case "source":
+			err = (&obj.Source).Unmarshal(kv.Value())
+		case "max_bytes":
+			n, err = kv.ValueAsInt()
+			obj.MaxBytes = uint(n)
+		case "period":
+			n, err = kv.ValueAsInt()
+			obj.Period = uint(n)
+		default:
+			err = fmt.Errorf("unknown property %s", kv.Key())
+		}
+
+		if err != nil {
+			return obj, err
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj RNGProperties) Marshal() (string, error) {
+	if !obj.Source.IsValid() {
+		return "", fmt.Errorf("invalid rng source %s", obj.Source)
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(fmt.Sprintf("source=%s", obj.Source))
+
+	if obj.MaxBytes != DefaultRNGPropertyMaxBytes {
+		content.Append(fmt.Sprintf("max_bytes=%d", obj.MaxBytes))
+	}
+
+	if obj.Period != DefaultRNGPropertyPeriod {
+		content.Append(fmt.Sprintf("period=%d", obj.Period))
+	}
+
+	return content.Marshal()
+}
+
+type WatchdogProperties struct {
+	Model  WatchdogModel
+	Action WatchdogAction
+}
+
+const DefaultWatchdogPropertyModel WatchdogModel = WatchdogModelI6300ESB
+
+func NewWatchdogProperties(media string) (obj WatchdogProperties, err error) {
+	obj.Model = DefaultWatchdogPropertyModel
+
+	props, err := newHardwareDictionary(media)
+	if err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		switch {
+		case !kv.HasValue():
+			err = (&obj.Model).Unmarshal(kv.Key())
+		case kv.Key() == "model":
+			err = (&obj.Model).Unmarshal(kv.Value())
+		case kv.Key() == "action":
+			err = (&obj.Action).Unmarshal(kv.Value())
+		default:
+			err = fmt.Errorf("unknown property %s", kv.Key())
+		}
+
+		if err != nil {
+			return obj, err
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj WatchdogProperties) Marshal() (string, error) {
+	model := obj.Model
+	if model == "" {
+		model = DefaultWatchdogPropertyModel
+	} else if !model.IsValid() {
+		return "", fmt.Errorf("invalid watchdog model %s", obj.Model)
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(fmt.Sprintf("model=%s", model))
+
+	if obj.Action != "" {
+		if !obj.Action.IsValid() {
+			return "", fmt.Errorf("invalid watchdog action %s", obj.Action)
+		}
+
+		content.Append(fmt.Sprintf("action=%s", obj.Action))
+	}
+
+	return content.Marshal()
+}
+
+// SMBIOSProperties are the type 1 SMBIOS fields exposed to the guest. Text
+// fields are sent base64 encoded, so they can contain any character.
+type SMBIOSProperties struct {
+	UUID string
+
+	Manufacturer string
+	Product      string
+	Version      string
+	Serial       string
+	SKU          string
+	Family       string
+}
+
+// NewSMBIOSProperties splits the values on their first equal sign, as the
+// base64 padding would make them ambiguous for a PVE dictionary.
+func NewSMBIOSProperties(media string) (obj SMBIOSProperties, err error) {
+	fields := make(map[string]string)
+	var keys []string
+
+	for _, field := range strings.Split(media, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return obj, fmt.Errorf("invalid smbios field %s", field)
+		}
+
+		fields[kv[0]] = kv[1]
+		keys = append(keys, kv[0])
+	}
+
+	encoded := fields["base64"] == "1"
+
+	for _, key := range keys {
+		value := fields[key]
+
+		if encoded && key != "uuid" && key != "base64" {
+			b, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return obj, err
+			}
+
+			value = string(b)
+		}
+
+		switch key {
+		case "uuid":
+			obj.UUID = value
+		case "manufacturer":
+			obj.Manufacturer = value
+		case "product":
+			obj.Product = value
+		case "version":
+			obj.Version = value
+		case "serial":
+			obj.Serial = value
+		case "sku":
+			obj.SKU = value
+		case "family":
+			obj.Family = value
+		case "base64":
+			continue
+		default:
+			return obj, fmt.Errorf("unknown property %s", key)
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj SMBIOSProperties) Marshal() (string, error) {
+	content := internal_types.PVEList{Separator: ","}
+
+	if obj.UUID != "" {
+		content.Append(fmt.Sprintf("uuid=%s", obj.UUID))
+	}
+
+	encoded := false
+
+	for _, x := range []struct {
+		Key   string
+		Value string
+	}{
+		{"manufacturer", obj.Manufacturer},
+		{"product", obj.Product},
+		{"version", obj.Version},
+		{"serial", obj.Serial},
+		{"sku", obj.SKU},
+		{"family", obj.Family},
+	} {
+		if x.Value != "" {
+			content.Append(fmt.Sprintf(
+				"%s=%s",
+				x.Key,
+				base64.StdEncoding.EncodeToString([]byte(x.Value)),
+			))
+
+			encoded = true
+		}
+	}
+
+	if encoded {
+		content.Append("base64=1")
+	}
+
+	return content.Marshal()
+}
+
+func newHotplugDevices(media string) ([]HotplugDevice, error) {
+	switch media {
+	case "0":
+		return []HotplugDevice{}, nil
+	case "1":
+		return nil, nil
+	}
+
+	var devices []HotplugDevice
+
+	for _, device := range strings.Split(media, ",") {
+		var d HotplugDevice
+		if err := (&d).Unmarshal(device); err != nil {
+			return nil, err
+		}
+
+		devices = append(devices, d)
+	}
+
+	if hotplugDevicesEqual(devices, DefaultHotplugDevices) {
+		return nil, nil
+	}
+
+	return devices, nil
+}
+
+func marshalHotplugDevices(devices []HotplugDevice) (string, error) {
+	if len(devices) == 0 {
+		return "0", nil
+	} else if hotplugDevicesEqual(devices, DefaultHotplugDevices) {
+		return "", nil
+	}
+
+	names := make([]string, len(devices))
+	for i, device := range devices {
+		if !device.IsValid() {
+			return "", fmt.Errorf("invalid hotplug device %s", device)
+		}
+
+		names[i] = string(device)
+	}
+
+	return strings.Join(names, ","), nil
+}
+
+func hotplugDevicesEqual(a, b []HotplugDevice) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sorted := func(devices []HotplugDevice) []string {
+		names := make([]string, len(devices))
+		for i, device := range devices {
+			names[i] = string(device)
+		}
+
+		sort.Strings(names)
+		return names
+	}
+
+	x, y := sorted(a), sorted(b)
+
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newHardwareDictionary(media string) (internal_types.PVEDictionary, error) {
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      true,
+	}
+
+	return props, (&props).Unmarshal(media)
+}
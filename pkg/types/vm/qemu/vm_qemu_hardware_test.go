@@ -0,0 +1,208 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestHardwareProperties(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		obj, err := qemu.NewHardwareProperties(types.Properties{})
+		require.NoError(t, err)
+
+		assert.Equal(t, qemu.HardwareProperties{
+			BIOS:           qemu.DefaultHardwarePropertyBIOS,
+			SCSIController: qemu.DefaultHardwarePropertySCSIController,
+		}, obj)
+
+		values, err := obj.MapToValues()
+		require.NoError(t, err)
+		assert.Empty(t, values)
+	})
+
+	props := test.HelperCreatePropertiesMap(types.Properties{
+		"bios":      "ovmf",
+		"scsihw":    "virtio-scsi-single",
+		"vga":       "qxl,memory=32",
+		"serial0":   "socket",
+		"parallel0": "/dev/parport0",
+		"audio0":    "device=ich9-intel-hda,driver=none",
+		"rng0":      "source=/dev/urandom,max_bytes=2048",
+		"watchdog":  "model=ib700,action=reset",
+		"hotplug":   "disk,cpu,memory",
+		"smbios1":   "uuid=6f0e8e2a-3f7a-4b1e-9c4d-0123456789ab,manufacturer=QWNtZSwgSW5jLg==,base64=1",
+		"args":      "-cpu host,kvm=off",
+	})
+
+	expected := qemu.HardwareProperties{
+		BIOS:           qemu.BIOSTypeOVMF,
+		SCSIController: qemu.SCSIControllerVirtIOSingle,
+		VGA: qemu.VGAProperties{
+			Type:   qemu.VGATypeQXL,
+			Memory: 32,
+		},
+		SerialPorts: []qemu.SerialPortProperties{
+			{DeviceNumber: 0, Device: qemu.SerialPortSocket},
+		},
+		ParallelPorts: []qemu.ParallelPortProperties{
+			{DeviceNumber: 0, Device: "/dev/parport0"},
+		},
+		Audio: qemu.AudioProperties{
+			Device: qemu.AudioDeviceICH9,
+			Driver: qemu.AudioDriverNone,
+		},
+		RNG: qemu.RNGProperties{
+			Source:   qemu.RNGSourceURandom,
+			MaxBytes: 2048,
+			Period:   qemu.DefaultRNGPropertyPeriod,
+		},
+		Watchdog: qemu.WatchdogProperties{
+			Model:  qemu.WatchdogModelIB700,
+			Action: qemu.WatchdogActionReset,
+		},
+		Hotplug: []qemu.HotplugDevice{
+			qemu.HotplugDeviceDisk,
+			qemu.HotplugDeviceCPU,
+			qemu.HotplugDeviceMemory,
+		},
+		SMBIOS: qemu.SMBIOSProperties{
+			UUID:         "6f0e8e2a-3f7a-4b1e-9c4d-0123456789ab",
+			Manufacturer: "Acme, Inc.",
+		},
+		Args: "-cpu host,kvm=off",
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		obj, err := qemu.NewHardwareProperties(props)
+		require.NoError(t, err)
+		assert.Equal(t, expected, obj)
+	})
+
+	t.Run("MapToValues", func(t *testing.T) {
+		values, err := expected.MapToValues()
+		require.NoError(t, err)
+
+		assert.Equal(t, request.Values{
+			"bios":      {"ovmf"},
+			"scsihw":    {"virtio-scsi-single"},
+			"vga":       {"qxl,memory=32"},
+			"serial0":   {"socket"},
+			"parallel0": {"/dev/parport0"},
+			"audio0":    {"device=ich9-intel-hda,driver=none"},
+			"rng0":      {"source=/dev/urandom,max_bytes=2048"},
+			"watchdog":  {"model=ib700,action=reset"},
+			"hotplug":   {"disk,cpu,memory"},
+			"smbios1":   {"uuid=6f0e8e2a-3f7a-4b1e-9c4d-0123456789ab,manufacturer=QWNtZSwgSW5jLg==,base64=1"},
+			"args":      {"-cpu host,kvm=off"},
+		}, values)
+	})
+
+	t.Run("Hotplug", func(t *testing.T) {
+		for value, devices := range map[string][]qemu.HotplugDevice{
+			"0":                {},
+			"1":                nil,
+			"usb,network,disk": nil,
+		} {
+			obj, err := qemu.NewHardwareProperties(types.Properties{
+				"hotplug": value,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, devices, obj.Hotplug, value)
+		}
+
+		values, err := qemu.HardwareProperties{
+			Hotplug: []qemu.HotplugDevice{},
+		}.MapToValues()
+		require.NoError(t, err)
+		assert.Equal(t, request.Values{"hotplug": {"0"}}, values)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, obj := range []qemu.HardwareProperties{
+			{BIOS: "uefi"},
+			{VGA: qemu.VGAProperties{Type: "matrox"}},
+			{SerialPorts: []qemu.SerialPortProperties{{Device: "ttyS0"}}},
+			{Audio: qemu.AudioProperties{Device: "sb16"}},
+			{RNG: qemu.RNGProperties{Source: "/dev/zero"}},
+			{Hotplug: []qemu.HotplugDevice{"pci"}},
+		} {
+			_, err := obj.MapToValues()
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestPropertiesWindows11(t *testing.T) {
+	props := test.HelperCreatePropertiesMap(types.Properties{
+		"ostype":    "win11",
+		"sockets":   1,
+		"cores":     4,
+		"memory":    8192,
+		"machine":   "pc-q35-7.2",
+		"bios":      "ovmf",
+		"scsihw":    "virtio-scsi-single",
+		"efidisk0":  "local-lvm:vm-100-disk-0,size=4M,efitype=4m,pre-enrolled-keys=1",
+		"tpmstate0": "local-lvm:vm-100-disk-1,size=4M,version=v2.0",
+		"vga":       "serial0",
+		"digest":    "0000000000000000000000000000000000000000",
+	})
+
+	obj, err := qemu.NewProperties(props)
+	require.NoError(t, err)
+
+	assert.Equal(t, qemu.MachineProperties{
+		Type:    qemu.MachineTypeQ35,
+		Version: "7.2",
+	}, obj.Machine)
+
+	assert.Equal(t, qemu.EFIDiskProperties{
+		DriveStorageProperties: qemu.DriveStorageProperties{
+			StorageName: "local-lvm",
+			StorageFile: "vm-100-disk-0",
+		},
+		Size:            "4M",
+		Type:            qemu.EFIType4M,
+		PreEnrolledKeys: true,
+	}, obj.Storage.EFIDisk)
+
+	assert.Equal(t, qemu.TPMStateProperties{
+		DriveStorageProperties: qemu.DriveStorageProperties{
+			StorageName: "local-lvm",
+			StorageFile: "vm-100-disk-1",
+		},
+		Size:    "4M",
+		Version: qemu.TPMVersionV20,
+	}, obj.Storage.TPMState)
+
+	t.Run("SerialConsoleRequiresPort", func(t *testing.T) {
+		_, err := obj.MapToValues()
+		assert.Error(t, err)
+	})
+
+	t.Run("MapToValues", func(t *testing.T) {
+		obj := obj
+		obj.Hardware.SerialPorts = []qemu.SerialPortProperties{
+			{DeviceNumber: 0, Device: qemu.SerialPortSocket},
+		}
+
+		values, err := obj.MapToValues()
+		require.NoError(t, err)
+
+		for k, v := range map[string]string{
+			"machine":   "pc-q35-7.2",
+			"bios":      "ovmf",
+			"efidisk0":  "local-lvm:vm-100-disk-0,size=4M,efitype=4m,pre-enrolled-keys=1",
+			"tpmstate0": "local-lvm:vm-100-disk-1,size=4M,version=v2.0",
+			"vga":       "serial0",
+			"serial0":   "socket",
+		} {
+			assert.Equal(t, []string{v}, values[k], k)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package qemu
+
+import (
+	"fmt"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+)
+
+// MachineProperties is the emulated chipset. An empty Version runs the
+// latest machine version available, while a set one pins it, like 5.1 or
+// 7.2+pve0, so the virtual hardware doesn't change on upgrades. Machines
+// that can't be decomposed, like microvm, keep their whole name as an
+// unknown Type.
+type MachineProperties struct {
+	Type    MachineType
+	Version string
+
+	VIOMMU VIOMMU
+}
+
+const (
+	DefaultMachineType MachineType = MachineTypeI440FX
+
+	machineNameI440FX          = "pc"
+	machineNameQ35             = "q35"
+	machineNameVirt            = "virt"
+	machineVersionPrefixI440FX = "pc-i440fx-"
+	machineVersionPrefixQ35    = "pc-q35-"
+	machineVersionPrefixVirt   = "virt-"
+)
+
+func NewMachineProperties(media string) (obj MachineProperties, err error) {
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      true,
+	}
+
+	if err := (&props).Unmarshal(media); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		switch {
+		case !kv.HasValue():
+			obj.setMachine(kv.Key())
+		case kv.Key() == "type":
+			obj.setMachine(kv.Value())
+		case kv.Key() == "viommu":
+			err = (&obj.VIOMMU).Unmarshal(kv.Value())
+		default:
+			err = fmt.Errorf("unknown property %s", kv.Key())
+		}
+
+		if err != nil {
+			return obj, err
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj *MachineProperties) setMachine(name string) {
+	switch {
+	case name == machineNameI440FX:
+		obj.Type = MachineTypeI440FX
+	case name == machineNameQ35:
+		obj.Type = MachineTypeQ35
+	case name == machineNameVirt:
+		obj.Type = MachineTypeVirt
+	case strings.HasPrefix(name, machineVersionPrefixI440FX):
+		obj.Type = MachineTypeI440FX
+		obj.Version = strings.TrimPrefix(name, machineVersionPrefixI440FX)
+	case strings.HasPrefix(name, machineVersionPrefixQ35):
+		obj.Type = MachineTypeQ35
+		obj.Version = strings.TrimPrefix(name, machineVersionPrefixQ35)
+	case strings.HasPrefix(name, machineVersionPrefixVirt):
+		obj.Type = MachineTypeVirt
+		obj.Version = strings.TrimPrefix(name, machineVersionPrefixVirt)
+	default:
+		obj.Type = MachineType(name)
+	}
+}
+
+// IsEmpty returns true when the default machine is used.
+func (obj MachineProperties) IsEmpty() bool {
+	return obj.Type == "" && obj.Version == "" && obj.VIOMMU == ""
+}
+
+func (obj MachineProperties) Name() string {
+	machineType := obj.Type
+	if machineType == "" {
+		machineType = DefaultMachineType
+	}
+
+	switch {
+	case machineType == MachineTypeQ35 && obj.Version == "":
+		return machineNameQ35
+	case machineType == MachineTypeQ35:
+		return machineVersionPrefixQ35 + obj.Version
+	case machineType == MachineTypeVirt && obj.Version == "":
+		return machineNameVirt
+	case machineType == MachineTypeVirt:
+		return machineVersionPrefixVirt + obj.Version
+	case machineType.IsUnknown():
+		return string(machineType)
+	case obj.Version == "":
+		return machineNameI440FX
+	default:
+		return machineVersionPrefixI440FX + obj.Version
+	}
+}
+
+func (obj MachineProperties) Marshal() (string, error) {
+	if obj.Type != "" && obj.Type.IsUnknown() && obj.Version != "" {
+		return "", fmt.Errorf("unknown machine type %s can't be pinned", obj.Type)
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(obj.Name())
+
+	if obj.VIOMMU != "" {
+		if !obj.VIOMMU.IsValid() {
+			return "", fmt.Errorf("invalid viommu %s", obj.VIOMMU)
+		}
+
+		if obj.VIOMMU == VIOMMUIntel && obj.Type != MachineTypeQ35 {
+			return "", fmt.Errorf("intel viommu requires the q35 machine type")
+		}
+
+		content.Append(fmt.Sprintf("viommu=%s", obj.VIOMMU))
+	}
+
+	return content.Marshal()
+}
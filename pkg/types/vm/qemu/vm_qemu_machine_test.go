@@ -0,0 +1,99 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+func TestMachineProperties(t *testing.T) {
+	options := map[string]struct {
+		Object qemu.MachineProperties
+		Value  string
+	}{
+		"I440FX": {
+			Object: qemu.MachineProperties{Type: qemu.MachineTypeI440FX},
+			Value:  "pc",
+		},
+		"Q35": {
+			Object: qemu.MachineProperties{Type: qemu.MachineTypeQ35},
+			Value:  "q35",
+		},
+		"PinnedI440FX": {
+			Object: qemu.MachineProperties{
+				Type:    qemu.MachineTypeI440FX,
+				Version: "5.1",
+			},
+			Value: "pc-i440fx-5.1",
+		},
+		"PinnedQ35": {
+			Object: qemu.MachineProperties{
+				Type:    qemu.MachineTypeQ35,
+				Version: "7.2+pve0",
+				VIOMMU:  qemu.VIOMMUIntel,
+			},
+			Value: "pc-q35-7.2+pve0,viommu=intel",
+		},
+		"Virt": {
+			Object: qemu.MachineProperties{Type: qemu.MachineTypeVirt},
+			Value:  "virt",
+		},
+		"PinnedVirt": {
+			Object: qemu.MachineProperties{
+				Type:    qemu.MachineTypeVirt,
+				Version: "8.1",
+			},
+			Value: "virt-8.1",
+		},
+		"Unknown": {
+			Object: qemu.MachineProperties{Type: qemu.MachineType("microvm")},
+			Value:  "microvm",
+		},
+	}
+
+	for n, tt := range options {
+		tt := tt
+
+		t.Run(n, func(t *testing.T) {
+			t.Run("Marshal", func(t *testing.T) {
+				value, err := tt.Object.Marshal()
+				require.NoError(t, err)
+				assert.Equal(t, tt.Value, value)
+			})
+
+			t.Run("Unmarshal", func(t *testing.T) {
+				obj, err := qemu.NewMachineProperties(tt.Value)
+				require.NoError(t, err)
+				assert.Equal(t, tt.Object, obj)
+			})
+		})
+	}
+
+	t.Run("TypeKey", func(t *testing.T) {
+		obj, err := qemu.NewMachineProperties("type=q35,viommu=virtio")
+		require.NoError(t, err)
+		assert.Equal(t, qemu.MachineProperties{
+			Type:   qemu.MachineTypeQ35,
+			VIOMMU: qemu.VIOMMUVirtIO,
+		}, obj)
+	})
+
+	t.Run("UnknownType", func(t *testing.T) {
+		obj, err := qemu.NewMachineProperties("microvm")
+		require.NoError(t, err)
+		assert.True(t, obj.Type.IsUnknown())
+
+		_, err = qemu.MachineProperties{
+			Type:    qemu.MachineType("microvm"),
+			Version: "8.1",
+		}.Marshal()
+		assert.Error(t, err)
+	})
+
+	t.Run("IntelVIOMMURequiresQ35", func(t *testing.T) {
+		_, err := qemu.MachineProperties{VIOMMU: qemu.VIOMMUIntel}.Marshal()
+		assert.Error(t, err)
+	})
+}
@@ -15,6 +15,9 @@ type MemoryProperties struct {
 	Ballooning    bool
 	MinimumMemory uint
 	Shares        uint
+
+	HugePages     HugePageSize
+	KeepHugePages bool
 }
 
 const (
@@ -22,6 +25,9 @@ const (
 	mkMemoryPropertyBalloon = "balloon"
 	mkMemoryPropertyShares  = "shares"
 
+	mkMemoryPropertyHugePages     = "hugepages"
+	mkMemoryPropertyKeepHugePages = "keephugepages"
+
 	DefaultMemoryShares uint = 1000
 )
 
@@ -93,6 +99,22 @@ func NewMemoryProperties(
 
 			return nil
 		},
+		func() error {
+			return props.SetFixedValue(
+				mkMemoryPropertyHugePages,
+				&obj.HugePages,
+				HugePageSize(""),
+				nil,
+			)
+		},
+		func() error {
+			return props.SetBool(
+				mkMemoryPropertyKeepHugePages,
+				&obj.KeepHugePages,
+				false,
+				nil,
+			)
+		},
 	)
 }
 
@@ -131,5 +153,14 @@ func (obj MemoryProperties) MapToValues() (request.Values, error) {
 		values.AddUint("shares", 0)
 	}
 
+	if obj.HugePages != "" {
+		if !obj.HugePages.IsValid() {
+			return nil, fmt.Errorf("Invalid hugepages size %s", obj.HugePages)
+		}
+
+		values.AddString("hugepages", string(obj.HugePages))
+		values.ConditionalAddBool("keephugepages", obj.KeepHugePages, obj.KeepHugePages)
+	}
+
 	return values, nil
 }
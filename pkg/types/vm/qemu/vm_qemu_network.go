@@ -22,11 +22,16 @@ type NetworkInterfaceProperties struct {
 	Multiqueue    int
 }
 
+func (obj NetworkInterfaceProperties) Name() string {
+	return fmt.Sprintf("net%d", obj.DeviceNumber)
+}
+
 func NewNetworkInterfaceProperties(
 	deviceNumber int,
 	media string,
 ) (obj NetworkInterfaceProperties, err error) {
 	obj.DeviceNumber = deviceNumber
+	obj.Enabled = true
 
 	props := internal_types.PVEDictionary{
 		ListSeparator:     ",",
@@ -59,9 +64,12 @@ func NewNetworkInterfaceProperties(
 				return obj, err
 			}
 		case "link_down":
-			if obj.Enabled, err = kv.ValueAsBool(); err != nil {
+			linkDown, err := kv.ValueAsBool()
+			if err != nil {
 				return obj, err
 			}
+
+			obj.Enabled = !linkDown
 		case "firewall":
 			if obj.EnableFirewall, err = kv.ValueAsBool(); err != nil {
 				return obj, err
@@ -81,3 +89,43 @@ func NewNetworkInterfaceProperties(
 
 	return obj, nil
 }
+
+func (obj NetworkInterfaceProperties) Marshal() (string, error) {
+	if obj.Model == "" {
+		return "", fmt.Errorf("network interface %s has no model", obj.Name())
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+
+	if obj.MACAddress != "" {
+		content.Append(fmt.Sprintf("%s=%s", obj.Model, obj.MACAddress))
+	} else {
+		content.Append(string(obj.Model))
+	}
+
+	if obj.Bridge != "" {
+		content.Append(fmt.Sprintf("bridge=%s", obj.Bridge))
+	}
+
+	if obj.VLAN != 0 {
+		content.Append(fmt.Sprintf("tag=%d", obj.VLAN))
+	}
+
+	if obj.EnableFirewall {
+		content.Append("firewall=1")
+	}
+
+	if !obj.Enabled {
+		content.Append("link_down=1")
+	}
+
+	if obj.RateLimitMBps != 0 {
+		content.Append(fmt.Sprintf("rate=%d", obj.RateLimitMBps))
+	}
+
+	if obj.Multiqueue != 0 {
+		content.Append(fmt.Sprintf("queues=%d", obj.Multiqueue))
+	}
+
+	return content.Marshal()
+}
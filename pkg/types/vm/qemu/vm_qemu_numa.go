@@ -0,0 +1,261 @@
+package qemu
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/errors"
+)
+
+const maxNUMANodePropertiesArrayCapacity = 8
+
+// IDRange is an inclusive range of CPU or host node ids.
+type IDRange struct {
+	Start uint
+	End   uint
+}
+
+func (obj IDRange) Len() uint {
+	return obj.End - obj.Start + 1
+}
+
+func (obj IDRange) String() string {
+	if obj.Start == obj.End {
+		return strconv.FormatUint(uint64(obj.Start), 10)
+	}
+
+	return fmt.Sprintf("%d-%d", obj.Start, obj.End)
+}
+
+func newIDRanges(s string) ([]IDRange, error) {
+	var ranges []IDRange
+
+	for _, r := range strings.Split(s, ";") {
+		bounds := strings.SplitN(r, "-", 2)
+
+		start, err := strconv.ParseUint(bounds[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id range %s", r)
+		}
+
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.ParseUint(bounds[1], 10, 32); err != nil || end < start {
+				return nil, fmt.Errorf("invalid id range %s", r)
+			}
+		}
+
+		ranges = append(ranges, IDRange{Start: uint(start), End: uint(end)})
+	}
+
+	return ranges, nil
+}
+
+func marshalIDRanges(ranges []IDRange) string {
+	s := make([]string, len(ranges))
+	for i, r := range ranges {
+		s[i] = r.String()
+	}
+
+	return strings.Join(s, ";")
+}
+
+// NUMANodeProperties is a numaN guest node, with its vCPUs and memory in
+// MiB, optionally bound to HostNodes with Policy.
+type NUMANodeProperties struct {
+	DeviceNumber int
+
+	CPUs   []IDRange
+	Memory uint
+
+	HostNodes []IDRange
+	Policy    NUMAPolicy
+}
+
+func (obj NUMANodeProperties) Name() string {
+	return fmt.Sprintf("numa%d", obj.DeviceNumber)
+}
+
+func NewNUMANodeProperties(
+	deviceNumber int,
+	media string,
+) (obj NUMANodeProperties, err error) {
+	obj.DeviceNumber = deviceNumber
+
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      false,
+	}
+
+	if err := (&props).Unmarshal(media); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		switch kv.Key() {
+		case "cpus":
+			obj.CPUs, err = newIDRanges(kv.Value())
+		case "memory":
+			var memory int
+			memory, err = kv.ValueAsInt()
+			obj.Memory = uint(memory)
+		case "hostnodes":
+			obj.HostNodes, err = newIDRanges(kv.Value())
+		case "policy":
+			err = (&obj.Policy).Unmarshal(kv.Value())
+		default:
+			err = fmt.Errorf("unknown property %s", kv.Key())
+		}
+
+		if err != nil {
+			return obj, err
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj NUMANodeProperties) Marshal() (string, error) {
+	if len(obj.CPUs) == 0 {
+		return "", fmt.Errorf("numa node %s has no cpus", obj.Name())
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(fmt.Sprintf("cpus=%s", marshalIDRanges(obj.CPUs)))
+
+	if obj.Memory != 0 {
+		content.Append(fmt.Sprintf("memory=%d", obj.Memory))
+	}
+
+	if len(obj.HostNodes) != 0 {
+		content.Append(fmt.Sprintf("hostnodes=%s", marshalIDRanges(obj.HostNodes)))
+	}
+
+	if obj.Policy != "" {
+		if !obj.Policy.IsValid() {
+			return "", fmt.Errorf("invalid numa policy %s", obj.Policy)
+		}
+
+		content.Append(fmt.Sprintf("policy=%s", obj.Policy))
+	}
+
+	return content.Marshal()
+}
+
+func newNUMANodes(props types.Properties) ([]NUMANodeProperties, error) {
+	var nodes []NUMANodeProperties
+
+	for i := 0; i < maxNUMANodePropertiesArrayCapacity; i++ {
+		propName := fmt.Sprintf("numa%d", i)
+		prop, ok := props[propName]
+		if !ok {
+			continue
+		}
+
+		media, ok := prop.(string)
+		if !ok {
+			err := errors.ErrInvalidProperty
+			err.AddKey("name", propName)
+			err.AddKey("value", prop)
+			return nil, err
+		}
+
+		node, err := NewNUMANodeProperties(i, media)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// validateNUMA checks that the NUMA nodes take every vCPU exactly once and
+// that memory is aligned to hugepages. Node memory is optional, as PVE
+// splits it evenly when no node sets it, so it's only checked to add up to
+// the guest memory when any node does.
+func (obj Properties) validateNUMA() error {
+	if (len(obj.CPU.NUMANodes) != 0 || obj.Memory.HugePages != "") && !obj.CPU.NUMA {
+		return fmt.Errorf("numa nodes and hugepages require numa to be enabled")
+	}
+
+	pageSize := uint(0)
+	if obj.Memory.HugePages != "" && obj.Memory.HugePages != HugePageSizeAny {
+		size, err := strconv.ParseUint(string(obj.Memory.HugePages), 10, 32)
+		if err != nil || !obj.Memory.HugePages.IsValid() {
+			return fmt.Errorf("invalid hugepages size %s", obj.Memory.HugePages)
+		}
+
+		pageSize = uint(size)
+	}
+
+	if pageSize != 0 && obj.Memory.Memory%pageSize != 0 {
+		return fmt.Errorf(
+			"memory %d is not aligned to %d MiB hugepages",
+			obj.Memory.Memory,
+			pageSize,
+		)
+	}
+
+	if len(obj.CPU.NUMANodes) == 0 {
+		return nil
+	}
+
+	vcpus := obj.CPU.Count()
+
+	var (
+		cpus   []IDRange
+		memory uint
+	)
+
+	for _, node := range obj.CPU.NUMANodes {
+		if pageSize != 0 && node.Memory%pageSize != 0 {
+			return fmt.Errorf(
+				"numa node %s memory is not aligned to %d MiB hugepages",
+				node.Name(),
+				pageSize,
+			)
+		}
+
+		cpus = append(cpus, node.CPUs...)
+		memory += node.Memory
+	}
+
+	sort.Slice(cpus, func(i, j int) bool {
+		return cpus[i].Start < cpus[j].Start
+	})
+
+	next := uint(0)
+
+	for _, r := range cpus {
+		if r.Start != next {
+			return fmt.Errorf("numa nodes don't assign cpu %d exactly once", next)
+		}
+
+		next = r.End + 1
+	}
+
+	if next != vcpus {
+		return fmt.Errorf(
+			"numa nodes assign %d cpus, but the guest has %d",
+			next,
+			vcpus,
+		)
+	}
+
+	if memory != 0 && memory != obj.Memory.Memory {
+		return fmt.Errorf(
+			"numa nodes assign %d MiB of memory, but the guest has %d MiB",
+			memory,
+			obj.Memory.Memory,
+		)
+	}
+
+	return nil
+}
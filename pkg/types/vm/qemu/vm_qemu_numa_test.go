@@ -0,0 +1,152 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestNUMANodeProperties(t *testing.T) {
+	options := map[string]struct {
+		Object qemu.NUMANodeProperties
+		Value  string
+	}{
+		"Simple": {
+			Object: qemu.NUMANodeProperties{
+				DeviceNumber: 0,
+				CPUs:         []qemu.IDRange{{Start: 0, End: 3}},
+				Memory:       4096,
+			},
+			Value: "cpus=0-3,memory=4096",
+		},
+		"Bound": {
+			Object: qemu.NUMANodeProperties{
+				DeviceNumber: 1,
+				CPUs: []qemu.IDRange{
+					{Start: 4, End: 5},
+					{Start: 8, End: 8},
+				},
+				Memory:    2048,
+				HostNodes: []qemu.IDRange{{Start: 1, End: 1}},
+				Policy:    qemu.NUMAPolicyBind,
+			},
+			Value: "cpus=4-5;8,memory=2048,hostnodes=1,policy=bind",
+		},
+	}
+
+	for n, tt := range options {
+		tt := tt
+
+		t.Run(n, func(t *testing.T) {
+			t.Run("Marshal", func(t *testing.T) {
+				value, err := tt.Object.Marshal()
+				require.NoError(t, err)
+				assert.Equal(t, tt.Value, value)
+			})
+
+			t.Run("Unmarshal", func(t *testing.T) {
+				obj, err := qemu.NewNUMANodeProperties(
+					tt.Object.DeviceNumber,
+					tt.Value,
+				)
+				require.NoError(t, err)
+				assert.Equal(t, tt.Object, obj)
+			})
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, value := range []string{
+			"cpus=3-1",
+			"cpus=a,memory=1024",
+		} {
+			_, err := qemu.NewNUMANodeProperties(0, value)
+			assert.Error(t, err, value)
+		}
+
+		obj, err := qemu.NewNUMANodeProperties(0, "cpus=0,policy=random")
+		require.NoError(t, err)
+		assert.True(t, obj.Policy.IsUnknown())
+
+		_, err = obj.Marshal()
+		assert.Error(t, err)
+	})
+}
+
+func TestPropertiesNUMA(t *testing.T) {
+	props := test.HelperCreatePropertiesMap(types.Properties{
+		"ostype":    "l26",
+		"sockets":   2,
+		"cores":     4,
+		"memory":    8192,
+		"numa":      1,
+		"numa0":     "cpus=0-3,hostnodes=0,memory=4096,policy=bind",
+		"numa1":     "cpus=4-7,hostnodes=1,memory=4096,policy=bind",
+		"hugepages": "1024",
+		"digest":    "0000000000000000000000000000000000000000",
+	})
+
+	obj, err := qemu.NewProperties(props)
+	require.NoError(t, err)
+	require.Len(t, obj.CPU.NUMANodes, 2)
+	assert.Equal(t, qemu.HugePageSize1G, obj.Memory.HugePages)
+
+	values, err := obj.MapToValues()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpus=0-3,memory=4096,hostnodes=0,policy=bind"}, values["numa0"])
+	assert.Equal(t, []string{"cpus=4-7,memory=4096,hostnodes=1,policy=bind"}, values["numa1"])
+	assert.Equal(t, []string{"1024"}, values["hugepages"])
+
+	t.Run("WithoutMemory", func(t *testing.T) {
+		obj, err := qemu.NewProperties(props)
+		require.NoError(t, err)
+
+		obj.CPU.NUMANodes[0].Memory = 0
+		obj.CPU.NUMANodes[1].Memory = 0
+
+		values, err := obj.MapToValues()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cpus=0-3,hostnodes=0,policy=bind"}, values["numa0"])
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		options := map[string]func(obj *qemu.Properties){
+			"NUMADisabled": func(obj *qemu.Properties) {
+				obj.CPU.NUMA = false
+			},
+			"MissingCPUs": func(obj *qemu.Properties) {
+				obj.CPU.NUMANodes[1].CPUs = []qemu.IDRange{{Start: 4, End: 6}}
+			},
+			"OverlappingCPUs": func(obj *qemu.Properties) {
+				obj.CPU.NUMANodes[1].CPUs = []qemu.IDRange{{Start: 3, End: 7}}
+			},
+			"ExtraCPUs": func(obj *qemu.Properties) {
+				obj.CPU.NUMANodes[1].CPUs = []qemu.IDRange{{Start: 4, End: 8}}
+			},
+			"Memory": func(obj *qemu.Properties) {
+				obj.CPU.NUMANodes[1].Memory = 2048
+			},
+			"HugePagesAlignment": func(obj *qemu.Properties) {
+				obj.Memory.Memory = 7680
+				obj.CPU.NUMANodes[0].Memory = 3584
+				obj.CPU.NUMANodes[1].Memory = 4096
+			},
+		}
+
+		for n, fn := range options {
+			fn := fn
+
+			t.Run(n, func(t *testing.T) {
+				obj, err := qemu.NewProperties(props)
+				require.NoError(t, err)
+
+				fn(&obj)
+				assert.Error(t, obj.Validate())
+			})
+		}
+	})
+}
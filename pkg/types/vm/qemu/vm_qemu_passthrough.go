@@ -0,0 +1,370 @@
+package qemu
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/errors"
+)
+
+const (
+	maxPCIDevicePropertiesArrayCapacity = 16
+	maxUSBDevicePropertiesArrayCapacity = 14
+
+	// USBHostSPICE redirects a client USB device through SPICE.
+	USBHostSPICE = "spice"
+
+	DefaultPCIDeviceROMBar bool = true
+)
+
+var (
+	pciHostIDRegExp = regexp.MustCompile(
+		`^([0-9a-fA-F]{4}:)?[0-9a-fA-F]{2}:[0-9a-fA-F]{2}(\.[0-7])?$`,
+	)
+	usbVendorProductRegExp = regexp.MustCompile(
+		`^(0x)?[0-9a-fA-F]{4}:(0x)?[0-9a-fA-F]{4}$`,
+	)
+	usbBusPortRegExp = regexp.MustCompile(`^\d+-\d+(\.\d+)*$`)
+)
+
+// PCIDeviceProperties is a hostpciN device. It either passes the host
+// functions in HostIDs, or a cluster wide resource Mapping.
+type PCIDeviceProperties struct {
+	DeviceNumber int
+
+	HostIDs []string
+	Mapping string
+
+	PCIExpress     bool
+	ROMBar         bool
+	ROMFile        string
+	PrimaryGPU     bool
+	LegacyIGD      bool
+	MediatedDevice string
+
+	// VendorID, DeviceID, SubsystemVendorID and SubsystemDeviceID override
+	// the ids the guest sees, in hexadecimal as in 0x8086.
+	VendorID          string
+	DeviceID          string
+	SubsystemVendorID string
+	SubsystemDeviceID string
+}
+
+func (obj PCIDeviceProperties) Name() string {
+	return fmt.Sprintf("hostpci%d", obj.DeviceNumber)
+}
+
+// IsMultiFunction returns true when the device passes every function of the
+// host devices, which happens when their ids have no function number.
+func (obj PCIDeviceProperties) IsMultiFunction() bool {
+	for _, id := range obj.HostIDs {
+		if strings.Contains(id, ".") {
+			return false
+		}
+	}
+
+	return len(obj.HostIDs) != 0
+}
+
+func NewPCIDeviceProperties(
+	deviceNumber int,
+	media string,
+) (obj PCIDeviceProperties, err error) {
+	obj.DeviceNumber = deviceNumber
+	obj.ROMBar = DefaultPCIDeviceROMBar
+
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      true,
+	}
+
+	if err := (&props).Unmarshal(media); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		if !kv.HasValue() {
+			obj.HostIDs = strings.Split(kv.Key(), ";")
+			continue
+		}
+
+		switch kv.Key() {
+		case "host":
+			obj.HostIDs = strings.Split(kv.Value(), ";")
+		case "mapping":
+			obj.Mapping = kv.Value()
+		case "pcie":
+			if obj.PCIExpress, err = kv.ValueAsBool(); err != nil {
+				return obj, err
+			}
+		case "rombar":
+			if obj.ROMBar, err = kv.ValueAsBool(); err != nil {
+				return obj, err
+			}
+		case "romfile":
+			obj.ROMFile = kv.Value()
+		case "x-vga":
+			if obj.PrimaryGPU, err = kv.ValueAsBool(); err != nil {
+				return obj, err
+			}
+		case "legacy-igd":
+			if obj.LegacyIGD, err = kv.ValueAsBool(); err != nil {
+				return obj, err
+			}
+		case "mdev":
+			obj.MediatedDevice = kv.Value()
+		case "vendor-id":
+			obj.VendorID = kv.Value()
+		case "device-id":
+			obj.DeviceID = kv.Value()
+		case "sub-vendor-id":
+			obj.SubsystemVendorID = kv.Value()
+		case "sub-device-id":
+			obj.SubsystemDeviceID = kv.Value()
+		default:
+			return obj, fmt.Errorf("unknown property %s", kv.Key())
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj PCIDeviceProperties) Validate() error {
+	if (len(obj.HostIDs) == 0) == (obj.Mapping == "") {
+		return fmt.Errorf(
+			"pci device %s needs either host ids or a mapping",
+			obj.Name(),
+		)
+	}
+
+	for _, id := range obj.HostIDs {
+		if !pciHostIDRegExp.MatchString(id) {
+			return fmt.Errorf("invalid pci host id %s", id)
+		}
+	}
+
+	if obj.MediatedDevice != "" && (len(obj.HostIDs) > 1 || obj.IsMultiFunction()) {
+		return fmt.Errorf(
+			"mediated device on %s requires a single host function",
+			obj.Name(),
+		)
+	}
+
+	return nil
+}
+
+func (obj PCIDeviceProperties) Marshal() (string, error) {
+	if err := obj.Validate(); err != nil {
+		return "", err
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+
+	if obj.Mapping != "" {
+		content.Append(fmt.Sprintf("mapping=%s", obj.Mapping))
+	} else {
+		content.Append(strings.Join(obj.HostIDs, ";"))
+	}
+
+	if obj.PCIExpress {
+		content.Append("pcie=1")
+	}
+
+	if obj.ROMBar != DefaultPCIDeviceROMBar {
+		content.Append(
+			fmt.Sprintf("rombar=%s", internal_types.PVEBool(obj.ROMBar)),
+		)
+	}
+
+	if obj.ROMFile != "" {
+		content.Append(fmt.Sprintf("romfile=%s", obj.ROMFile))
+	}
+
+	if obj.PrimaryGPU {
+		content.Append("x-vga=1")
+	}
+
+	if obj.LegacyIGD {
+		content.Append("legacy-igd=1")
+	}
+
+	if obj.MediatedDevice != "" {
+		content.Append(fmt.Sprintf("mdev=%s", obj.MediatedDevice))
+	}
+
+	for _, id := range []struct {
+		Key   string
+		Value string
+	}{
+		{"vendor-id", obj.VendorID},
+		{"device-id", obj.DeviceID},
+		{"sub-vendor-id", obj.SubsystemVendorID},
+		{"sub-device-id", obj.SubsystemDeviceID},
+	} {
+		if id.Value != "" {
+			content.Append(fmt.Sprintf("%s=%s", id.Key, id.Value))
+		}
+	}
+
+	return content.Marshal()
+}
+
+// USBDeviceProperties is a usbN device. Host is either a vendor:product id
+// or a bus-port path, and SPICE redirects a device from the client instead.
+type USBDeviceProperties struct {
+	DeviceNumber int
+
+	Host    string
+	Mapping string
+	SPICE   bool
+
+	USB3 bool
+}
+
+func (obj USBDeviceProperties) Name() string {
+	return fmt.Sprintf("usb%d", obj.DeviceNumber)
+}
+
+func (obj USBDeviceProperties) IsVendorProduct() bool {
+	return usbVendorProductRegExp.MatchString(obj.Host)
+}
+
+func (obj USBDeviceProperties) IsBusPort() bool {
+	return usbBusPortRegExp.MatchString(obj.Host)
+}
+
+func NewUSBDeviceProperties(
+	deviceNumber int,
+	media string,
+) (obj USBDeviceProperties, err error) {
+	obj.DeviceNumber = deviceNumber
+
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      true,
+	}
+
+	if err := (&props).Unmarshal(media); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		key, value := kv.Key(), kv.Value()
+		if !kv.HasValue() {
+			key, value = "host", kv.Key()
+		}
+
+		switch key {
+		case "host":
+			if value == USBHostSPICE {
+				obj.SPICE = true
+			} else {
+				obj.Host = value
+			}
+		case "mapping":
+			obj.Mapping = value
+		case "usb3":
+			if obj.USB3, err = kv.ValueAsBool(); err != nil {
+				return obj, err
+			}
+		default:
+			return obj, fmt.Errorf("unknown property %s", key)
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj USBDeviceProperties) Validate() error {
+	sources := 0
+
+	for _, set := range []bool{obj.Host != "", obj.Mapping != "", obj.SPICE} {
+		if set {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return fmt.Errorf(
+			"usb device %s needs exactly one of host, mapping or spice",
+			obj.Name(),
+		)
+	}
+
+	if obj.Host != "" && !obj.IsVendorProduct() && !obj.IsBusPort() {
+		return fmt.Errorf("invalid usb host device %s", obj.Host)
+	}
+
+	return nil
+}
+
+func (obj USBDeviceProperties) Marshal() (string, error) {
+	if err := obj.Validate(); err != nil {
+		return "", err
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+
+	switch {
+	case obj.Mapping != "":
+		content.Append(fmt.Sprintf("mapping=%s", obj.Mapping))
+	case obj.SPICE:
+		content.Append(fmt.Sprintf("host=%s", USBHostSPICE))
+	default:
+		content.Append(fmt.Sprintf("host=%s", obj.Host))
+	}
+
+	if obj.USB3 {
+		content.Append("usb3=1")
+	}
+
+	return content.Marshal()
+}
+
+func newPassthroughDevices(
+	props types.Properties,
+) (pci []PCIDeviceProperties, usb []USBDeviceProperties, err error) {
+	for _, x := range []struct {
+		prefix   string
+		capacity int
+		add      func(i int, media string) error
+	}{
+		{"hostpci", maxPCIDevicePropertiesArrayCapacity, func(i int, media string) error {
+			device, err := NewPCIDeviceProperties(i, media)
+			pci = append(pci, device)
+			return err
+		}},
+		{"usb", maxUSBDevicePropertiesArrayCapacity, func(i int, media string) error {
+			device, err := NewUSBDeviceProperties(i, media)
+			usb = append(usb, device)
+			return err
+		}},
+	} {
+		for i := 0; i < x.capacity; i++ {
+			propName := fmt.Sprintf("%s%d", x.prefix, i)
+			prop, ok := props[propName]
+			if !ok {
+				continue
+			}
+
+			media, ok := prop.(string)
+			if !ok {
+				err := errors.ErrInvalidProperty
+				err.AddKey("name", propName)
+				err.AddKey("value", prop)
+				return nil, nil, err
+			}
+
+			if err := x.add(i, media); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return pci, usb, nil
+}
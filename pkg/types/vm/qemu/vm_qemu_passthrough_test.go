@@ -0,0 +1,204 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestPCIDeviceProperties(t *testing.T) {
+	options := map[string]struct {
+		Object qemu.PCIDeviceProperties
+		Value  string
+	}{
+		"GPU": {
+			Object: qemu.PCIDeviceProperties{
+				DeviceNumber: 0,
+				HostIDs:      []string{"0000:01:00"},
+				PCIExpress:   true,
+				ROMBar:       true,
+				PrimaryGPU:   true,
+			},
+			Value: "0000:01:00,pcie=1,x-vga=1",
+		},
+		"Functions": {
+			Object: qemu.PCIDeviceProperties{
+				DeviceNumber: 1,
+				HostIDs:      []string{"02:00.0", "02:00.1"},
+				ROMFile:      "vbios.bin",
+			},
+			Value: "02:00.0;02:00.1,rombar=0,romfile=vbios.bin",
+		},
+		"Mapping": {
+			Object: qemu.PCIDeviceProperties{
+				DeviceNumber:   2,
+				Mapping:        "vgpu",
+				ROMBar:         true,
+				MediatedDevice: "nvidia-63",
+			},
+			Value: "mapping=vgpu,mdev=nvidia-63",
+		},
+		"IntegratedGPU": {
+			Object: qemu.PCIDeviceProperties{
+				DeviceNumber:      3,
+				HostIDs:           []string{"00:02.0"},
+				ROMBar:            true,
+				LegacyIGD:         true,
+				VendorID:          "0x8086",
+				DeviceID:          "0x3e92",
+				SubsystemVendorID: "0x1458",
+				SubsystemDeviceID: "0xd000",
+			},
+			Value: "00:02.0,legacy-igd=1,vendor-id=0x8086,device-id=0x3e92,sub-vendor-id=0x1458,sub-device-id=0xd000",
+		},
+	}
+
+	for n, tt := range options {
+		tt := tt
+
+		t.Run(n, func(t *testing.T) {
+			t.Run("Marshal", func(t *testing.T) {
+				value, err := tt.Object.Marshal()
+				require.NoError(t, err)
+				assert.Equal(t, tt.Value, value)
+			})
+
+			t.Run("Unmarshal", func(t *testing.T) {
+				obj, err := qemu.NewPCIDeviceProperties(
+					tt.Object.DeviceNumber,
+					tt.Value,
+				)
+				require.NoError(t, err)
+				assert.Equal(t, tt.Object, obj)
+			})
+		})
+	}
+
+	t.Run("IsMultiFunction", func(t *testing.T) {
+		assert.True(t, options["GPU"].Object.IsMultiFunction())
+		assert.False(t, options["Functions"].Object.IsMultiFunction())
+		assert.False(t, options["Mapping"].Object.IsMultiFunction())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, obj := range []qemu.PCIDeviceProperties{
+			{},
+			{HostIDs: []string{"01:00.0"}, Mapping: "gpu"},
+			{HostIDs: []string{"not-a-pci-id"}},
+			{HostIDs: []string{"01:00"}, MediatedDevice: "nvidia-63"},
+		} {
+			assert.Error(t, obj.Validate())
+		}
+	})
+}
+
+func TestUSBDeviceProperties(t *testing.T) {
+	options := map[string]struct {
+		Object qemu.USBDeviceProperties
+		Value  string
+	}{
+		"VendorProduct": {
+			Object: qemu.USBDeviceProperties{
+				DeviceNumber: 0,
+				Host:         "0951:1666",
+				USB3:         true,
+			},
+			Value: "host=0951:1666,usb3=1",
+		},
+		"BusPort": {
+			Object: qemu.USBDeviceProperties{
+				DeviceNumber: 1,
+				Host:         "1-2.3",
+			},
+			Value: "host=1-2.3",
+		},
+		"SPICE": {
+			Object: qemu.USBDeviceProperties{
+				DeviceNumber: 2,
+				SPICE:        true,
+			},
+			Value: "host=spice",
+		},
+		"Mapping": {
+			Object: qemu.USBDeviceProperties{
+				DeviceNumber: 3,
+				Mapping:      "dongle",
+			},
+			Value: "mapping=dongle",
+		},
+	}
+
+	for n, tt := range options {
+		tt := tt
+
+		t.Run(n, func(t *testing.T) {
+			t.Run("Marshal", func(t *testing.T) {
+				value, err := tt.Object.Marshal()
+				require.NoError(t, err)
+				assert.Equal(t, tt.Value, value)
+			})
+
+			t.Run("Unmarshal", func(t *testing.T) {
+				obj, err := qemu.NewUSBDeviceProperties(
+					tt.Object.DeviceNumber,
+					tt.Value,
+				)
+				require.NoError(t, err)
+				assert.Equal(t, tt.Object, obj)
+			})
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, obj := range []qemu.USBDeviceProperties{
+			{},
+			{Host: "0951:1666", SPICE: true},
+			{Host: "usb-stick"},
+		} {
+			assert.Error(t, obj.Validate())
+		}
+	})
+}
+
+func TestPropertiesPassthrough(t *testing.T) {
+	props := test.HelperCreatePropertiesMap(types.Properties{
+		"ostype":   "l26",
+		"sockets":  1,
+		"cores":    1,
+		"memory":   4096,
+		"hostpci0": "0000:01:00,pcie=1,x-vga=1",
+		"usb0":     "host=0951:1666",
+		"digest":   "0000000000000000000000000000000000000000",
+	})
+
+	t.Run("RequiresQ35", func(t *testing.T) {
+		obj, err := qemu.NewProperties(props)
+		require.NoError(t, err)
+		require.Len(t, obj.PCIDevices, 1)
+		require.Len(t, obj.USBDevices, 1)
+
+		assert.Error(t, obj.Validate())
+
+		_, err = obj.MapToValues()
+		assert.Error(t, err)
+	})
+
+	t.Run("Q35", func(t *testing.T) {
+		props := test.HelperCreatePropertiesMap(props)
+		props["machine"] = "pc-q35-5.1"
+
+		obj, err := qemu.NewProperties(props)
+		require.NoError(t, err)
+		assert.True(t, obj.IsQ35())
+
+		values, err := obj.MapToValues()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0000:01:00,pcie=1,x-vga=1"}, values["hostpci0"])
+		assert.Equal(t, []string{"host=0951:1666"}, values["usb0"])
+		assert.Equal(t, []string{"pc-q35-5.1"}, values["machine"])
+	})
+}
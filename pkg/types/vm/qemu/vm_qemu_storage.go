@@ -5,14 +5,22 @@ import (
 	"strings"
 
 	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
 	"github.com/xabinapal/gopve/pkg/types"
 	"github.com/xabinapal/gopve/pkg/types/errors"
+	"github.com/xabinapal/gopve/pkg/types/storage"
 )
 
 type StorageProperties struct {
 	HardDrives []HardDriveProperties
 	CDROMs     []CDROMProperties
 	EFIDisk    EFIDiskProperties
+	TPMState   TPMStateProperties
+
+	// Unused is read only, PVE manages the unusedN keys itself, so they
+	// are never serialized and dropping an entry doesn't delete anything.
+	// Use DeleteUnusedDisk to destroy an unused volume.
+	Unused []UnusedDiskProperties
 }
 
 const (
@@ -20,6 +28,7 @@ const (
 	maxSATAPropertiesArrayCapacity   = 6
 	maxSCSIPropertiesArrayCapacity   = 31
 	maxVirtIOPropertiesArrayCapacity = 16
+	maxUnusedPropertiesArrayCapacity = 256
 )
 
 func NewStorageProperties(
@@ -95,6 +104,44 @@ func NewStorageProperties(
 		}
 	}
 
+	if prop, ok := props["tpmstate0"]; ok {
+		media, ok := prop.(string)
+		if !ok {
+			err := errors.ErrInvalidProperty
+			err.AddKey("name", "tpmstate0")
+			err.AddKey("value", prop)
+			return obj, err
+		}
+
+		if tpmState, err := NewTPMStateProperties(media); err == nil {
+			obj.TPMState = tpmState
+		} else {
+			return obj, err
+		}
+	}
+
+	for i := 0; i < maxUnusedPropertiesArrayCapacity; i++ {
+		propName := fmt.Sprintf("unused%d", i)
+		prop, ok := props[propName]
+		if !ok {
+			continue
+		}
+
+		volume, ok := prop.(string)
+		if !ok {
+			err := errors.ErrInvalidProperty
+			err.AddKey("name", propName)
+			err.AddKey("value", prop)
+			return obj, err
+		}
+
+		if unused, err := NewUnusedDiskProperties(i, volume); err == nil {
+			obj.Unused = append(obj.Unused, unused)
+		} else {
+			return obj, err
+		}
+	}
+
 	return obj, nil
 }
 
@@ -117,6 +164,8 @@ func NewStorageDrive(
 		switch x {
 		case "cdrom":
 			return NewCDROMProperties(deviceBus, deviceNumber, props)
+		case "disk":
+			return NewHardDriveProperties(deviceBus, deviceNumber, props)
 		default:
 			return nil, fmt.Errorf("unknown media type %s", x)
 		}
@@ -157,14 +206,24 @@ func (obj *DriveStorageProperties) setProperties(
 	return nil
 }
 
+func (obj DriveStorageProperties) marshal(prefix string) string {
+	return fmt.Sprintf("%s:%s%s", obj.StorageName, prefix, obj.StorageFile)
+}
+
 type HardDriveProperties struct {
 	DeviceBus    Bus
 	DeviceNumber int
 
 	DriveStorageProperties
 
-	Size  string
-	Cache HardDriveCache
+	Size   string
+	Format storage.ImageFormat
+	Cache  HardDriveCache
+
+	// ImportFrom is a volume or an absolute path whose content is copied
+	// into a new disk. Only used when creating the disk, use STORAGE:0 as
+	// the storage file.
+	ImportFrom string
 
 	Discard    bool
 	EmulateSSD bool
@@ -192,6 +251,10 @@ const (
 	DefaultHardDriveReplicate  bool = true
 )
 
+func (obj HardDriveProperties) Name() string {
+	return fmt.Sprintf("%s%d", obj.DeviceBus.String(), obj.DeviceNumber)
+}
+
 func NewHardDriveProperties(
 	deviceBus Bus,
 	deviceNumber int,
@@ -217,16 +280,22 @@ func NewHardDriveProperties(
 		}
 
 		switch kv.Key() {
+		case "media":
+			continue
 		case "size":
 			obj.Size = kv.Value()
+		case "format":
+			if err := (&obj.Format).Unmarshal(kv.Value()); err != nil {
+				return obj, err
+			}
 		case "cache":
 			if err := (&obj.Cache).Unmarshal(kv.Value()); err != nil {
 				return obj, err
 			}
+		case "import-from":
+			obj.ImportFrom = kv.Value()
 		case "discard":
-			if obj.Discard, err = kv.ValueAsBool(); err != nil {
-				return obj, err
-			}
+			obj.Discard = kv.Value() == "on"
 		case "ssd":
 			if obj.EmulateSSD, err = kv.ValueAsBool(); err != nil {
 				return obj, err
@@ -255,7 +324,7 @@ func NewHardDriveProperties(
 			if obj.ReadMBBurst, err = kv.ValueAsInt(); err != nil {
 				return obj, err
 			}
-		case "ios_rd_max":
+		case "iops_rd_max":
 			if obj.ReadIOPSBurst, err = kv.ValueAsInt(); err != nil {
 				return obj, err
 			}
@@ -271,7 +340,7 @@ func NewHardDriveProperties(
 			if obj.WriteMBBurst, err = kv.ValueAsInt(); err != nil {
 				return obj, err
 			}
-		case "ios_wr_max":
+		case "iops_wr_max":
 			if obj.WriteIOPSBurst, err = kv.ValueAsInt(); err != nil {
 				return obj, err
 			}
@@ -300,8 +369,17 @@ const (
 	CDROMSourceNone CDROMSource = iota
 	CDROMSourcePhysical
 	CDROMSourceISOFile
+	CDROMSourceCloudInit
 )
 
+// CloudInitVolume is the storage file that makes PVE allocate a new
+// cloud-init drive when used in a CDROM.
+const CloudInitVolume = "cloudinit"
+
+func (obj CDROMProperties) Name() string {
+	return fmt.Sprintf("%s%d", obj.DeviceBus.String(), obj.DeviceNumber)
+}
+
 func NewCDROMProperties(
 	deviceBus Bus,
 	deviceNumber int,
@@ -318,6 +396,15 @@ func NewCDROMProperties(
 			case "cdrom":
 				obj.Source = CDROMSourcePhysical
 			default:
+				if strings.HasSuffix(kv.Key(), CloudInitVolume) {
+					obj.Source = CDROMSourceCloudInit
+					if err := (&obj.DriveStorageProperties).setProperties(kv.Key(), ""); err != nil {
+						return obj, err
+					}
+
+					continue
+				}
+
 				obj.Source = CDROMSourceISOFile
 				if err := (&obj.DriveStorageProperties).setProperties(kv.Key(), "iso/"); err != nil {
 					return obj, err
@@ -345,6 +432,11 @@ type EFIDiskProperties struct {
 	DriveStorageProperties
 
 	Size string
+
+	// Type is the size of the OVMF variables store, and PreEnrolledKeys
+	// enrolls the distribution and Microsoft keys to allow secure boot.
+	Type            EFIType
+	PreEnrolledKeys bool
 }
 
 func NewEFIDiskProperties(
@@ -372,6 +464,14 @@ func NewEFIDiskProperties(
 		switch kv.Key() {
 		case "size":
 			obj.Size = kv.Value()
+		case "efitype":
+			if err := (&obj.Type).Unmarshal(kv.Value()); err != nil {
+				return obj, err
+			}
+		case "pre-enrolled-keys":
+			if obj.PreEnrolledKeys, err = kv.ValueAsBool(); err != nil {
+				return obj, err
+			}
 		default:
 			err := errors.ErrInvalidProperty
 			err.AddKey("name", "efidisk0")
@@ -381,3 +481,246 @@ func NewEFIDiskProperties(
 
 	return obj, nil
 }
+
+type UnusedDiskProperties struct {
+	DeviceNumber int
+
+	DriveStorageProperties
+}
+
+func NewUnusedDiskProperties(
+	deviceNumber int,
+	volume string,
+) (obj UnusedDiskProperties, err error) {
+	obj.DeviceNumber = deviceNumber
+
+	if err := (&obj.DriveStorageProperties).setProperties(volume, ""); err != nil {
+		return obj, err
+	}
+
+	return obj, nil
+}
+
+func (obj UnusedDiskProperties) Name() string {
+	return fmt.Sprintf("unused%d", obj.DeviceNumber)
+}
+
+func (obj StorageProperties) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	for _, drive := range obj.HardDrives {
+		if err := values.AddObject(drive.Name(), drive); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, cdrom := range obj.CDROMs {
+		if err := values.AddObject(cdrom.Name(), cdrom); err != nil {
+			return nil, err
+		}
+	}
+
+	if obj.EFIDisk.StorageName != "" {
+		if err := values.AddObject("efidisk0", obj.EFIDisk); err != nil {
+			return nil, err
+		}
+	}
+
+	if obj.TPMState.StorageName != "" {
+		if err := values.AddObject("tpmstate0", obj.TPMState); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func (obj HardDriveProperties) Marshal() (string, error) {
+	if obj.StorageName == "" {
+		return "", fmt.Errorf("hard drive %s has no storage", obj.Name())
+	}
+
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(obj.DriveStorageProperties.marshal(""))
+
+	if obj.Size != "" {
+		content.Append(fmt.Sprintf("size=%s", obj.Size))
+	}
+
+	if obj.Format != 0 {
+		format, err := obj.Format.Marshal()
+		if err != nil {
+			return "", err
+		}
+
+		content.Append(fmt.Sprintf("format=%s", format))
+	}
+
+	if obj.Cache != "" && obj.Cache != DefaultHardDriveCache {
+		content.Append(fmt.Sprintf("cache=%s", obj.Cache))
+	}
+
+	if obj.ImportFrom != "" {
+		content.Append(fmt.Sprintf("import-from=%s", obj.ImportFrom))
+	}
+
+	for _, x := range [](struct {
+		Key     string
+		Value   bool
+		Default bool
+	}){
+		{"ssd", obj.EmulateSSD, DefaultHardDriveEmulateSSD},
+		{"iothread", obj.IOThread, DefaultHardDriveIOThread},
+		{"backup", obj.Backup, DefaultHardDriveBackup},
+		{"replicate", obj.Replicate, DefaultHardDriveReplicate},
+	} {
+		if x.Value != x.Default {
+			content.Append(
+				fmt.Sprintf("%s=%s", x.Key, internal_types.PVEBool(x.Value)),
+			)
+		}
+	}
+
+	if obj.Discard {
+		content.Append("discard=on")
+	}
+
+	for _, x := range [](struct {
+		Key   string
+		Value int
+	}){
+		{"mbps_rd", obj.ReadMBLimit},
+		{"iops_rd", obj.ReadIOPSLimit},
+		{"mbps_rd_max", obj.ReadMBBurst},
+		{"iops_rd_max", obj.ReadIOPSBurst},
+		{"mbps_wr", obj.WriteMBLimit},
+		{"iops_wr", obj.WriteIOPSLimit},
+		{"mbps_wr_max", obj.WriteMBBurst},
+		{"iops_wr_max", obj.WriteIOPSBurst},
+	} {
+		if x.Value != 0 {
+			content.Append(fmt.Sprintf("%s=%d", x.Key, x.Value))
+		}
+	}
+
+	return content.Marshal()
+}
+
+func (obj CDROMProperties) Marshal() (string, error) {
+	content := internal_types.PVEList{Separator: ","}
+
+	switch obj.Source {
+	case CDROMSourceNone:
+		content.Append("none")
+	case CDROMSourcePhysical:
+		content.Append("cdrom")
+	case CDROMSourceISOFile:
+		content.Append(obj.DriveStorageProperties.marshal("iso/"))
+	case CDROMSourceCloudInit:
+		content.Append(obj.DriveStorageProperties.marshal(""))
+	default:
+		return "", fmt.Errorf("unknown cdrom source")
+	}
+
+	content.Append("media=cdrom")
+
+	if obj.Size != "" {
+		content.Append(fmt.Sprintf("size=%s", obj.Size))
+	}
+
+	return content.Marshal()
+}
+
+func (obj EFIDiskProperties) Marshal() (string, error) {
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(obj.DriveStorageProperties.marshal(""))
+
+	if obj.Size != "" {
+		content.Append(fmt.Sprintf("size=%s", obj.Size))
+	}
+
+	if obj.Type != "" {
+		if !obj.Type.IsValid() {
+			return "", fmt.Errorf("invalid efi type %s", obj.Type)
+		}
+
+		content.Append(fmt.Sprintf("efitype=%s", obj.Type))
+	}
+
+	if obj.PreEnrolledKeys {
+		content.Append("pre-enrolled-keys=1")
+	}
+
+	return content.Marshal()
+}
+
+// TPMStateProperties is the volume holding the state of the emulated TPM,
+// required along with OVMF to install Windows 11.
+type TPMStateProperties struct {
+	DriveStorageProperties
+
+	Size    string
+	Version TPMVersion
+}
+
+func NewTPMStateProperties(
+	media string,
+) (obj TPMStateProperties, err error) {
+	props := internal_types.PVEDictionary{
+		ListSeparator:     ",",
+		KeyValueSeparator: "=",
+		AllowNoValue:      true,
+	}
+
+	if err := (&props).Unmarshal(media); err != nil {
+		return obj, err
+	}
+
+	for _, kv := range props.List() {
+		if !kv.HasValue() {
+			if err := (&obj.DriveStorageProperties).setProperties(kv.Key(), ""); err != nil {
+				return obj, err
+			}
+
+			continue
+		}
+
+		switch kv.Key() {
+		case "file":
+			if err := (&obj.DriveStorageProperties).setProperties(kv.Value(), ""); err != nil {
+				return obj, err
+			}
+		case "size":
+			obj.Size = kv.Value()
+		case "version":
+			if err := (&obj.Version).Unmarshal(kv.Value()); err != nil {
+				return obj, err
+			}
+		default:
+			err := errors.ErrInvalidProperty
+			err.AddKey("name", "tpmstate0")
+			return obj, err
+		}
+	}
+
+	return obj, nil
+}
+
+func (obj TPMStateProperties) Marshal() (string, error) {
+	content := internal_types.PVEList{Separator: ","}
+	content.Append(obj.DriveStorageProperties.marshal(""))
+
+	if obj.Size != "" {
+		content.Append(fmt.Sprintf("size=%s", obj.Size))
+	}
+
+	if obj.Version != "" {
+		if !obj.Version.IsValid() {
+			return "", fmt.Errorf("invalid tpm version %s", obj.Version)
+		}
+
+		content.Append(fmt.Sprintf("version=%s", obj.Version))
+	}
+
+	return content.Marshal()
+}
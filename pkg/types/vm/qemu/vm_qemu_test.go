@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
 	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
 	"github.com/xabinapal/gopve/test"
 )
@@ -74,3 +75,128 @@ func TestGlobalProperties(t *testing.T) {
 		),
 	)
 }
+
+func TestProperties(t *testing.T) {
+	props := test.HelperCreatePropertiesMap(types.Properties{
+		"description": "test_description",
+		"onboot":      1,
+		"startup":     "order=2,up=30",
+		"tags":        "prod,web",
+		"ostype":      "l26",
+		"cpu":         "host,flags=+aes",
+		"sockets":     1,
+		"cores":       4,
+		"memory":      2048,
+		"balloon":     0,
+		"scsi0":       "local-lvm:vm-100-disk-0,discard=on,iothread=1,size=32G",
+		"scsi1":       "local-lvm:vm-100-disk-1,backup=0,size=8G",
+		"ide2":        "local:iso/debian.iso,media=cdrom",
+		"ide3":        "local-lvm:vm-100-cloudinit,media=cdrom",
+		"boot":        "order=scsi0;ide2;net0",
+		"efidisk0":    "local-lvm:vm-100-disk-2,size=4M",
+		"net0":        "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,tag=10,firewall=1",
+		"unused0":     "local-lvm:vm-100-disk-3",
+		"digest":      "0000000000000000000000000000000000000000",
+	})
+
+	obj, err := qemu.NewProperties(props)
+	require.NoError(t, err)
+
+	t.Run("Create", func(t *testing.T) {
+		require.Len(t, obj.Storage.HardDrives, 2)
+		require.Len(t, obj.Storage.CDROMs, 2)
+		require.Len(t, obj.Storage.Unused, 1)
+		require.Len(t, obj.Network, 1)
+
+		assert.Equal(t, "scsi0", obj.Storage.HardDrives[0].Name())
+		assert.True(t, obj.Storage.HardDrives[0].Discard)
+		assert.True(t, obj.Storage.HardDrives[0].IOThread)
+		assert.False(t, obj.Storage.HardDrives[1].Backup)
+		assert.Equal(t, "unused0", obj.Storage.Unused[0].Name())
+		assert.Equal(t, qemu.CDROMSourceCloudInit, obj.Storage.CDROMs[1].Source)
+		assert.Equal(t, []string{"scsi0", "ide2", "net0"}, obj.BootOrder)
+		assert.Equal(t, vm.NewTags("prod", "web"), obj.Tags)
+		assert.Equal(t, qemu.NetworkModelVirtIO, obj.Network[0].Model)
+		assert.True(t, obj.Network[0].Enabled)
+	})
+
+	t.Run("MapToValues", func(t *testing.T) {
+		values, err := obj.MapToValues()
+		require.NoError(t, err)
+
+		expectedValues := map[string]string{
+			"description": "test_description",
+			"protection":  "0",
+			"onboot":      "1",
+			"startup":     "order=2,up=30",
+			"tags":        "prod;web",
+			"ostype":      "l26",
+			"acpi":        "1",
+			"kvm":         "1",
+			"tablet":      "1",
+			"cpu":         "host,flags=+aes",
+			"sockets":     "1",
+			"cores":       "4",
+			"numa":        "0",
+			"freeze":      "0",
+			"memory":      "2048",
+			"balloon":     "0",
+			"shares":      "0",
+			"scsi0":       "local-lvm:vm-100-disk-0,size=32G,iothread=1,discard=on",
+			"scsi1":       "local-lvm:vm-100-disk-1,size=8G,backup=0",
+			"ide2":        "local:iso/debian.iso,media=cdrom",
+			"ide3":        "local-lvm:vm-100-cloudinit,media=cdrom",
+			"boot":        "order=scsi0;ide2;net0",
+			"efidisk0":    "local-lvm:vm-100-disk-2,size=4M",
+			"net0":        "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,tag=10,firewall=1",
+			"digest":      "0000000000000000000000000000000000000000",
+		}
+
+		require.Len(t, values, len(expectedValues))
+		for k, v := range expectedValues {
+			assert.Equal(t, []string{v}, values[k], k)
+		}
+
+		reparsedProps := make(types.Properties, len(values))
+		for k, v := range props {
+			if _, ok := values[k]; ok {
+				reparsedProps[k] = v
+			}
+		}
+
+		assert.NotContains(t, values, "unused0")
+
+		reparsed, err := qemu.NewProperties(reparsedProps)
+		require.NoError(t, err)
+		assert.Empty(t, reparsed.Storage.Unused)
+
+		reparsed.Storage.Unused = obj.Storage.Unused
+		assert.Equal(t, obj, reparsed)
+	})
+
+	t.Run("MapToUpdateValues", func(t *testing.T) {
+		updated := obj
+		updated.Description = ""
+		updated.Storage.HardDrives = updated.Storage.HardDrives[:1]
+		updated.Network = nil
+		updated.Tags = nil
+		updated.Storage.Unused = nil
+
+		values, err := updated.MapToUpdateValues(obj)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"description,net0,scsi1,tags"}, values["delete"])
+		assert.Equal(
+			t,
+			[]string{"0000000000000000000000000000000000000000"},
+			values["digest"],
+		)
+		assert.NotContains(t, values, "scsi1")
+	})
+
+	t.Run("CreateOptions", func(t *testing.T) {
+		values, err := qemu.CreateOptions{Properties: obj}.MapToValues()
+		require.NoError(t, err)
+		assert.NotContains(t, values, "digest")
+	})
+}
@@ -0,0 +1,52 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type WatchdogAction string
+
+const (
+	WatchdogActionReset    WatchdogAction = "reset"
+	WatchdogActionShutdown WatchdogAction = "shutdown"
+	WatchdogActionPowerOff WatchdogAction = "poweroff"
+	WatchdogActionPause    WatchdogAction = "pause"
+	WatchdogActionDebug    WatchdogAction = "debug"
+	WatchdogActionNone     WatchdogAction = "none"
+)
+
+func (obj WatchdogAction) IsValid() bool {
+	switch obj {
+	case WatchdogActionReset,
+		WatchdogActionShutdown,
+		WatchdogActionPowerOff,
+		WatchdogActionPause,
+		WatchdogActionDebug,
+		WatchdogActionNone:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj WatchdogAction) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj WatchdogAction) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *WatchdogAction) Unmarshal(s string) error {
+	*obj = WatchdogAction(s)
+	return nil
+}
+
+func (obj *WatchdogAction) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
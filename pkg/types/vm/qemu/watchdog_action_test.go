@@ -0,0 +1,45 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestWatchdogAction(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.WatchdogAction)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Reset": {
+				Object: qemu.WatchdogActionReset,
+				Value:  "reset",
+			},
+			"Shutdown": {
+				Object: qemu.WatchdogActionShutdown,
+				Value:  "shutdown",
+			},
+			"PowerOff": {
+				Object: qemu.WatchdogActionPowerOff,
+				Value:  "poweroff",
+			},
+			"Pause": {
+				Object: qemu.WatchdogActionPause,
+				Value:  "pause",
+			},
+			"Debug": {
+				Object: qemu.WatchdogActionDebug,
+				Value:  "debug",
+			},
+			"None": {
+				Object: qemu.WatchdogActionNone,
+				Value:  "none",
+			},
+		},
+	)
+}
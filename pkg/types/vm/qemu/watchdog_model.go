@@ -0,0 +1,43 @@
+package qemu
+
+import (
+	"encoding/json"
+)
+
+type WatchdogModel string
+
+const (
+	WatchdogModelI6300ESB WatchdogModel = "i6300esb"
+	WatchdogModelIB700    WatchdogModel = "ib700"
+)
+
+func (obj WatchdogModel) IsValid() bool {
+	switch obj {
+	case WatchdogModelI6300ESB, WatchdogModelIB700:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj WatchdogModel) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj WatchdogModel) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *WatchdogModel) Unmarshal(s string) error {
+	*obj = WatchdogModel(s)
+	return nil
+}
+
+func (obj *WatchdogModel) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,29 @@
+package qemu_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestWatchdogModel(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*qemu.WatchdogModel)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"I6300ESB": {
+				Object: qemu.WatchdogModelI6300ESB,
+				Value:  "i6300esb",
+			},
+			"IB700": {
+				Object: qemu.WatchdogModelIB700,
+				Value:  "ib700",
+			},
+		},
+	)
+}
@@ -0,0 +1,70 @@
+package vm
+
+import (
+	"encoding/json"
+)
+
+type QMPStatus string
+
+const (
+	QMPStatusRunning       QMPStatus = "running"
+	QMPStatusPaused        QMPStatus = "paused"
+	QMPStatusSuspended     QMPStatus = "suspended"
+	QMPStatusPrelaunch     QMPStatus = "prelaunch"
+	QMPStatusInMigrate     QMPStatus = "inmigrate"
+	QMPStatusPostMigrate   QMPStatus = "postmigrate"
+	QMPStatusFinishMigrate QMPStatus = "finish-migrate"
+	QMPStatusSaveVM        QMPStatus = "save-vm"
+	QMPStatusRestoreVM     QMPStatus = "restore-vm"
+	QMPStatusIOError       QMPStatus = "io-error"
+	QMPStatusInternalError QMPStatus = "internal-error"
+	QMPStatusGuestPanicked QMPStatus = "guest-panicked"
+	QMPStatusWatchdog      QMPStatus = "watchdog"
+	QMPStatusShutdown      QMPStatus = "shutdown"
+	QMPStatusDebug         QMPStatus = "debug"
+)
+
+func (obj QMPStatus) IsValid() bool {
+	switch obj {
+	case QMPStatusRunning,
+		QMPStatusPaused,
+		QMPStatusSuspended,
+		QMPStatusPrelaunch,
+		QMPStatusInMigrate,
+		QMPStatusPostMigrate,
+		QMPStatusFinishMigrate,
+		QMPStatusSaveVM,
+		QMPStatusRestoreVM,
+		QMPStatusIOError,
+		QMPStatusInternalError,
+		QMPStatusGuestPanicked,
+		QMPStatusWatchdog,
+		QMPStatusShutdown,
+		QMPStatusDebug:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj QMPStatus) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj QMPStatus) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *QMPStatus) Unmarshal(s string) error {
+	*obj = QMPStatus(s)
+	return nil
+}
+
+func (obj *QMPStatus) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,81 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestQMPStatus(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*vm.QMPStatus)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Running": {
+				Object: vm.QMPStatusRunning,
+				Value:  "running",
+			},
+			"Paused": {
+				Object: vm.QMPStatusPaused,
+				Value:  "paused",
+			},
+			"Suspended": {
+				Object: vm.QMPStatusSuspended,
+				Value:  "suspended",
+			},
+			"Prelaunch": {
+				Object: vm.QMPStatusPrelaunch,
+				Value:  "prelaunch",
+			},
+			"InMigrate": {
+				Object: vm.QMPStatusInMigrate,
+				Value:  "inmigrate",
+			},
+			"PostMigrate": {
+				Object: vm.QMPStatusPostMigrate,
+				Value:  "postmigrate",
+			},
+			"FinishMigrate": {
+				Object: vm.QMPStatusFinishMigrate,
+				Value:  "finish-migrate",
+			},
+			"SaveVM": {
+				Object: vm.QMPStatusSaveVM,
+				Value:  "save-vm",
+			},
+			"RestoreVM": {
+				Object: vm.QMPStatusRestoreVM,
+				Value:  "restore-vm",
+			},
+			"IOError": {
+				Object: vm.QMPStatusIOError,
+				Value:  "io-error",
+			},
+			"InternalError": {
+				Object: vm.QMPStatusInternalError,
+				Value:  "internal-error",
+			},
+			"GuestPanicked": {
+				Object: vm.QMPStatusGuestPanicked,
+				Value:  "guest-panicked",
+			},
+			"Watchdog": {
+				Object: vm.QMPStatusWatchdog,
+				Value:  "watchdog",
+			},
+			"Shutdown": {
+				Object: vm.QMPStatusShutdown,
+				Value:  "shutdown",
+			},
+			"Debug": {
+				Object: vm.QMPStatusDebug,
+				Value:  "debug",
+			},
+		},
+	)
+}
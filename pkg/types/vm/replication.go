@@ -0,0 +1,127 @@
+package vm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xabinapal/gopve/pkg/request"
+)
+
+// ReplicationJob is a storage replication job of a guest, identified by
+// the guest VMID and a job number, as in 100-0.
+type ReplicationJob interface {
+	ID() string
+	VMID() uint
+	JobNumber() uint
+
+	GetProperties() (ReplicationJobProperties, error)
+	SetProperties(props ReplicationJobProperties) error
+
+	// GetStatus, GetLog and ScheduleNow are run on the node the guest is
+	// currently on, as it is the replication source.
+	GetStatus() (ReplicationStatus, error)
+	GetLog(opts GetReplicationLogOptions) (ReplicationLogEntries, error)
+	ScheduleNow() error
+
+	Delete(opts DeleteReplicationJobOptions) error
+}
+
+// ReplicationJobProperties describe a replication job. RateLimit is in
+// MB/s, and an empty Schedule uses the PVE default of every 15 minutes.
+type ReplicationJobProperties struct {
+	Target    string
+	Schedule  string
+	RateLimit float64
+	Comment   string
+	Disabled  bool
+}
+
+func (obj ReplicationJobProperties) MapToValues() (request.Values, error) {
+	if obj.Target == "" {
+		return nil, fmt.Errorf("replication job target is required")
+	}
+
+	values := request.Values{}
+
+	values.AddString("target", obj.Target)
+	values.ConditionalAddString("schedule", obj.Schedule, obj.Schedule != "")
+	values.ConditionalAddString(
+		"rate",
+		strconv.FormatFloat(obj.RateLimit, 'f', -1, 64),
+		obj.RateLimit != 0,
+	)
+	values.ConditionalAddString("comment", obj.Comment, obj.Comment != "")
+	values.ConditionalAddBool("disable", obj.Disabled, obj.Disabled)
+
+	return values, nil
+}
+
+// MapToUpdateValues serializes the properties like MapToValues, deleting
+// the ones that were set in previous. The target can't be changed.
+func (obj ReplicationJobProperties) MapToUpdateValues(
+	previous ReplicationJobProperties,
+) (request.Values, error) {
+	if obj.Target != previous.Target {
+		return nil, fmt.Errorf("replication job target can't be changed")
+	}
+
+	values, err := obj.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	previousValues, err := previous.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	delete(values, "target")
+
+	values.AddDeleted(previousValues, "target")
+
+	return values, nil
+}
+
+// ReplicationStatus is the state of a job on its source node. Times are
+// zero when the job never ran, and Running is set while a sync is ongoing.
+type ReplicationStatus struct {
+	LastSync time.Time
+	LastTry  time.Time
+	NextSync time.Time
+	Duration time.Duration
+
+	FailCount uint
+	Error     string
+
+	Running bool
+}
+
+func (obj ReplicationStatus) Failed() bool {
+	return obj.FailCount != 0 || strings.TrimSpace(obj.Error) != ""
+}
+
+type GetReplicationLogOptions struct {
+	LineStart uint
+	LineLimit uint
+}
+
+type ReplicationLogEntries map[int]string
+
+// DeleteReplicationJobOptions set whether the replicated volumes are kept
+// on the target, and whether the job is removed from the configuration
+// without cleaning up the target at all.
+type DeleteReplicationJobOptions struct {
+	Keep  bool
+	Force bool
+}
+
+func (obj DeleteReplicationJobOptions) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	values.ConditionalAddBool("keep", obj.Keep, obj.Keep)
+	values.ConditionalAddBool("force", obj.Force, obj.Force)
+
+	return values, nil
+}
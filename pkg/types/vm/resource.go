@@ -0,0 +1,56 @@
+package vm
+
+import (
+	"time"
+)
+
+// Resource is a guest as reported by the cluster resources index, which is
+// cheaper to fetch than the status or config of every guest.
+type Resource struct {
+	VMID     uint
+	Kind     Kind
+	Node     string
+	Name     string
+	Template bool
+
+	// Status is empty or unknown when the node is offline.
+	Status Status
+	Uptime time.Duration
+	Lock   string
+
+	CPU  float64
+	CPUs uint
+
+	Memory      uint64
+	MemoryTotal uint64
+
+	Disk      uint64
+	DiskTotal uint64
+
+	Pool    string
+	HAState string
+	Tags    Tags
+}
+
+func (obj Resource) IsRunning() bool {
+	return obj.Status == StatusRunning
+}
+
+// MemoryUsage returns the used fraction of the memory, between 0 and 1.
+func (obj Resource) MemoryUsage() float64 {
+	if obj.MemoryTotal == 0 {
+		return 0
+	}
+
+	return float64(obj.Memory) / float64(obj.MemoryTotal)
+}
+
+// DiskUsage returns the used fraction of the disk, between 0 and 1. PVE
+// only reports disk usage for containers.
+func (obj Resource) DiskUsage() float64 {
+	if obj.DiskTotal == 0 {
+		return 0
+	}
+
+	return float64(obj.Disk) / float64(obj.DiskTotal)
+}
@@ -0,0 +1,200 @@
+package vm
+
+import (
+	"sort"
+	"strings"
+)
+
+// ResourceQuery filters and sorts guest resources. Conditions are combined
+// with a logical and, and sort keys are applied in the order they are added.
+//
+//	query := NewResourceQuery().
+//		OnNode("pve1").
+//		WithStatus(StatusRunning).
+//		Where(func(r Resource) bool { return r.MemoryUsage() > 0.8 }).
+//		SortBy(ResourceSortKeyMemoryUsage, true)
+type ResourceQuery struct {
+	filters []func(Resource) bool
+	sorts   []resourceSort
+	limit   uint
+}
+
+type resourceSort struct {
+	key        ResourceSortKey
+	descending bool
+}
+
+func NewResourceQuery() *ResourceQuery {
+	return &ResourceQuery{}
+}
+
+func (q *ResourceQuery) Where(fn func(Resource) bool) *ResourceQuery {
+	q.filters = append(q.filters, fn)
+	return q
+}
+
+func (q *ResourceQuery) OfKind(kind Kind) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return r.Kind == kind
+	})
+}
+
+func (q *ResourceQuery) OnNode(nodes ...string) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return containsString(nodes, r.Node)
+	})
+}
+
+func (q *ResourceQuery) InPool(pools ...string) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return containsString(pools, r.Pool)
+	})
+}
+
+func (q *ResourceQuery) WithStatus(status Status) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return r.Status == status
+	})
+}
+
+func (q *ResourceQuery) WithTags(filter TagFilter) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return filter.Matches(r.Tags)
+	})
+}
+
+// Templates keeps only templates when set, and excludes them otherwise.
+func (q *ResourceQuery) Templates(template bool) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return r.Template == template
+	})
+}
+
+func (q *ResourceQuery) NameContains(s string) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return strings.Contains(r.Name, s)
+	})
+}
+
+func (q *ResourceQuery) CPUAbove(fraction float64) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return r.CPU > fraction
+	})
+}
+
+func (q *ResourceQuery) MemoryUsageAbove(fraction float64) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return r.MemoryUsage() > fraction
+	})
+}
+
+func (q *ResourceQuery) DiskUsageAbove(fraction float64) *ResourceQuery {
+	return q.Where(func(r Resource) bool {
+		return r.DiskUsage() > fraction
+	})
+}
+
+func (q *ResourceQuery) SortBy(
+	key ResourceSortKey,
+	descending bool,
+) *ResourceQuery {
+	q.sorts = append(q.sorts, resourceSort{key: key, descending: descending})
+	return q
+}
+
+// Limit keeps at most n results after sorting. Zero means no limit.
+func (q *ResourceQuery) Limit(n uint) *ResourceQuery {
+	q.limit = n
+	return q
+}
+
+// Apply returns the matching resources without modifying the input. Without
+// sort keys, the input order is kept.
+func (q *ResourceQuery) Apply(resources []Resource) []Resource {
+	var out []Resource
+
+	for _, r := range resources {
+		if q.matches(r) {
+			out = append(out, r)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		for _, s := range q.sorts {
+			c := compareResources(out[i], out[j], s.key)
+			if s.descending {
+				c = -c
+			}
+
+			if c != 0 {
+				return c < 0
+			}
+		}
+
+		return false
+	})
+
+	if q.limit != 0 && uint(len(out)) > q.limit {
+		out = out[:q.limit]
+	}
+
+	return out
+}
+
+func (q *ResourceQuery) matches(r Resource) bool {
+	for _, fn := range q.filters {
+		if !fn(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func compareResources(a, b Resource, key ResourceSortKey) int {
+	switch key {
+	case ResourceSortKeyVMID:
+		return compareFloat(float64(a.VMID), float64(b.VMID))
+	case ResourceSortKeyName:
+		return strings.Compare(a.Name, b.Name)
+	case ResourceSortKeyNode:
+		return strings.Compare(a.Node, b.Node)
+	case ResourceSortKeyCPU:
+		return compareFloat(a.CPU, b.CPU)
+	case ResourceSortKeyMemory:
+		return compareFloat(float64(a.Memory), float64(b.Memory))
+	case ResourceSortKeyMemoryTotal:
+		return compareFloat(float64(a.MemoryTotal), float64(b.MemoryTotal))
+	case ResourceSortKeyMemoryUsage:
+		return compareFloat(a.MemoryUsage(), b.MemoryUsage())
+	case ResourceSortKeyDisk:
+		return compareFloat(float64(a.Disk), float64(b.Disk))
+	case ResourceSortKeyDiskTotal:
+		return compareFloat(float64(a.DiskTotal), float64(b.DiskTotal))
+	case ResourceSortKeyUptime:
+		return compareFloat(float64(a.Uptime), float64(b.Uptime))
+	default:
+		return 0
+	}
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+
+	return false
+}
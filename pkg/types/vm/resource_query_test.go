@@ -0,0 +1,104 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestResourceQuery(t *testing.T) {
+	resources := []vm.Resource{
+		{
+			VMID:        100,
+			Kind:        vm.KindQEMU,
+			Node:        "node1",
+			Name:        "web",
+			Status:      vm.StatusRunning,
+			Memory:      900,
+			MemoryTotal: 1000,
+			Tags:        vm.NewTags("prod"),
+		},
+		{
+			VMID:        101,
+			Kind:        vm.KindLXC,
+			Node:        "node1",
+			Name:        "db",
+			Status:      vm.StatusRunning,
+			Memory:      850,
+			MemoryTotal: 1000,
+			Disk:        500,
+			DiskTotal:   1000,
+			Pool:        "data",
+		},
+		{
+			VMID:        102,
+			Kind:        vm.KindQEMU,
+			Node:        "node2",
+			Name:        "cache",
+			Status:      vm.StatusRunning,
+			Memory:      950,
+			MemoryTotal: 1000,
+		},
+		{
+			VMID:        103,
+			Kind:        vm.KindQEMU,
+			Node:        "node1",
+			Name:        "batch",
+			Status:      vm.StatusStopped,
+			MemoryTotal: 1000,
+			Template:    true,
+		},
+	}
+
+	vmids := func(resources []vm.Resource) []uint {
+		var out []uint
+		for _, r := range resources {
+			out = append(out, r.VMID)
+		}
+
+		return out
+	}
+
+	t.Run("MemoryUsage", func(t *testing.T) {
+		query := vm.NewResourceQuery().
+			OnNode("node1").
+			WithStatus(vm.StatusRunning).
+			MemoryUsageAbove(0.8).
+			SortBy(vm.ResourceSortKeyMemoryUsage, true)
+
+		assert.Equal(t, []uint{100, 101}, vmids(query.Apply(resources)))
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		assert.Equal(t, []uint{101}, vmids(vm.NewResourceQuery().
+			OfKind(vm.KindLXC).
+			InPool("data").
+			DiskUsageAbove(0.4).
+			Apply(resources)))
+
+		assert.Equal(t, []uint{100}, vmids(vm.NewResourceQuery().
+			WithTags(vm.TagFilter{AllOf: []string{"prod"}}).
+			Apply(resources)))
+
+		assert.Equal(t, []uint{103}, vmids(vm.NewResourceQuery().
+			Templates(true).
+			Apply(resources)))
+
+		assert.Equal(t, []uint{102}, vmids(vm.NewResourceQuery().
+			NameContains("cac").
+			Apply(resources)))
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		assert.Equal(t, []uint{103, 102, 101, 100}, vmids(vm.NewResourceQuery().
+			SortBy(vm.ResourceSortKeyName, false).
+			Apply(resources)))
+
+		assert.Equal(t, []uint{103, 101}, vmids(vm.NewResourceQuery().
+			SortBy(vm.ResourceSortKeyNode, false).
+			SortBy(vm.ResourceSortKeyVMID, true).
+			Limit(2).
+			Apply(resources)))
+	})
+}
@@ -0,0 +1,60 @@
+package vm
+
+import (
+	"encoding/json"
+)
+
+type ResourceSortKey string
+
+const (
+	ResourceSortKeyVMID        ResourceSortKey = "vmid"
+	ResourceSortKeyName        ResourceSortKey = "name"
+	ResourceSortKeyNode        ResourceSortKey = "node"
+	ResourceSortKeyCPU         ResourceSortKey = "cpu"
+	ResourceSortKeyMemory      ResourceSortKey = "mem"
+	ResourceSortKeyMemoryTotal ResourceSortKey = "maxmem"
+	ResourceSortKeyMemoryUsage ResourceSortKey = "memusage"
+	ResourceSortKeyDisk        ResourceSortKey = "disk"
+	ResourceSortKeyDiskTotal   ResourceSortKey = "maxdisk"
+	ResourceSortKeyUptime      ResourceSortKey = "uptime"
+)
+
+func (obj ResourceSortKey) IsValid() bool {
+	switch obj {
+	case ResourceSortKeyVMID,
+		ResourceSortKeyName,
+		ResourceSortKeyNode,
+		ResourceSortKeyCPU,
+		ResourceSortKeyMemory,
+		ResourceSortKeyMemoryTotal,
+		ResourceSortKeyMemoryUsage,
+		ResourceSortKeyDisk,
+		ResourceSortKeyDiskTotal,
+		ResourceSortKeyUptime:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj ResourceSortKey) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj ResourceSortKey) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *ResourceSortKey) Unmarshal(s string) error {
+	*obj = ResourceSortKey(s)
+	return nil
+}
+
+func (obj *ResourceSortKey) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,61 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestResourceSortKey(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*vm.ResourceSortKey)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"VMID": {
+				Object: vm.ResourceSortKeyVMID,
+				Value:  "vmid",
+			},
+			"Name": {
+				Object: vm.ResourceSortKeyName,
+				Value:  "name",
+			},
+			"Node": {
+				Object: vm.ResourceSortKeyNode,
+				Value:  "node",
+			},
+			"CPU": {
+				Object: vm.ResourceSortKeyCPU,
+				Value:  "cpu",
+			},
+			"Memory": {
+				Object: vm.ResourceSortKeyMemory,
+				Value:  "mem",
+			},
+			"MemoryTotal": {
+				Object: vm.ResourceSortKeyMemoryTotal,
+				Value:  "maxmem",
+			},
+			"MemoryUsage": {
+				Object: vm.ResourceSortKeyMemoryUsage,
+				Value:  "memusage",
+			},
+			"Disk": {
+				Object: vm.ResourceSortKeyDisk,
+				Value:  "disk",
+			},
+			"DiskTotal": {
+				Object: vm.ResourceSortKeyDiskTotal,
+				Value:  "maxdisk",
+			},
+			"Uptime": {
+				Object: vm.ResourceSortKeyUptime,
+				Value:  "uptime",
+			},
+		},
+	)
+}
@@ -0,0 +1,12 @@
+package vm
+
+import (
+	"time"
+)
+
+// ShutdownOptions set how long PVE waits for the guest to shut down, in
+// whole seconds, and whether it is stopped once the timeout is reached.
+type ShutdownOptions struct {
+	Timeout   time.Duration
+	ForceStop bool
+}
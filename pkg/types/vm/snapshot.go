@@ -4,8 +4,11 @@ import (
 	"time"
 
 	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/task"
 )
 
+const CurrentSnapshotName = "current"
+
 type Snapshot interface {
 	Name() string
 	Description() string
@@ -19,11 +22,19 @@ type Snapshot interface {
 	GetProperties() (SnapshotProperties, error)
 	SetProperties(props SnapshotProperties) error
 
-	Delete() error
+	IsCurrent() bool
+
+	// Delete removes the snapshot. When force is set, the snapshot config is
+	// removed even if deleting the underlying disk snapshots fails.
+	Delete(force bool) (task.Task, error)
 }
 
 type SnapshotProperties struct {
 	Description string
+
+	// WithRAM saves the virtual machine memory along with the snapshot. It
+	// is only honored on creation, and only for running QEMU machines.
+	WithRAM bool
 }
 
 func (obj SnapshotProperties) MapToValues() (request.Values, error) {
@@ -31,5 +42,7 @@ func (obj SnapshotProperties) MapToValues() (request.Values, error) {
 		"description": {obj.Description},
 	}
 
+	values.ConditionalAddBool("vmstate", true, obj.WithRAM)
+
 	return values, nil
 }
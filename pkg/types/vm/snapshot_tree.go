@@ -0,0 +1,213 @@
+package vm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type SnapshotTree struct {
+	Roots   []*SnapshotNode
+	Current *SnapshotNode
+}
+
+type SnapshotNode struct {
+	Snapshot Snapshot
+	Parent   *SnapshotNode
+	Children []*SnapshotNode
+	Depth    uint
+}
+
+func NewSnapshotTree(snapshots []Snapshot) (*SnapshotTree, error) {
+	nodes := make(map[string]*SnapshotNode, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		if _, ok := nodes[snapshot.Name()]; ok {
+			return nil, fmt.Errorf("duplicated snapshot %s", snapshot.Name())
+		}
+
+		nodes[snapshot.Name()] = &SnapshotNode{Snapshot: snapshot}
+	}
+
+	tree := &SnapshotTree{}
+
+	for _, snapshot := range snapshots {
+		node := nodes[snapshot.Name()]
+
+		if snapshot.IsCurrent() {
+			tree.Current = node
+		}
+
+		if snapshot.Parent() == "" {
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+
+		parent, ok := nodes[snapshot.Parent()]
+		if !ok {
+			return nil, fmt.Errorf(
+				"parent snapshot %s of %s not found",
+				snapshot.Parent(),
+				snapshot.Name(),
+			)
+		}
+
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	sortSnapshotNodes(tree.Roots)
+
+	var depth func(nodes []*SnapshotNode, d uint) int
+	depth = func(nodes []*SnapshotNode, d uint) int {
+		visited := 0
+
+		for _, node := range nodes {
+			node.Depth = d
+			sortSnapshotNodes(node.Children)
+
+			visited += 1 + depth(node.Children, d+1)
+		}
+
+		return visited
+	}
+
+	// Snapshots caught in a parent cycle are unreachable from any root.
+	visited := depth(tree.Roots, 0)
+
+	if visited != len(nodes) {
+		return nil, fmt.Errorf("snapshot tree contains a cycle")
+	}
+
+	return tree, nil
+}
+
+// sortSnapshotNodes orders siblings chronologically, leaving the current
+// marker last.
+func sortSnapshotNodes(nodes []*SnapshotNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i].Snapshot, nodes[j].Snapshot
+
+		if a.IsCurrent() || b.IsCurrent() {
+			return b.IsCurrent() && !a.IsCurrent()
+		}
+
+		return a.Timestamp().Before(b.Timestamp())
+	})
+}
+
+func (tree *SnapshotTree) Find(name string) (*SnapshotNode, bool) {
+	var found *SnapshotNode
+
+	tree.Walk(func(node *SnapshotNode) bool {
+		if node.Snapshot.Name() == name {
+			found = node
+			return false
+		}
+
+		return true
+	})
+
+	return found, found != nil
+}
+
+// Walk visits every node depth first, parents before their children, until
+// fn returns false.
+func (tree *SnapshotTree) Walk(fn func(node *SnapshotNode) bool) {
+	var walk func(nodes []*SnapshotNode) bool
+	walk = func(nodes []*SnapshotNode) bool {
+		for _, node := range nodes {
+			if !fn(node) || !walk(node.Children) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	walk(tree.Roots)
+}
+
+// Snapshots returns every snapshot in the tree except the current marker.
+func (tree *SnapshotTree) Snapshots() []Snapshot {
+	var snapshots []Snapshot
+
+	tree.Walk(func(node *SnapshotNode) bool {
+		if !node.Snapshot.IsCurrent() {
+			snapshots = append(snapshots, node.Snapshot)
+		}
+
+		return true
+	})
+
+	return snapshots
+}
+
+// PathToCurrent returns the chain of snapshots from a root up to the
+// current marker, both included.
+func (tree *SnapshotTree) PathToCurrent() []*SnapshotNode {
+	if tree.Current == nil {
+		return nil
+	}
+
+	return tree.Current.Path()
+}
+
+// Path returns the chain of snapshots from its root up to the node, both
+// included.
+func (node *SnapshotNode) Path() []*SnapshotNode {
+	path := make([]*SnapshotNode, node.Depth+1)
+
+	for n := node; n != nil; n = n.Parent {
+		path[n.Depth] = n
+	}
+
+	return path
+}
+
+type SnapshotRetention struct {
+	// KeepLast is the number of newest snapshots that are always kept.
+	KeepLast uint
+	// OlderThan selects only snapshots taken before this long ago. When
+	// zero, every snapshot not kept by KeepLast is selected.
+	OlderThan time.Duration
+}
+
+// SelectSnapshotsToPrune returns the snapshots that the retention policy
+// does not keep, oldest first. The current marker is never selected.
+func SelectSnapshotsToPrune(
+	snapshots []Snapshot,
+	retention SnapshotRetention,
+	now time.Time,
+) []Snapshot {
+	candidates := make([]Snapshot, 0, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		if !snapshot.IsCurrent() {
+			candidates = append(candidates, snapshot)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Timestamp().After(candidates[j].Timestamp())
+	})
+
+	if uint(len(candidates)) <= retention.KeepLast {
+		return nil
+	}
+
+	candidates = candidates[retention.KeepLast:]
+
+	var selected []Snapshot
+
+	for i := len(candidates) - 1; i >= 0; i-- {
+		snapshot := candidates[i]
+
+		if retention.OlderThan == 0 ||
+			snapshot.Timestamp().Before(now.Add(-retention.OlderThan)) {
+			selected = append(selected, snapshot)
+		}
+	}
+
+	return selected
+}
@@ -0,0 +1,100 @@
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xabinapal/gopve/pkg/request"
+)
+
+// Change is a single difference between the spec and the live guest. From
+// and To hold the raw property values, empty when the property is absent.
+type Change struct {
+	Scope  ChangeScope
+	Action ChangeAction
+	Key    string
+
+	From string
+	To   string
+
+	RequiresReboot bool
+	Destructive    bool
+}
+
+func (obj Change) String() string {
+	var flags []string
+
+	if obj.RequiresReboot {
+		flags = append(flags, "reboot")
+	}
+
+	if obj.Destructive {
+		flags = append(flags, "destructive")
+	}
+
+	s := fmt.Sprintf("%s %s %s: %q -> %q", obj.Scope, obj.Action, obj.Key, obj.From, obj.To)
+	if len(flags) != 0 {
+		s = fmt.Sprintf("%s (%s)", s, strings.Join(flags, ", "))
+	}
+
+	return s
+}
+
+// diffValues returns a change for every key whose value differs, sorted by
+// key. Nil current values mean the object doesn't exist yet.
+func diffValues(
+	scope ChangeScope,
+	current, desired request.Values,
+	requiresReboot func(key string) bool,
+) []Change {
+	keys := make(map[string]struct{}, len(current)+len(desired))
+
+	for k := range current {
+		keys[k] = struct{}{}
+	}
+
+	for k := range desired {
+		keys[k] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+
+	sort.Strings(sorted)
+
+	var changes []Change
+
+	for _, k := range sorted {
+		from, inCurrent := current[k]
+		to, inDesired := desired[k]
+
+		change := Change{
+			Scope: scope,
+			Key:   k,
+			From:  strings.Join(from, ","),
+			To:    strings.Join(to, ","),
+		}
+
+		switch {
+		case !inCurrent:
+			change.Action = ChangeActionCreate
+		case !inDesired:
+			change.Action = ChangeActionDelete
+		case change.From == change.To:
+			continue
+		default:
+			change.Action = ChangeActionUpdate
+		}
+
+		if current != nil && requiresReboot != nil {
+			change.RequiresReboot = requiresReboot(k)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
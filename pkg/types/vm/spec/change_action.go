@@ -0,0 +1,50 @@
+package spec
+
+import (
+	"encoding/json"
+)
+
+type ChangeAction string
+
+const (
+	ChangeActionCreate ChangeAction = "create"
+	ChangeActionUpdate ChangeAction = "update"
+	ChangeActionDelete ChangeAction = "delete"
+	ChangeActionResize ChangeAction = "resize"
+	ChangeActionMove   ChangeAction = "move"
+)
+
+func (obj ChangeAction) IsValid() bool {
+	switch obj {
+	case ChangeActionCreate,
+		ChangeActionUpdate,
+		ChangeActionDelete,
+		ChangeActionResize,
+		ChangeActionMove:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj ChangeAction) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj ChangeAction) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *ChangeAction) Unmarshal(s string) error {
+	*obj = ChangeAction(s)
+	return nil
+}
+
+func (obj *ChangeAction) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
@@ -0,0 +1,41 @@
+package spec_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/spec"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestChangeAction(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*spec.ChangeAction)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Create": {
+				Object: spec.ChangeActionCreate,
+				Value:  "create",
+			},
+			"Update": {
+				Object: spec.ChangeActionUpdate,
+				Value:  "update",
+			},
+			"Delete": {
+				Object: spec.ChangeActionDelete,
+				Value:  "delete",
+			},
+			"Resize": {
+				Object: spec.ChangeActionResize,
+				Value:  "resize",
+			},
+			"Move": {
+				Object: spec.ChangeActionMove,
+				Value:  "move",
+			},
+		},
+	)
+}
@@ -0,0 +1,45 @@
+package spec
+
+import (
+	"encoding/json"
+)
+
+type ChangeScope string
+
+const (
+	ChangeScopeConfig   ChangeScope = "config"
+	ChangeScopeFirewall ChangeScope = "firewall"
+	ChangeScopePool     ChangeScope = "pool"
+	ChangeScopeHA       ChangeScope = "ha"
+)
+
+func (obj ChangeScope) IsValid() bool {
+	switch obj {
+	case ChangeScopeConfig, ChangeScopeFirewall, ChangeScopePool, ChangeScopeHA:
+		return true
+	default:
+		return false
+	}
+}
+
+func (obj ChangeScope) IsUnknown() bool {
+	return !obj.IsValid()
+}
+
+func (obj ChangeScope) Marshal() (string, error) {
+	return string(obj), nil
+}
+
+func (obj *ChangeScope) Unmarshal(s string) error {
+	*obj = ChangeScope(s)
+	return nil
+}
+
+func (obj *ChangeScope) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
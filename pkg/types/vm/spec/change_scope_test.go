@@ -0,0 +1,37 @@
+package spec_test
+
+import (
+	"testing"
+
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/vm/spec"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestChangeScope(t *testing.T) {
+	test.HelperTestFixedValue(
+		t,
+		(*spec.ChangeScope)(nil),
+		map[string](struct {
+			Object types.FixedValue
+			Value  string
+		}){
+			"Config": {
+				Object: spec.ChangeScopeConfig,
+				Value:  "config",
+			},
+			"Firewall": {
+				Object: spec.ChangeScopeFirewall,
+				Value:  "firewall",
+			},
+			"Pool": {
+				Object: spec.ChangeScopePool,
+				Value:  "pool",
+			},
+			"HA": {
+				Object: spec.ChangeScopeHA,
+				Value:  "ha",
+			},
+		},
+	)
+}
@@ -0,0 +1,147 @@
+package spec
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+type liveDisk struct {
+	Name       string
+	Storage    string
+	Size       uint64
+	MountPoint string
+}
+
+func (obj liveDisk) String() string {
+	size, _ := vm.DiskSize{Bytes: obj.Size}.Marshal()
+	return fmt.Sprintf("%s,size=%s", obj.Storage, size)
+}
+
+// diskSize rounds the spec size up to whole GiB, the unit used to allocate
+// new volumes.
+func diskSize(s string) (uint64, error) {
+	size, err := vm.NewDiskSize(s)
+	if err != nil {
+		return 0, err
+	}
+
+	gib := (size.Bytes + vm.DiskSizeGibibyte - 1) / vm.DiskSizeGibibyte
+	if gib == 0 {
+		gib = 1
+	}
+
+	return gib, nil
+}
+
+func diskValue(disk DiskSpec, gib uint64) string {
+	value := fmt.Sprintf("%s:%d", disk.Storage, gib)
+
+	if disk.MountPoint != "" {
+		value = fmt.Sprintf("%s,mp=%s", value, disk.MountPoint)
+	}
+
+	return value
+}
+
+// planDisks creates missing disks, moves the ones in another storage and
+// grows smaller ones. Disks bigger than the spec are an error, as they
+// can't be shrunk, and disks missing in the spec are deleted. Only storage
+// and size are compared.
+func planDisks(s Spec, disks []liveDisk, running bool) ([]Change, error) {
+	current := make(map[string]liveDisk, len(disks))
+	for _, disk := range disks {
+		current[disk.Name] = disk
+	}
+
+	desired := make([]DiskSpec, len(s.Disks))
+	copy(desired, s.Disks)
+
+	sort.Slice(desired, func(i, j int) bool {
+		return desired[i].Name < desired[j].Name
+	})
+
+	var changes []Change
+
+	for _, disk := range desired {
+		gib, err := diskSize(disk.Size)
+		if err != nil {
+			return nil, err
+		}
+
+		bytes := gib * vm.DiskSizeGibibyte
+
+		live, ok := current[disk.Name]
+		delete(current, disk.Name)
+
+		if !ok {
+			changes = append(changes, Change{
+				Scope:  ChangeScopeConfig,
+				Action: ChangeActionCreate,
+				Key:    disk.Name,
+				To:     diskValue(disk, gib),
+			})
+
+			continue
+		}
+
+		if live.Size > bytes {
+			return nil, fmt.Errorf(
+				"disk %s is bigger than the spec, disks can't be shrunk",
+				disk.Name,
+			)
+		}
+
+		if live.Storage != disk.Storage {
+			if s.Kind == vm.KindLXC && running {
+				return nil, fmt.Errorf(
+					"container volume %s can only be moved while stopped",
+					disk.Name,
+				)
+			}
+
+			changes = append(changes, Change{
+				Scope:  ChangeScopeConfig,
+				Action: ChangeActionMove,
+				Key:    disk.Name,
+				From:   live.Storage,
+				To:     disk.Storage,
+			})
+		}
+
+		if live.Size < bytes {
+			from, _ := vm.DiskSize{Bytes: live.Size}.Marshal()
+			to, _ := vm.DiskSize{Bytes: bytes}.Marshal()
+
+			changes = append(changes, Change{
+				Scope:  ChangeScopeConfig,
+				Action: ChangeActionResize,
+				Key:    disk.Name,
+				From:   from,
+				To:     to,
+			})
+		}
+	}
+
+	sort.Slice(disks, func(i, j int) bool {
+		return disks[i].Name < disks[j].Name
+	})
+
+	for _, disk := range disks {
+		if _, ok := current[disk.Name]; !ok || disk.Name == "rootfs" {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Scope:          ChangeScopeConfig,
+			Action:         ChangeActionDelete,
+			Key:            disk.Name,
+			From:           disk.String(),
+			RequiresReboot: s.Kind == vm.KindLXC,
+			Destructive:    true,
+		})
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,12 @@
+package spec
+
+import "github.com/xabinapal/gopve/pkg/types/errors"
+
+const (
+	ErrKindMismatch = errors.ClientError(
+		"500 - spec kind doesn't match the virtual machine!",
+	)
+	ErrDestructivePlan = errors.ClientError(
+		"500 - plan has destructive changes!",
+	)
+)
@@ -0,0 +1,427 @@
+package spec
+
+import (
+	"fmt"
+	"sort"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/firewall"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+// LiveState is the current state of the guest a spec is planned against.
+// Only the properties matching the spec kind are used.
+type LiveState struct {
+	Exists  bool
+	Running bool
+
+	Node string
+	Name string
+	Pool string
+
+	QEMU     *qemu.Properties
+	LXC      *lxc.Properties
+	Firewall *firewall.VMProperties
+	HA       *vm.HAProperties
+}
+
+// Plan is the ordered change set needed to bring a guest to its spec:
+// configuration properties sorted by key, then disks, firewall options,
+// pool membership and HA resource.
+type Plan struct {
+	Spec Spec
+
+	Exists  bool
+	Running bool
+	Node    string
+	Digest  string
+
+	Changes []Change
+}
+
+type ApplyOptions struct {
+	// AllowDestructive must be set to apply plans that delete disks.
+	AllowDestructive bool
+	// Reboot restarts a running guest when a change needs it to take effect.
+	Reboot bool
+}
+
+func NewPlan(s Spec, live LiveState) (Plan, error) {
+	if err := s.Validate(); err != nil {
+		return Plan{}, err
+	}
+
+	plan := Plan{
+		Spec:    s,
+		Exists:  live.Exists,
+		Running: live.Exists && live.Running,
+		Node:    s.Node,
+	}
+
+	if live.Exists && live.Node != "" {
+		plan.Node = live.Node
+	}
+
+	var (
+		changes []Change
+		err     error
+	)
+
+	switch s.Kind {
+	case vm.KindQEMU:
+		changes, plan.Digest, err = planQEMU(s, live)
+	case vm.KindLXC:
+		changes, plan.Digest, err = planLXC(s, live)
+	}
+
+	if err != nil {
+		return Plan{}, err
+	}
+
+	plan.Changes = append(plan.Changes, changes...)
+
+	if changes, err = planFirewall(s, live); err != nil {
+		return Plan{}, err
+	}
+
+	plan.Changes = append(plan.Changes, changes...)
+
+	if s.Pool != nil && *s.Pool != live.Pool {
+		change := Change{
+			Scope:  ChangeScopePool,
+			Action: ChangeActionUpdate,
+			Key:    "pool",
+			From:   live.Pool,
+			To:     *s.Pool,
+		}
+
+		if change.From == "" {
+			change.Action = ChangeActionCreate
+		} else if change.To == "" {
+			change.Action = ChangeActionDelete
+		}
+
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if changes, err = planHA(s, live); err != nil {
+		return Plan{}, err
+	}
+
+	plan.Changes = append(plan.Changes, changes...)
+
+	return plan, nil
+}
+
+func (obj Plan) IsEmpty() bool {
+	return len(obj.Changes) == 0
+}
+
+// RequiresReboot reports whether the guest is running and any change only
+// takes effect after a reboot.
+func (obj Plan) RequiresReboot() bool {
+	if !obj.Running {
+		return false
+	}
+
+	for _, change := range obj.Changes {
+		if change.RequiresReboot {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (obj Plan) IsDestructive() bool {
+	for _, change := range obj.Changes {
+		if change.Destructive {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (obj Plan) HasScope(scope ChangeScope) bool {
+	return len(obj.Filter(scope)) != 0
+}
+
+// Filter returns the changes of a scope, optionally restricted to some
+// actions.
+func (obj Plan) Filter(scope ChangeScope, actions ...ChangeAction) []Change {
+	var changes []Change
+
+	for _, change := range obj.Changes {
+		if change.Scope != scope {
+			continue
+		}
+
+		if len(actions) == 0 {
+			changes = append(changes, change)
+			continue
+		}
+
+		for _, action := range actions {
+			if change.Action == action {
+				changes = append(changes, change)
+				break
+			}
+		}
+	}
+
+	return changes
+}
+
+// ConfigValues returns the configuration created, updated or deleted by the
+// plan. Disk moves and resizes need their own requests.
+func (obj Plan) ConfigValues() request.Values {
+	values := request.Values{}
+
+	var keys []string
+
+	for _, change := range obj.Filter(ChangeScopeConfig) {
+		switch change.Action {
+		case ChangeActionCreate, ChangeActionUpdate:
+			values.AddString(change.Key, change.To)
+		case ChangeActionDelete:
+			keys = append(keys, change.Key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	delete := internal_types.NewPVEList(",", keys)
+	values.ConditionalAddObject("delete", delete, delete.Len() != 0)
+
+	return values
+}
+
+func applyCommonSpec(s Spec, props *vm.Properties) {
+	if s.Description != nil {
+		props.Description = *s.Description
+	}
+
+	if s.OnBoot != nil {
+		props.StartOnBoot = *s.OnBoot
+	}
+
+	if s.Tags != nil {
+		props.Tags = vm.NewTags(s.Tags...)
+	}
+}
+
+func newBaseProperties() vm.Properties {
+	return vm.Properties{
+		Description:     vm.DefaultPropertyDescription,
+		Protected:       vm.DefaultPropertyProtected,
+		StartOnBoot:     vm.DefaultPropertyStartOnBoot,
+		StartupOrder:    vm.DefaultPropertyStartupOrder,
+		StartDelay:      vm.DefaultPropertyStartDelay,
+		ShutdownTimeout: vm.DefaultPropertyShutdownTimeout,
+	}
+}
+
+func mergeValues(
+	fns ...func() (request.Values, error),
+) (request.Values, error) {
+	values := request.Values{}
+
+	for _, f := range fns {
+		v, err := f()
+		if err != nil {
+			return nil, err
+		}
+
+		for k, vv := range v {
+			values[k] = vv
+		}
+	}
+
+	delete(values, "digest")
+
+	return values, nil
+}
+
+// rebootUnless returns whether changing a key needs a reboot, which is
+// every key but the given ones and the network interfaces.
+func rebootUnless(keys ...string) func(key string) bool {
+	hot := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		hot[k] = struct{}{}
+	}
+
+	return func(key string) bool {
+		if networkNameRegExp.MatchString(key) {
+			return false
+		}
+
+		_, ok := hot[key]
+		return !ok
+	}
+}
+
+func planFirewall(s Spec, live LiveState) ([]Change, error) {
+	if s.Firewall == nil {
+		return nil, nil
+	}
+
+	var current firewall.VMProperties
+	if live.Firewall != nil {
+		current = *live.Firewall
+	}
+
+	desired, err := s.Firewall.Overlay(current)
+	if err != nil {
+		return nil, err
+	}
+
+	currentValues, err := current.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	desiredValues, err := desired.MapToValues()
+	if err != nil {
+		return nil, err
+	}
+
+	managed := request.Values{}
+	previous := request.Values{}
+
+	for _, k := range s.Firewall.keys() {
+		managed[k] = desiredValues[k]
+		previous[k] = currentValues[k]
+	}
+
+	if live.Firewall == nil {
+		previous = nil
+	}
+
+	return diffValues(ChangeScopeFirewall, previous, managed, nil), nil
+}
+
+// Overlay returns the firewall options with the managed fields of the spec.
+func (obj FirewallSpec) Overlay(
+	props firewall.VMProperties,
+) (firewall.VMProperties, error) {
+	for _, b := range []struct {
+		spec  *bool
+		value *bool
+	}{
+		{obj.Enable, &props.Enable},
+		{obj.DHCP, &props.EnableDHCP},
+		{obj.MACFilter, &props.EnableMACFilter},
+		{obj.IPFilter, &props.EnableIPFilter},
+	} {
+		if b.spec != nil {
+			*b.value = *b.spec
+		}
+	}
+
+	for _, p := range []struct {
+		spec  string
+		value *firewall.Action
+	}{
+		{obj.PolicyIn, &props.DefaultInputPolicy},
+		{obj.PolicyOut, &props.DefaultOutputPolicy},
+	} {
+		if p.spec == "" {
+			continue
+		}
+
+		if err := p.value.Unmarshal(p.spec); err != nil {
+			return props, err
+		}
+	}
+
+	return props, nil
+}
+
+func (obj FirewallSpec) keys() []string {
+	var keys []string
+
+	for _, k := range []struct {
+		key     string
+		managed bool
+	}{
+		{"enable", obj.Enable != nil},
+		{"policy_in", obj.PolicyIn != ""},
+		{"policy_out", obj.PolicyOut != ""},
+		{"dhcp", obj.DHCP != nil},
+		{"macfilter", obj.MACFilter != nil},
+		{"ipfilter", obj.IPFilter != nil},
+	} {
+		if k.managed {
+			keys = append(keys, k.key)
+		}
+	}
+
+	return keys
+}
+
+func planHA(s Spec, live LiveState) ([]Change, error) {
+	if s.HA == nil {
+		return nil, nil
+	}
+
+	desired := s.HA.Overlay(live.HA)
+
+	var current, values request.Values
+	var err error
+
+	if live.HA != nil {
+		if current, err = live.HA.MapToValues(); err != nil {
+			return nil, err
+		}
+	}
+
+	if desired != nil {
+		if values, err = desired.MapToValues(); err != nil {
+			return nil, err
+		}
+	}
+
+	if current == nil && values == nil {
+		return nil, nil
+	}
+
+	return diffValues(ChangeScopeHA, current, values, nil), nil
+}
+
+// Overlay returns the HA resource with the managed fields of the spec, or
+// nil when the guest must not be an HA resource.
+func (obj HASpec) Overlay(current *vm.HAProperties) *vm.HAProperties {
+	if obj.State == "" {
+		return nil
+	}
+
+	props := vm.NewHAProperties()
+	if current != nil {
+		props = *current
+	}
+
+	props.State = obj.State
+	props.Group = obj.Group
+
+	if obj.MaxRestart != nil {
+		props.MaxRestart = *obj.MaxRestart
+	}
+
+	if obj.MaxRelocate != nil {
+		props.MaxRelocate = *obj.MaxRelocate
+	}
+
+	return &props
+}
+
+func requireLive(kind vm.Kind, found bool) error {
+	if !found {
+		return fmt.Errorf("missing live %s properties", kind)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+)
+
+var lxcRequiresReboot = rebootUnless(
+	"description",
+	"tags",
+	"onboot",
+	"startup",
+	"protection",
+	"memory",
+	"swap",
+	"cores",
+	"cpulimit",
+	"cpuunits",
+)
+
+func newLXCBase() lxc.Properties {
+	return lxc.Properties{
+		Properties: newBaseProperties(),
+		Memory: lxc.MemoryProperties{
+			Memory: 512,
+			Swap:   512,
+		},
+	}
+}
+
+// lxcConfigValues leaves out unprivileged, as it can only be set on
+// creation.
+func lxcConfigValues(props lxc.Properties) (request.Values, error) {
+	values, err := mergeValues(
+		props.Properties.MapToValues,
+		props.GlobalProperties.MapToValues,
+		props.CPU.MapToValues,
+		props.Memory.MapToValues,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(values, "unprivileged")
+
+	for _, network := range props.Network {
+		if err := values.AddObject(network.Name(), network); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func planLXC(s Spec, live LiveState) ([]Change, string, error) {
+	current := newLXCBase()
+
+	if live.Exists {
+		if err := requireLive(s.Kind, live.LXC != nil); err != nil {
+			return nil, "", err
+		}
+
+		current = *live.LXC
+	} else if s.OSTemplate == "" {
+		return nil, "", fmt.Errorf("container template is required")
+	}
+
+	desired, err := overlayLXC(s, current)
+	if err != nil {
+		return nil, "", err
+	}
+
+	desiredValues, err := lxcConfigValues(desired)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var currentValues request.Values
+
+	if live.Exists {
+		if currentValues, err = lxcConfigValues(current); err != nil {
+			return nil, "", err
+		}
+	}
+
+	changes := diffValues(
+		ChangeScopeConfig,
+		currentValues,
+		desiredValues,
+		lxcRequiresReboot,
+	)
+
+	if s.Disks == nil {
+		return changes, current.Digest, nil
+	}
+
+	var disks []liveDisk
+
+	if live.Exists {
+		disks = append(disks, liveDisk{
+			Name:    "rootfs",
+			Storage: storageName(current.RootFS.Volume),
+			Size:    current.RootFS.Size.Bytes,
+		})
+	}
+
+	for _, mp := range current.MountPoints {
+		if mp.IsBindMount() {
+			continue
+		}
+
+		disks = append(disks, liveDisk{
+			Name:       mp.Name(),
+			Storage:    storageName(mp.Volume),
+			Size:       mp.Size.Bytes,
+			MountPoint: mp.MountPoint,
+		})
+	}
+
+	diskChanges, err := planDisks(s, disks, live.Running)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return append(changes, diskChanges...), current.Digest, nil
+}
+
+func overlayLXC(s Spec, props lxc.Properties) (lxc.Properties, error) {
+	applyCommonSpec(s, &props.Properties)
+
+	if s.Name != "" {
+		props.Hostname = s.Name
+	}
+
+	if s.OSType != "" {
+		props.OSType = lxc.OSType(s.OSType)
+		if !props.OSType.IsValid() {
+			return props, fmt.Errorf("invalid lxc os type %s", s.OSType)
+		}
+	}
+
+	if s.CPU != nil {
+		if s.CPU.Cores != 0 {
+			props.CPU.Cores = s.CPU.Cores
+		}
+
+		if s.CPU.Limit != nil {
+			props.CPU.Limit = *s.CPU.Limit
+		}
+
+		if s.CPU.Units != 0 {
+			props.CPU.Units = s.CPU.Units
+		}
+	}
+
+	if s.Memory != nil {
+		if s.Memory.Size != 0 {
+			props.Memory.Memory = s.Memory.Size
+		}
+
+		if s.Memory.Swap != nil {
+			props.Memory.Swap = *s.Memory.Swap
+		}
+	}
+
+	if s.DNS != nil {
+		props.Nameservers = s.DNS.Nameservers
+		props.SearchDomain = s.DNS.SearchDomain
+	}
+
+	if s.Networks == nil {
+		return props, nil
+	}
+
+	networks := make([]lxc.NetworkInterfaceProperties, 0, len(s.Networks))
+
+	for _, network := range s.Networks {
+		n := deviceNumber(network.Name)
+
+		nic := lxc.NetworkInterfaceProperties{
+			DeviceNumber:  n,
+			InterfaceName: fmt.Sprintf("eth%d", n),
+			Enabled:       true,
+		}
+
+		for _, current := range props.Network {
+			if current.DeviceNumber == n {
+				nic = current
+			}
+		}
+
+		for _, field := range []struct {
+			spec  string
+			value *string
+		}{
+			{network.Interface, &nic.InterfaceName},
+			{network.MACAddress, &nic.MACAddress},
+			{network.Bridge, &nic.Bridge},
+			{network.IPv4, &nic.IPv4},
+			{network.GatewayIPv4, &nic.GatewayIPv4},
+			{network.IPv6, &nic.IPv6},
+			{network.GatewayIPv6, &nic.GatewayIPv6},
+		} {
+			if field.spec != "" {
+				*field.value = field.spec
+			}
+		}
+
+		if network.VLAN != 0 {
+			nic.VLAN = network.VLAN
+		}
+
+		if network.Firewall != nil {
+			nic.EnableFirewall = *network.Firewall
+		}
+
+		networks = append(networks, nic)
+	}
+
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].DeviceNumber < networks[j].DeviceNumber
+	})
+
+	props.Network = networks
+
+	return props, nil
+}
+
+func storageName(volume string) string {
+	return strings.SplitN(volume, ":", 2)[0]
+}
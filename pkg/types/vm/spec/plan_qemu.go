@@ -0,0 +1,301 @@
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/xabinapal/gopve/pkg/request"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+)
+
+var qemuRequiresReboot = rebootUnless(
+	"name",
+	"description",
+	"tags",
+	"onboot",
+	"startup",
+	"protection",
+	"balloon",
+	"shares",
+)
+
+func newQEMUBase() qemu.Properties {
+	return qemu.Properties{
+		Properties: newBaseProperties(),
+		GlobalProperties: qemu.GlobalProperties{
+			ACPI:              qemu.DefaultGlobalPropertiesACPI,
+			KVMVirtualization: qemu.DefaultGlobalPropertiesKVMVirtualization,
+			USBTabletDevice:   qemu.DefaultGlobalPropertiesUSBTabletDevice,
+		},
+		CPU: qemu.CPUProperties{
+			Kind:         qemu.DefaultCPUPropertyKind,
+			Architecture: qemu.DefaultCPUPropertyArchitecture,
+		},
+		Memory: qemu.MemoryProperties{
+			Ballooning: true,
+		},
+	}
+}
+
+func qemuConfigValues(props qemu.Properties, name string) (request.Values, error) {
+	values, err := mergeValues(
+		props.Properties.MapToValues,
+		props.GlobalProperties.MapToValues,
+		props.CPU.MapToValues,
+		props.Memory.MapToValues,
+		props.Agent.MapToValues,
+		props.CloudInit.MapToValues,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, network := range props.Network {
+		if err := values.AddObject(network.Name(), network); err != nil {
+			return nil, err
+		}
+	}
+
+	values.ConditionalAddString("name", name, name != "")
+
+	return values, nil
+}
+
+func planQEMU(s Spec, live LiveState) ([]Change, string, error) {
+	current := newQEMUBase()
+	name := ""
+
+	if live.Exists {
+		if err := requireLive(s.Kind, live.QEMU != nil); err != nil {
+			return nil, "", err
+		}
+
+		current = *live.QEMU
+		name = live.Name
+	} else if s.Name == "" {
+		return nil, "", fmt.Errorf("spec has no name")
+	}
+
+	desired, err := overlayQEMU(s, current)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if s.Name != "" {
+		name = s.Name
+	}
+
+	desiredValues, err := qemuConfigValues(desired, name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var currentValues request.Values
+
+	if live.Exists {
+		if currentValues, err = qemuConfigValues(current, live.Name); err != nil {
+			return nil, "", err
+		}
+
+		// The password is write-only, it can't be compared once set.
+		if current.CloudInit.Password != "" {
+			delete(desiredValues, "cipassword")
+		}
+	}
+
+	changes := diffValues(
+		ChangeScopeConfig,
+		currentValues,
+		desiredValues,
+		qemuRequiresReboot,
+	)
+
+	if s.Disks == nil {
+		return changes, current.Digest, nil
+	}
+
+	disks := make([]liveDisk, 0, len(current.Storage.HardDrives))
+
+	for _, drive := range current.Storage.HardDrives {
+		size, err := vm.NewDiskSize(drive.Size)
+		if err != nil {
+			return nil, "", err
+		}
+
+		disks = append(disks, liveDisk{
+			Name:    drive.Name(),
+			Storage: drive.StorageName,
+			Size:    size.Bytes,
+		})
+	}
+
+	diskChanges, err := planDisks(s, disks, live.Running)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return append(changes, diskChanges...), current.Digest, nil
+}
+
+func overlayQEMU(s Spec, props qemu.Properties) (qemu.Properties, error) {
+	applyCommonSpec(s, &props.Properties)
+
+	if s.OSType != "" {
+		props.OSType = qemu.OSType(s.OSType)
+		if !props.OSType.IsValid() {
+			return props, fmt.Errorf("invalid qemu os type %s", s.OSType)
+		}
+	}
+
+	if s.CPU != nil {
+		// Keep every vCPU plugged unless some were explicitly unplugged.
+		if props.CPU.VCPUs == props.CPU.Count() {
+			props.CPU.VCPUs = 0
+		}
+
+		if s.CPU.Type != "" {
+			props.CPU.Kind = qemu.CPUType(s.CPU.Type)
+			if !props.CPU.Kind.IsValid() {
+				return props, fmt.Errorf("invalid cpu type %s", s.CPU.Type)
+			}
+		}
+
+		if s.CPU.Sockets != 0 {
+			props.CPU.Sockets = s.CPU.Sockets
+		}
+
+		if s.CPU.Cores != 0 {
+			props.CPU.Cores = s.CPU.Cores
+		}
+
+		if s.CPU.Limit != nil {
+			props.CPU.Limit = *s.CPU.Limit
+		}
+
+		if s.CPU.Units != 0 {
+			props.CPU.Units = s.CPU.Units
+		}
+	}
+
+	if s.Memory != nil {
+		if s.Memory.Size != 0 {
+			props.Memory.Memory = s.Memory.Size
+		}
+
+		if s.Memory.Minimum != nil {
+			props.Memory.Ballooning = *s.Memory.Minimum != 0
+			props.Memory.MinimumMemory = *s.Memory.Minimum
+		}
+	}
+
+	if s.CloudInit != nil {
+		if s.CloudInit.User != "" {
+			props.CloudInit.User = s.CloudInit.User
+		}
+
+		if s.CloudInit.Password != "" {
+			props.CloudInit.Password = s.CloudInit.Password
+		}
+
+		if s.CloudInit.SSHKeys != nil {
+			props.CloudInit.SSHKeys = s.CloudInit.SSHKeys
+		}
+	}
+
+	if s.DNS != nil {
+		props.CloudInit.Nameservers = s.DNS.Nameservers
+		props.CloudInit.SearchDomain = s.DNS.SearchDomain
+	}
+
+	if s.Networks == nil {
+		return props, nil
+	}
+
+	networks := make([]qemu.NetworkInterfaceProperties, 0, len(s.Networks))
+	ipConfigs := make([]qemu.CloudInitIPConfigProperties, 0, len(s.Networks))
+
+	for _, network := range s.Networks {
+		n := deviceNumber(network.Name)
+
+		nic := qemu.NetworkInterfaceProperties{
+			DeviceNumber: n,
+			Model:        qemu.NetworkModelVirtIO,
+			Enabled:      true,
+		}
+
+		for _, current := range props.Network {
+			if current.DeviceNumber == n {
+				nic = current
+			}
+		}
+
+		if network.Model != "" {
+			nic.Model = qemu.NetworkModel(network.Model)
+		}
+
+		if network.MACAddress != "" {
+			nic.MACAddress = network.MACAddress
+		}
+
+		if network.Bridge != "" {
+			nic.Bridge = network.Bridge
+		}
+
+		if network.VLAN != 0 {
+			nic.VLAN = network.VLAN
+		}
+
+		if network.Firewall != nil {
+			nic.EnableFirewall = *network.Firewall
+		}
+
+		networks = append(networks, nic)
+
+		ipConfig := qemu.CloudInitIPConfigProperties{DeviceNumber: n}
+		hasIPConfig := false
+
+		for _, current := range props.CloudInit.IPConfig {
+			if current.DeviceNumber == n {
+				ipConfig = current
+				hasIPConfig = true
+			}
+		}
+
+		if network.IPv4 != "" || network.IPv6 != "" {
+			ipConfig.IPv4 = network.IPv4
+			ipConfig.GatewayIPv4 = network.GatewayIPv4
+			ipConfig.IPv6 = network.IPv6
+			ipConfig.GatewayIPv6 = network.GatewayIPv6
+			hasIPConfig = true
+		}
+
+		if hasIPConfig {
+			ipConfigs = append(ipConfigs, ipConfig)
+		}
+	}
+
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].DeviceNumber < networks[j].DeviceNumber
+	})
+
+	sort.Slice(ipConfigs, func(i, j int) bool {
+		return ipConfigs[i].DeviceNumber < ipConfigs[j].DeviceNumber
+	})
+
+	props.Network = networks
+	props.CloudInit.IPConfig = ipConfigs
+
+	return props, nil
+}
+
+func deviceNumber(name string) int {
+	matches := deviceNumberRegExp.FindStringSubmatch(name)
+	if matches == nil {
+		return -1
+	}
+
+	n, _ := strconv.Atoi(matches[1])
+	return n
+}
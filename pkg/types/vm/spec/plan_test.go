@@ -0,0 +1,237 @@
+package spec_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/firewall"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/lxc"
+	"github.com/xabinapal/gopve/pkg/types/vm/qemu"
+	"github.com/xabinapal/gopve/pkg/types/vm/spec"
+	"github.com/xabinapal/gopve/test"
+)
+
+func TestNewPlanQEMU(t *testing.T) {
+	props, err := qemu.NewProperties(test.HelperCreatePropertiesMap(types.Properties{
+		"ostype":  "l26",
+		"sockets": 1,
+		"cores":   2,
+		"memory":  2048,
+		"balloon": 0,
+		"scsi0":   "local-lvm:vm-100-disk-0,size=32G",
+		"scsi1":   "local-lvm:vm-100-disk-1,size=8G",
+		"net0":    "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0",
+		"digest":  "0000000000000000000000000000000000000000",
+	}))
+	require.NoError(t, err)
+
+	live := spec.LiveState{
+		Exists:   true,
+		Running:  true,
+		Node:     "test_node",
+		Name:     "web",
+		QEMU:     &props,
+		Firewall: &firewall.VMProperties{},
+	}
+
+	enable := true
+
+	s := spec.Spec{
+		VMID:   100,
+		Node:   "test_node",
+		Kind:   vm.KindQEMU,
+		Name:   "web",
+		Tags:   []string{"prod"},
+		CPU:    &spec.CPUSpec{Cores: 4},
+		Memory: &spec.MemorySpec{Size: 4096},
+		Networks: []spec.NetworkSpec{
+			{Name: "net1", Bridge: "vmbr1", IPv4: "10.0.0.2/24", GatewayIPv4: "10.0.0.1"},
+			{Name: "net0"},
+		},
+		Firewall: &spec.FirewallSpec{Enable: &enable},
+		HA:       &spec.HASpec{State: vm.HAStateStarted},
+	}
+
+	t.Run("Update", func(t *testing.T) {
+		plan, err := spec.NewPlan(s, live)
+		require.NoError(t, err)
+
+		assert.Equal(t, "0000000000000000000000000000000000000000", plan.Digest)
+		assert.Equal(t, []spec.Change{
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionUpdate, Key: "cores", From: "2", To: "4", RequiresReboot: true},
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionCreate, Key: "ipconfig1", To: "ip=10.0.0.2/24,gw=10.0.0.1", RequiresReboot: true},
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionUpdate, Key: "memory", From: "2048", To: "4096", RequiresReboot: true},
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionCreate, Key: "net1", To: "virtio,bridge=vmbr1"},
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionCreate, Key: "tags", To: "prod"},
+			{Scope: spec.ChangeScopeFirewall, Action: spec.ChangeActionUpdate, Key: "enable", From: "0", To: "1"},
+			{Scope: spec.ChangeScopeHA, Action: spec.ChangeActionCreate, Key: "max_relocate", To: "1"},
+			{Scope: spec.ChangeScopeHA, Action: spec.ChangeActionCreate, Key: "max_restart", To: "1"},
+			{Scope: spec.ChangeScopeHA, Action: spec.ChangeActionCreate, Key: "state", To: "started"},
+		}, plan.Changes)
+
+		assert.True(t, plan.RequiresReboot())
+		assert.False(t, plan.IsDestructive())
+	})
+
+	t.Run("Disks", func(t *testing.T) {
+		s := spec.Spec{
+			VMID: 100,
+			Node: "test_node",
+			Kind: vm.KindQEMU,
+			Disks: []spec.DiskSpec{
+				{Name: "scsi0", Storage: "local-lvm", Size: "40G"},
+				{Name: "virtio0", Storage: "ceph", Size: "1500M"},
+			},
+		}
+
+		plan, err := spec.NewPlan(s, live)
+		require.NoError(t, err)
+
+		assert.Equal(t, []spec.Change{
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionResize, Key: "scsi0", From: "32G", To: "40G"},
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionCreate, Key: "virtio0", To: "ceph:2"},
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionDelete, Key: "scsi1", From: "local-lvm,size=8G", Destructive: true},
+		}, plan.Changes)
+
+		assert.True(t, plan.IsDestructive())
+		assert.Equal(t, map[string][]string{
+			"virtio0": {"ceph:2"},
+			"delete":  {"scsi1"},
+		}, map[string][]string(plan.ConfigValues()))
+
+		s.Disks[0].Storage = "ceph"
+
+		plan, err = spec.NewPlan(s, live)
+		require.NoError(t, err)
+
+		assert.Equal(t, []spec.Change{
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionMove, Key: "scsi0", From: "local-lvm", To: "ceph"},
+			{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionResize, Key: "scsi0", From: "32G", To: "40G"},
+		}, plan.Changes[:2])
+
+		s.Disks[0].Size = "16G"
+
+		_, err = spec.NewPlan(s, live)
+		assert.Error(t, err)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		plan, err := spec.NewPlan(s, spec.LiveState{})
+		require.NoError(t, err)
+
+		assert.False(t, plan.Exists)
+		assert.False(t, plan.RequiresReboot())
+
+		for _, change := range plan.Changes {
+			assert.Equal(t, spec.ChangeActionCreate, change.Action, change.Key)
+		}
+
+		values := plan.ConfigValues()
+		assert.Equal(t, []string{"web"}, values["name"])
+		assert.Equal(t, []string{"4"}, values["cores"])
+		assert.Equal(t, []string{"virtio"}, values["net0"])
+		assert.Equal(t, []string{"virtio,bridge=vmbr1"}, values["net1"])
+		assert.NotContains(t, values, "delete")
+
+		s := s
+		s.Name = ""
+
+		_, err = spec.NewPlan(s, spec.LiveState{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		plan, err := spec.NewPlan(spec.Spec{
+			VMID: 100,
+			Node: "test_node",
+			Kind: vm.KindQEMU,
+			Name: "web",
+		}, live)
+		require.NoError(t, err)
+
+		assert.True(t, plan.IsEmpty())
+	})
+}
+
+func TestNewPlanLXC(t *testing.T) {
+	props, err := lxc.NewProperties(test.HelperCreatePropertiesMap(types.Properties{
+		"ostype":   "debian",
+		"hostname": "db",
+		"arch":     "amd64",
+		"cores":    1,
+		"memory":   512,
+		"swap":     512,
+		"rootfs":   "local-lvm:vm-200-disk-0,size=8G",
+		"mp0":      "local-lvm:vm-200-disk-1,mp=/srv,size=16G",
+		"net0":     "name=eth0,bridge=vmbr0,ip=dhcp,type=veth",
+		"digest":   "0000000000000000000000000000000000000000",
+	}))
+	require.NoError(t, err)
+
+	live := spec.LiveState{
+		Exists:  true,
+		Running: true,
+		Node:    "test_node",
+		Name:    "db",
+		Pool:    "dev",
+		LXC:     &props,
+	}
+
+	swap := uint(1024)
+	pool := "prod"
+
+	s := spec.Spec{
+		VMID:   200,
+		Node:   "test_node",
+		Kind:   vm.KindLXC,
+		Name:   "db",
+		Memory: &spec.MemorySpec{Swap: &swap},
+		Networks: []spec.NetworkSpec{
+			{Name: "net0", IPv4: "10.0.0.3/24"},
+		},
+		Disks: []spec.DiskSpec{
+			{Name: "rootfs", Storage: "local-lvm", Size: "8G"},
+			{Name: "mp0", Storage: "local-lvm", Size: "16G", MountPoint: "/srv"},
+		},
+		Pool: &pool,
+	}
+
+	plan, err := spec.NewPlan(s, live)
+	require.NoError(t, err)
+
+	assert.Equal(t, []spec.Change{
+		{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionUpdate, Key: "net0", From: "name=eth0,bridge=vmbr0,ip=dhcp,type=veth", To: "name=eth0,bridge=vmbr0,ip=10.0.0.3/24,type=veth"},
+		{Scope: spec.ChangeScopeConfig, Action: spec.ChangeActionUpdate, Key: "swap", From: "512", To: "1024"},
+		{Scope: spec.ChangeScopePool, Action: spec.ChangeActionUpdate, Key: "pool", From: "dev", To: "prod"},
+	}, plan.Changes)
+
+	s.Disks[1].Storage = "local-zfs"
+
+	_, err = spec.NewPlan(s, live)
+	assert.Error(t, err, "container volumes can't be moved while running")
+
+	stopped := live
+	stopped.Running = false
+
+	plan, err = spec.NewPlan(s, stopped)
+	require.NoError(t, err)
+	assert.Contains(t, plan.Changes, spec.Change{
+		Scope:  spec.ChangeScopeConfig,
+		Action: spec.ChangeActionMove,
+		Key:    "mp0",
+		From:   "local-lvm",
+		To:     "local-zfs",
+	})
+	assert.False(t, plan.IsDestructive())
+
+	s.Disks[1].Size = "8G"
+
+	_, err = spec.NewPlan(s, stopped)
+	assert.Error(t, err, "disks can't be shrunk")
+
+	_, err = spec.NewPlan(s, spec.LiveState{})
+	assert.Error(t, err, "container template is required")
+}
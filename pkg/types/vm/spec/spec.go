@@ -0,0 +1,237 @@
+package spec
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/xabinapal/gopve/pkg/types/firewall"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes the desired state of a guest. Nil pointers, nil slices and
+// zero values are left unmanaged, so only the described fields are diffed
+// against the live configuration.
+type Spec struct {
+	VMID uint    `json:"vmid" yaml:"vmid"`
+	Node string  `json:"node" yaml:"node"`
+	Kind vm.Kind `json:"kind" yaml:"kind"`
+	Name string  `json:"name,omitempty" yaml:"name,omitempty"`
+
+	Description *string  `json:"description,omitempty" yaml:"description,omitempty"`
+	OnBoot      *bool    `json:"onboot,omitempty" yaml:"onboot,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	OSType      string   `json:"ostype,omitempty" yaml:"ostype,omitempty"`
+
+	CPU    *CPUSpec    `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory *MemorySpec `json:"memory,omitempty" yaml:"memory,omitempty"`
+
+	Disks    []DiskSpec    `json:"disks,omitempty" yaml:"disks,omitempty"`
+	Networks []NetworkSpec `json:"networks,omitempty" yaml:"networks,omitempty"`
+
+	CloudInit *CloudInitSpec `json:"cloudinit,omitempty" yaml:"cloudinit,omitempty"`
+	DNS       *DNSSpec       `json:"dns,omitempty" yaml:"dns,omitempty"`
+
+	Firewall *FirewallSpec `json:"firewall,omitempty" yaml:"firewall,omitempty"`
+	Pool     *string       `json:"pool,omitempty" yaml:"pool,omitempty"`
+	HA       *HASpec       `json:"ha,omitempty" yaml:"ha,omitempty"`
+
+	// OSTemplate and Unprivileged are only used when creating a container.
+	OSTemplate   string `json:"ostemplate,omitempty" yaml:"ostemplate,omitempty"`
+	Unprivileged *bool  `json:"unprivileged,omitempty" yaml:"unprivileged,omitempty"`
+}
+
+type CPUSpec struct {
+	Type    string `json:"type,omitempty" yaml:"type,omitempty"`
+	Sockets uint   `json:"sockets,omitempty" yaml:"sockets,omitempty"`
+	Cores   uint   `json:"cores,omitempty" yaml:"cores,omitempty"`
+	Limit   *uint  `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Units   uint   `json:"units,omitempty" yaml:"units,omitempty"`
+}
+
+// MemorySpec sizes are in MiB. Minimum enables ballooning on QEMU guests,
+// and Swap is only used by containers.
+type MemorySpec struct {
+	Size    uint  `json:"size,omitempty" yaml:"size,omitempty"`
+	Minimum *uint `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Swap    *uint `json:"swap,omitempty" yaml:"swap,omitempty"`
+}
+
+// DiskSpec describes a QEMU hard drive, like scsi0, or a container volume,
+// either rootfs or mpN. MountPoint is required for mount points.
+type DiskSpec struct {
+	Name       string `json:"name" yaml:"name"`
+	Storage    string `json:"storage" yaml:"storage"`
+	Size       string `json:"size" yaml:"size"`
+	MountPoint string `json:"mountpoint,omitempty" yaml:"mountpoint,omitempty"`
+}
+
+// NetworkSpec describes the netN interface. Addresses are written to the
+// cloud-init ipconfigN property on QEMU guests, and Interface is the name
+// of the network device inside a container.
+type NetworkSpec struct {
+	Name       string `json:"name" yaml:"name"`
+	Model      string `json:"model,omitempty" yaml:"model,omitempty"`
+	MACAddress string `json:"macaddr,omitempty" yaml:"macaddr,omitempty"`
+	Bridge     string `json:"bridge,omitempty" yaml:"bridge,omitempty"`
+	VLAN       int    `json:"vlan,omitempty" yaml:"vlan,omitempty"`
+	Firewall   *bool  `json:"firewall,omitempty" yaml:"firewall,omitempty"`
+
+	Interface string `json:"interface,omitempty" yaml:"interface,omitempty"`
+
+	IPv4        string `json:"ip,omitempty" yaml:"ip,omitempty"`
+	GatewayIPv4 string `json:"gw,omitempty" yaml:"gw,omitempty"`
+	IPv6        string `json:"ip6,omitempty" yaml:"ip6,omitempty"`
+	GatewayIPv6 string `json:"gw6,omitempty" yaml:"gw6,omitempty"`
+}
+
+type CloudInitSpec struct {
+	User     string   `json:"user,omitempty" yaml:"user,omitempty"`
+	Password string   `json:"password,omitempty" yaml:"password,omitempty"`
+	SSHKeys  []string `json:"sshkeys,omitempty" yaml:"sshkeys,omitempty"`
+}
+
+type DNSSpec struct {
+	Nameservers  []string `json:"nameservers,omitempty" yaml:"nameservers,omitempty"`
+	SearchDomain string   `json:"searchdomain,omitempty" yaml:"searchdomain,omitempty"`
+}
+
+type FirewallSpec struct {
+	Enable    *bool  `json:"enable,omitempty" yaml:"enable,omitempty"`
+	PolicyIn  string `json:"policy_in,omitempty" yaml:"policy_in,omitempty"`
+	PolicyOut string `json:"policy_out,omitempty" yaml:"policy_out,omitempty"`
+	DHCP      *bool  `json:"dhcp,omitempty" yaml:"dhcp,omitempty"`
+	MACFilter *bool  `json:"macfilter,omitempty" yaml:"macfilter,omitempty"`
+	IPFilter  *bool  `json:"ipfilter,omitempty" yaml:"ipfilter,omitempty"`
+}
+
+// HASpec describes the HA resource of the guest. An empty State removes
+// the guest from HA.
+type HASpec struct {
+	State       vm.HAState `json:"state,omitempty" yaml:"state,omitempty"`
+	Group       string     `json:"group,omitempty" yaml:"group,omitempty"`
+	MaxRestart  *uint      `json:"max_restart,omitempty" yaml:"max_restart,omitempty"`
+	MaxRelocate *uint      `json:"max_relocate,omitempty" yaml:"max_relocate,omitempty"`
+}
+
+var (
+	qemuDiskNameRegExp   = regexp.MustCompile(`^(ide|sata|scsi|virtio)\d+$`)
+	lxcDiskNameRegExp    = regexp.MustCompile(`^(rootfs|mp\d+)$`)
+	mountPointNameRegExp = regexp.MustCompile(`^mp\d+$`)
+	networkNameRegExp    = regexp.MustCompile(`^net\d+$`)
+	deviceNumberRegExp   = regexp.MustCompile(`^[a-z]+(\d+)$`)
+)
+
+// Parse reads a spec from its YAML or JSON representation.
+func Parse(b []byte) (Spec, error) {
+	var obj Spec
+
+	if err := yaml.Unmarshal(b, &obj); err != nil {
+		return obj, err
+	}
+
+	return obj, obj.Validate()
+}
+
+func (obj Spec) Validate() error {
+	if obj.VMID == 0 {
+		return fmt.Errorf("spec has no vmid")
+	}
+
+	if obj.Node == "" {
+		return fmt.Errorf("spec has no node")
+	}
+
+	if err := obj.Kind.IsValid(); err != nil {
+		return err
+	}
+
+	for _, tag := range obj.Tags {
+		if !vm.IsValidTag(tag) {
+			return vm.ErrInvalidTag
+		}
+	}
+
+	diskNameRegExp := qemuDiskNameRegExp
+	if obj.Kind == vm.KindLXC {
+		diskNameRegExp = lxcDiskNameRegExp
+	}
+
+	disks := make(map[string]struct{}, len(obj.Disks))
+
+	for _, disk := range obj.Disks {
+		if !diskNameRegExp.MatchString(disk.Name) {
+			return fmt.Errorf("invalid %s disk name %s", obj.Kind, disk.Name)
+		} else if _, ok := disks[disk.Name]; ok {
+			return fmt.Errorf("duplicated disk %s", disk.Name)
+		}
+
+		disks[disk.Name] = struct{}{}
+
+		if disk.Storage == "" {
+			return fmt.Errorf("disk %s has no storage", disk.Name)
+		}
+
+		if _, err := vm.NewDiskSize(disk.Size); err != nil {
+			return err
+		}
+
+		if mountPointNameRegExp.MatchString(disk.Name) && disk.MountPoint == "" {
+			return fmt.Errorf("mount point %s has no path", disk.Name)
+		}
+	}
+
+	networks := make(map[string]struct{}, len(obj.Networks))
+
+	for _, network := range obj.Networks {
+		if !networkNameRegExp.MatchString(network.Name) {
+			return fmt.Errorf("invalid network interface name %s", network.Name)
+		} else if _, ok := networks[network.Name]; ok {
+			return fmt.Errorf("duplicated network interface %s", network.Name)
+		}
+
+		networks[network.Name] = struct{}{}
+	}
+
+	switch obj.Kind {
+	case vm.KindQEMU:
+		if obj.OSTemplate != "" || obj.Unprivileged != nil {
+			return fmt.Errorf("container options set on a qemu spec")
+		}
+
+		if obj.Memory != nil && obj.Memory.Swap != nil {
+			return fmt.Errorf("swap can't be set on a qemu spec")
+		}
+	case vm.KindLXC:
+		if obj.CloudInit != nil {
+			return fmt.Errorf("cloud-init can't be set on a lxc spec")
+		}
+
+		if obj.CPU != nil && (obj.CPU.Type != "" || obj.CPU.Sockets != 0) {
+			return fmt.Errorf("cpu type and sockets can't be set on a lxc spec")
+		}
+
+		if obj.Memory != nil && obj.Memory.Minimum != nil {
+			return fmt.Errorf("memory minimum can't be set on a lxc spec")
+		}
+	}
+
+	if obj.Firewall != nil {
+		for _, policy := range []string{obj.Firewall.PolicyIn, obj.Firewall.PolicyOut} {
+			if policy == "" {
+				continue
+			}
+
+			var action firewall.Action
+			if err := (&action).Unmarshal(policy); err != nil {
+				return err
+			}
+		}
+	}
+
+	if obj.HA != nil && obj.HA.State != "" && !obj.HA.State.IsValid() {
+		return fmt.Errorf("invalid high availability state %s", obj.HA.State)
+	}
+
+	return nil
+}
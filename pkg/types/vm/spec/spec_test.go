@@ -0,0 +1,94 @@
+package spec_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+	"github.com/xabinapal/gopve/pkg/types/vm/spec"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		s, err := spec.Parse([]byte(`
+vmid: 100
+node: test_node
+kind: qemu
+name: web
+tags: [prod, web]
+cpu:
+  type: host
+  cores: 4
+memory:
+  size: 4096
+  minimum: 2048
+disks:
+  - name: scsi0
+    storage: local-lvm
+    size: 32G
+networks:
+  - name: net0
+    bridge: vmbr0
+    ip: dhcp
+pool: prod
+ha:
+  state: started
+  max_restart: 3
+`))
+		require.NoError(t, err)
+
+		minimum, maxRestart, pool := uint(2048), uint(3), "prod"
+
+		assert.Equal(t, spec.Spec{
+			VMID:   100,
+			Node:   "test_node",
+			Kind:   vm.KindQEMU,
+			Name:   "web",
+			Tags:   []string{"prod", "web"},
+			CPU:    &spec.CPUSpec{Type: "host", Cores: 4},
+			Memory: &spec.MemorySpec{Size: 4096, Minimum: &minimum},
+			Disks: []spec.DiskSpec{
+				{Name: "scsi0", Storage: "local-lvm", Size: "32G"},
+			},
+			Networks: []spec.NetworkSpec{
+				{Name: "net0", Bridge: "vmbr0", IPv4: "dhcp"},
+			},
+			Pool: &pool,
+			HA:   &spec.HASpec{State: vm.HAStateStarted, MaxRestart: &maxRestart},
+		}, s)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		s, err := spec.Parse([]byte(`{"vmid":200,"node":"test_node","kind":"lxc","ostemplate":"local:vztmpl/debian.tar.zst","tags":[]}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, vm.KindLXC, s.Kind)
+		assert.Equal(t, "local:vztmpl/debian.tar.zst", s.OSTemplate)
+		assert.NotNil(t, s.Tags)
+		assert.Empty(t, s.Tags)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for n, tc := range map[string]string{
+			"NoVMID":        `{"node":"n","kind":"qemu"}`,
+			"NoNode":        `{"vmid":100,"kind":"qemu"}`,
+			"Kind":          `{"vmid":100,"node":"n","kind":"xen"}`,
+			"Tag":           `{"vmid":100,"node":"n","kind":"qemu","tags":["a b"]}`,
+			"DiskName":      `{"vmid":100,"node":"n","kind":"qemu","disks":[{"name":"rootfs","storage":"s","size":"8G"}]}`,
+			"DiskSize":      `{"vmid":100,"node":"n","kind":"qemu","disks":[{"name":"scsi0","storage":"s","size":"big"}]}`,
+			"MountPoint":    `{"vmid":100,"node":"n","kind":"lxc","disks":[{"name":"mp0","storage":"s","size":"8G"}]}`,
+			"DuplicatedNIC": `{"vmid":100,"node":"n","kind":"qemu","networks":[{"name":"net0"},{"name":"net0"}]}`,
+			"CloudInit":     `{"vmid":100,"node":"n","kind":"lxc","cloudinit":{"user":"root"}}`,
+			"Template":      `{"vmid":100,"node":"n","kind":"qemu","ostemplate":"t"}`,
+			"Policy":        `{"vmid":100,"node":"n","kind":"qemu","firewall":{"policy_in":"ALLOW"}}`,
+			"HAState":       `{"vmid":100,"node":"n","kind":"qemu","ha":{"state":"on"}}`,
+		} {
+			tc := tc
+			t.Run(n, func(t *testing.T) {
+				_, err := spec.Parse([]byte(tc))
+				assert.Error(t, err)
+			})
+		}
+	})
+}
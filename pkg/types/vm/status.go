@@ -2,6 +2,7 @@ package vm
 
 import (
 	"fmt"
+	"time"
 )
 
 type Status string
@@ -19,3 +20,54 @@ func (obj Status) IsValid() error {
 		return fmt.Errorf("invalid virtual machine status")
 	}
 }
+
+type CurrentStatus struct {
+	Status Status
+	// QMPStatus is the detailed state reported by the QEMU monitor, and is
+	// empty for LXC containers and stopped QEMU machines.
+	QMPStatus QMPStatus
+
+	Uptime time.Duration
+	PID    uint
+	Lock   string
+
+	CPU  float64
+	CPUs uint
+
+	Memory      uint64
+	MemoryTotal uint64
+	Balloon     uint64
+	Swap        uint64
+	SwapTotal   uint64
+
+	Disk      uint64
+	DiskTotal uint64
+	DiskRead  uint64
+	DiskWrite uint64
+
+	NetIn  uint64
+	NetOut uint64
+
+	HA HAStatus
+
+	RunningMachine string
+	RunningQEMU    string
+
+	AgentEnabled bool
+}
+
+func (obj CurrentStatus) IsRunning() bool {
+	return obj.Status == StatusRunning
+}
+
+func (obj CurrentStatus) IsPaused() bool {
+	return obj.QMPStatus == QMPStatusPaused ||
+		obj.QMPStatus == QMPStatusSuspended ||
+		obj.QMPStatus == QMPStatusPrelaunch
+}
+
+type HAStatus struct {
+	Managed bool
+	State   string
+	Group   string
+}
@@ -0,0 +1,124 @@
+package vm
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var tagRegexp = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_\-+.]*$`)
+
+func IsValidTag(tag string) bool {
+	return tagRegexp.MatchString(tag)
+}
+
+// Tags is the set of tags of a guest. PVE accepts semicolons, commas and
+// spaces as separators, and always writes them separated by semicolons. The
+// empty set is nil.
+type Tags map[string]struct{}
+
+func NewTags(tags ...string) Tags {
+	var obj Tags
+	obj.Add(tags...)
+
+	return obj
+}
+
+func (obj Tags) Has(tag string) bool {
+	_, ok := obj[tag]
+	return ok
+}
+
+func (obj *Tags) Add(tags ...string) {
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			if *obj == nil {
+				*obj = Tags{}
+			}
+
+			(*obj)[tag] = struct{}{}
+		}
+	}
+}
+
+func (obj *Tags) Remove(tags ...string) {
+	for _, tag := range tags {
+		delete(*obj, strings.TrimSpace(tag))
+	}
+
+	if len(*obj) == 0 {
+		*obj = nil
+	}
+}
+
+// List returns the tags sorted alphabetically.
+func (obj Tags) List() []string {
+	tags := make([]string, 0, len(obj))
+	for tag := range obj {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
+
+func (obj Tags) Copy() Tags {
+	return NewTags(obj.List()...)
+}
+
+func (obj Tags) Marshal() (string, error) {
+	return strings.Join(obj.List(), ";"), nil
+}
+
+func (obj *Tags) Unmarshal(s string) error {
+	*obj = NewTags(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	})...)
+
+	return nil
+}
+
+func (obj *Tags) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return obj.Unmarshal(s)
+}
+
+// TagFilter selects guests by their tags. Every non-empty condition must
+// hold for a guest to match.
+type TagFilter struct {
+	AllOf  []string
+	AnyOf  []string
+	NoneOf []string
+}
+
+func (obj TagFilter) Matches(tags Tags) bool {
+	for _, tag := range obj.AllOf {
+		if !tags.Has(tag) {
+			return false
+		}
+	}
+
+	for _, tag := range obj.NoneOf {
+		if tags.Has(tag) {
+			return false
+		}
+	}
+
+	if len(obj.AnyOf) == 0 {
+		return true
+	}
+
+	for _, tag := range obj.AnyOf {
+		if tags.Has(tag) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestTags(t *testing.T) {
+	var tags vm.Tags
+	require.NoError(t, (&tags).Unmarshal("prod;owner-ops,tier.gold web"))
+
+	assert.Equal(t, []string{"owner-ops", "prod", "tier.gold", "web"}, tags.List())
+	assert.True(t, tags.Has("prod"))
+	assert.False(t, tags.Has("dev"))
+
+	tags.Add("dev", " ")
+	tags.Remove("web", "prod")
+
+	s, err := tags.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, "dev;owner-ops;tier.gold", s)
+
+	tags.Remove(tags.List()...)
+	assert.Nil(t, tags)
+
+	require.NoError(t, (&tags).Unmarshal(""))
+	assert.Nil(t, tags)
+
+	assert.True(t, vm.IsValidTag("tier.gold"))
+	assert.False(t, vm.IsValidTag("-tag"))
+	assert.False(t, vm.IsValidTag("two words"))
+}
+
+func TestTagFilter(t *testing.T) {
+	tags := vm.NewTags("prod", "web", "tier-gold")
+
+	for name, tc := range map[string]struct {
+		Filter  vm.TagFilter
+		Matches bool
+	}{
+		"Empty":       {vm.TagFilter{}, true},
+		"AllOf":       {vm.TagFilter{AllOf: []string{"prod", "web"}}, true},
+		"AllOfMiss":   {vm.TagFilter{AllOf: []string{"prod", "db"}}, false},
+		"AnyOf":       {vm.TagFilter{AnyOf: []string{"db", "web"}}, true},
+		"AnyOfMiss":   {vm.TagFilter{AnyOf: []string{"db", "cache"}}, false},
+		"NoneOf":      {vm.TagFilter{NoneOf: []string{"dev"}}, true},
+		"NoneOfMatch": {vm.TagFilter{NoneOf: []string{"prod"}}, false},
+		"Combined": {vm.TagFilter{
+			AllOf:  []string{"prod"},
+			AnyOf:  []string{"tier-gold", "tier-silver"},
+			NoneOf: []string{"dev"},
+		}, true},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Matches, tc.Filter.Matches(tags))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package vm
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type LinkedClone struct {
+	VMID uint
+	// Volume is the clone disk, like local-lvm:base-100-disk-0/vm-101-disk-0.
+	Volume string
+}
+
+var baseVolumeRegexp = regexp.MustCompile(`^base-(\d+)-disk-\d+`)
+
+// ParseLinkedCloneVolume returns the VMID of the template whose base disk
+// the volume is linked to. Volumes that are not linked clones, including
+// the base disks themselves, return false.
+func ParseLinkedCloneVolume(volume string) (uint, bool) {
+	if i := strings.Index(volume, ":"); i != -1 {
+		volume = volume[i+1:]
+	}
+
+	segments := strings.Split(volume, "/")
+
+	// The last segment is always the disk itself, its parent is the
+	// nearest base disk before it.
+	for i := len(segments) - 2; i >= 0; i-- {
+		match := baseVolumeRegexp.FindStringSubmatch(segments[i])
+		if match == nil {
+			continue
+		}
+
+		vmid, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		return uint(vmid), true
+	}
+
+	return 0, false
+}
+
+// LinkedCloneGraph maps every template to the guests whose disks are linked
+// clones of its base disks. Unreadable lists the guests whose configuration
+// couldn't be read, like the ones on offline nodes, so their clones are
+// missing from the graph.
+type LinkedCloneGraph struct {
+	clones map[uint][]LinkedClone
+
+	Unreadable []uint
+}
+
+// NewLinkedCloneGraph builds the graph from the disk volumes of each guest,
+// keyed by VMID.
+func NewLinkedCloneGraph(volumes map[uint][]string) LinkedCloneGraph {
+	graph := LinkedCloneGraph{
+		clones: make(map[uint][]LinkedClone),
+	}
+
+	for vmid, guestVolumes := range volumes {
+		for _, volume := range guestVolumes {
+			template, ok := ParseLinkedCloneVolume(volume)
+			if !ok || template == vmid {
+				continue
+			}
+
+			graph.clones[template] = append(graph.clones[template], LinkedClone{
+				VMID:   vmid,
+				Volume: volume,
+			})
+		}
+	}
+
+	for _, clones := range graph.clones {
+		sort.Slice(clones, func(i, j int) bool {
+			if clones[i].VMID != clones[j].VMID {
+				return clones[i].VMID < clones[j].VMID
+			}
+
+			return clones[i].Volume < clones[j].Volume
+		})
+	}
+
+	return graph
+}
+
+// Templates returns the VMIDs of the templates with at least one linked
+// clone.
+func (graph LinkedCloneGraph) Templates() []uint {
+	templates := make([]uint, 0, len(graph.clones))
+	for vmid := range graph.clones {
+		templates = append(templates, vmid)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i] < templates[j]
+	})
+
+	return templates
+}
+
+func (graph LinkedCloneGraph) Clones(template uint) []LinkedClone {
+	return graph.clones[template]
+}
+
+// Dependents returns the VMIDs of the guests linked to the template.
+func (graph LinkedCloneGraph) Dependents(template uint) []uint {
+	var dependents []uint
+
+	for _, clone := range graph.clones[template] {
+		if len(dependents) == 0 || dependents[len(dependents)-1] != clone.VMID {
+			dependents = append(dependents, clone.VMID)
+		}
+	}
+
+	return dependents
+}
@@ -0,0 +1,66 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xabinapal/gopve/pkg/types/vm"
+)
+
+func TestParseLinkedCloneVolume(t *testing.T) {
+	options := map[string]struct {
+		Volume   string
+		Template uint
+		Linked   bool
+	}{
+		"LVMThin": {
+			Volume:   "local-lvm:base-100-disk-0/vm-101-disk-0",
+			Template: 100,
+			Linked:   true,
+		},
+		"Directory": {
+			Volume:   "local:100/base-100-disk-0.qcow2/101/vm-101-disk-0.qcow2",
+			Template: 100,
+			Linked:   true,
+		},
+		"ChainedTemplate": {
+			Volume:   "local-lvm:base-100-disk-0/base-101-disk-0",
+			Template: 100,
+			Linked:   true,
+		},
+		"BaseDisk": {
+			Volume: "local-lvm:base-100-disk-0",
+		},
+		"FullClone": {
+			Volume: "local-lvm:vm-101-disk-0",
+		},
+	}
+
+	for n, tt := range options {
+		tt := tt
+
+		t.Run(n, func(t *testing.T) {
+			template, ok := vm.ParseLinkedCloneVolume(tt.Volume)
+			assert.Equal(t, tt.Linked, ok)
+			assert.Equal(t, tt.Template, template)
+		})
+	}
+}
+
+func TestLinkedCloneGraph(t *testing.T) {
+	graph := vm.NewLinkedCloneGraph(map[uint][]string{
+		100: {"local-lvm:base-100-disk-0", "local-lvm:base-100-disk-1"},
+		101: {
+			"local-lvm:base-100-disk-0/vm-101-disk-0",
+			"local-lvm:base-100-disk-1/vm-101-disk-1",
+		},
+		102: {"local-lvm:base-100-disk-0/vm-102-disk-0"},
+		103: {"local-lvm:vm-103-disk-0"},
+		200: {"local:iso/debian.iso"},
+	})
+
+	assert.Equal(t, []uint{100}, graph.Templates())
+	assert.Equal(t, []uint{101, 102}, graph.Dependents(100))
+	assert.Len(t, graph.Clones(100), 3)
+	assert.Empty(t, graph.Dependents(103))
+}
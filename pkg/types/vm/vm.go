@@ -1,9 +1,15 @@
 package vm
 
 import (
+	"fmt"
+
+	internal_types "github.com/xabinapal/gopve/internal/types"
+	"github.com/xabinapal/gopve/pkg/request"
 	"github.com/xabinapal/gopve/pkg/types"
+	"github.com/xabinapal/gopve/pkg/types/console"
 	"github.com/xabinapal/gopve/pkg/types/errors"
 	"github.com/xabinapal/gopve/pkg/types/firewall"
+	"github.com/xabinapal/gopve/pkg/types/metrics"
 	"github.com/xabinapal/gopve/pkg/types/task"
 )
 
@@ -15,28 +21,62 @@ type VirtualMachine interface {
 	Name() string
 	Template() bool
 
+	ConvertToTemplate() error
+
 	GetProperties() (Properties, error)
 
 	Description() (string, error)
 
+	Tags() (Tags, error)
+	AddTags(tags ...string) error
+	RemoveTags(tags ...string) error
+
 	Digest() (string, error)
 
+	GetPendingChanges() (PendingChanges, error)
+	RebootRequired() (bool, error)
+
 	GetStatus() (Status, error)
+	GetCurrentStatus() (CurrentStatus, error)
+	GetMetrics(opts metrics.Options) (metrics.GuestMetrics, error)
+
+	VNCProxy(opts console.VNCProxyOptions) (console.VNCTicket, error)
+	SPICEProxy(opts console.SPICEProxyOptions) (console.SPICETicket, error)
+	TermProxy(opts console.TermProxyOptions) (console.TermTicket, error)
+	OpenTerminal(opts console.TermProxyOptions) (*console.Terminal, error)
 
 	Clone(options CloneOptions) (task.Task, error)
+	Backup(opts BackupOptions) (task.Task, error)
+
+	GetMigrationPreconditions(target string) (MigrationPreconditions, error)
+	Migrate(target string, options MigrateOptions) (task.Task, error)
 
 	Start() (task.Task, error)
 	Stop() (task.Task, error)
 	Reset() (task.Task, error)
 	Shutdown() (task.Task, error)
+	ShutdownWithOptions(opts ShutdownOptions) (task.Task, error)
 	Reboot() (task.Task, error)
 	Suspend() (task.Task, error)
 	Resume() (task.Task, error)
 
 	ListSnapshots() ([]Snapshot, error)
 	GetSnapshot(name string) (Snapshot, error)
+	GetSnapshotTree() (*SnapshotTree, error)
 	CreateSnapshot(name string, props SnapshotProperties) (task.Task, error)
 	RollbackToSnapshot(name string) (task.Task, error)
+	// PruneSnapshots deletes, one at a time and oldest first, every snapshot
+	// not kept by the retention policy, and returns the finished tasks.
+	PruneSnapshots(retention SnapshotRetention) ([]task.Task, error)
+
+	// GetHAProperties returns nil when the guest is not an HA resource.
+	GetHAProperties() (*HAProperties, error)
+	// SetHAProperties adds or updates the HA resource, or removes it when
+	// props is nil.
+	SetHAProperties(props *HAProperties) error
+
+	ListReplicationJobs() ([]ReplicationJob, error)
+	CreateReplicationJob(props ReplicationJobProperties) (ReplicationJob, error)
 
 	GetFirewallLog(opts firewall.GetLogOptions) (firewall.LogEntries, error)
 	GetFirewallProperties() (firewall.VMProperties, error)
@@ -68,6 +108,8 @@ type Properties struct {
 	StartDelay      int
 	ShutdownTimeout int
 
+	Tags Tags
+
 	Digest string
 }
 
@@ -81,6 +123,8 @@ const (
 	mkKeyPropertyStartDelay      = "up"
 	mkKeyPropertyShutdownTimeout = "down"
 
+	mkPropertyTags = "tags"
+
 	mkPropertyDigest = "digest"
 )
 
@@ -112,6 +156,14 @@ func NewProperties(props types.Properties) (obj Properties, err error) {
 				nil,
 			)
 		},
+		func() (err error) {
+			return props.SetBool(
+				mkPropertyStartOnBoot,
+				&obj.StartOnBoot,
+				DefaultPropertyStartOnBoot,
+				nil,
+			)
+		},
 		func() (err error) {
 			startupOptions, err := props.GetAsDict(
 				mkDictPropertyStartup,
@@ -150,8 +202,61 @@ func NewProperties(props types.Properties) (obj Properties, err error) {
 				},
 			)
 		},
+		func() (err error) {
+			var tags string
+			if err := props.SetString(mkPropertyTags, &tags, "", nil); err != nil {
+				return err
+			}
+
+			return (&obj.Tags).Unmarshal(tags)
+		},
 		func() (err error) {
 			return props.SetRequiredString(mkPropertyDigest, &obj.Digest, nil)
 		},
 	)
 }
+
+func (obj Properties) MapToValues() (request.Values, error) {
+	values := request.Values{}
+
+	values.ConditionalAddString(
+		mkPropertyDescription,
+		obj.Description,
+		obj.Description != DefaultPropertyDescription,
+	)
+	values.AddBool(mkPropertyProtected, obj.Protected)
+
+	values.AddBool(mkPropertyStartOnBoot, obj.StartOnBoot)
+
+	startup := internal_types.PVEList{Separator: ","}
+
+	if obj.StartupOrder != DefaultPropertyStartupOrder {
+		startup.Append(
+			fmt.Sprintf("%s=%d", mkKeyPropertyStartupOrder, obj.StartupOrder),
+		)
+	}
+
+	if obj.StartDelay != DefaultPropertyStartDelay {
+		startup.Append(
+			fmt.Sprintf("%s=%d", mkKeyPropertyStartDelay, obj.StartDelay),
+		)
+	}
+
+	if obj.ShutdownTimeout != DefaultPropertyShutdownTimeout {
+		startup.Append(
+			fmt.Sprintf(
+				"%s=%d",
+				mkKeyPropertyShutdownTimeout,
+				obj.ShutdownTimeout,
+			),
+		)
+	}
+
+	values.ConditionalAddObject(mkDictPropertyStartup, startup, startup.Len() != 0)
+
+	values.ConditionalAddObject(mkPropertyTags, obj.Tags, len(obj.Tags) != 0)
+
+	values.ConditionalAddString(mkPropertyDigest, obj.Digest, obj.Digest != "")
+
+	return values, nil
+}